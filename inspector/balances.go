@@ -0,0 +1,285 @@
+package inspector
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+
+	"github.com/scatkit/pumpdexer/rpc"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// ErrNoSwap is returned when the balance changes of a trader are not a swap
+// of exactly one asset for another.
+var ErrNoSwap = errors.New("no swap found")
+
+// SOLDecimals is the number of decimals of a SOL amount in lamports.
+const SOLDecimals = 9
+
+// SOLDelta is the change of the lamports of an account.
+type SOLDelta struct {
+	Account solana.PublicKey `json:"account"`
+	Pre     uint64           `json:"pre"`
+	Post    uint64           `json:"post"`
+	Delta   int64            `json:"delta"`
+}
+
+// TokenDelta is the change of the balance of a mint held by an owner,
+// summed over the token accounts of the owner the transaction touched.
+type TokenDelta struct {
+	Owner    solana.PublicKey   `json:"owner"`
+	Mint     solana.PublicKey   `json:"mint"`
+	Decimals uint8              `json:"decimals"`
+	Accounts []solana.PublicKey `json:"accounts"`
+	Pre      uint64             `json:"pre"`
+	Post     uint64             `json:"post"`
+	Delta    *big.Int           `json:"delta"`
+}
+
+// BalanceChanges are the balance changes of a confirmed transaction.
+type BalanceChanges struct {
+	FeePayer solana.PublicKey `json:"feePayer"`
+	Fee      uint64           `json:"fee"`
+	// Whether the transaction failed; only the fee was then charged.
+	Failed bool `json:"failed"`
+	// One per account of the transaction, in the order of the accounts.
+	SOL []SOLDelta `json:"sol"`
+	// One per owner and mint, in the order of the token balances of the meta.
+	Tokens []TokenDelta `json:"tokens"`
+}
+
+// Swap is a swap of one asset for another by a trader.
+// SOL is reported as solana.SolMint, whether it was native or wrapped.
+type Swap struct {
+	Trader solana.PublicKey `json:"trader"`
+
+	// What the trader gave.
+	InputMint     solana.PublicKey `json:"inputMint"`
+	InputAmount   uint64           `json:"inputAmount"`
+	InputDecimals uint8            `json:"inputDecimals"`
+
+	// What the trader received.
+	OutputMint     solana.PublicKey `json:"outputMint"`
+	OutputAmount   uint64           `json:"outputAmount"`
+	OutputDecimals uint8            `json:"outputDecimals"`
+
+	// Input paid per unit of output, in UI amounts.
+	Price float64 `json:"price"`
+	// Transaction fee in lamports, if the trader paid it; not part of the input.
+	Fee uint64 `json:"fee"`
+}
+
+// GetBalanceChanges returns the SOL and token balance changes of a transaction
+// fetched with rpc.Client.GetTransaction (in a binary encoding).
+func GetBalanceChanges(result *rpc.GetTransactionResult) (*BalanceChanges, error) {
+	if result == nil || result.Transaction == nil {
+		return nil, errors.New("transaction result is empty")
+	}
+	if result.Meta == nil {
+		return nil, errors.New("transaction meta is empty")
+	}
+	tx, err := result.Transaction.GetTransaction()
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode transaction: %w", err)
+	}
+	if tx == nil {
+		return nil, errors.New("transaction result is empty")
+	}
+	return BalanceChangesOf(tx, result.Meta)
+}
+
+// BalanceChangesOf returns the SOL and token balance changes of a transaction, from its meta.
+func BalanceChangesOf(tx *solana.Transaction, meta *rpc.TransactionMeta) (*BalanceChanges, error) {
+	// The balances index the static accounts, then the loaded writable and readonly ones.
+	keys := make(solana.PublicKeySlice, 0, len(tx.Message.AccountKeys)+len(meta.LoadedAddresses.Writable)+len(meta.LoadedAddresses.ReadOnly))
+	keys = append(keys, tx.Message.AccountKeys...)
+	keys = append(keys, meta.LoadedAddresses.Writable...)
+	keys = append(keys, meta.LoadedAddresses.ReadOnly...)
+	if len(keys) == 0 {
+		return nil, errors.New("transaction has no accounts")
+	}
+	if len(meta.PreBalances) != len(keys) || len(meta.PostBalances) != len(keys) {
+		return nil, fmt.Errorf("the meta has %d pre and %d post balances for %d accounts",
+			len(meta.PreBalances), len(meta.PostBalances), len(keys))
+	}
+
+	out := &BalanceChanges{
+		FeePayer: keys[0],
+		Fee:      meta.Fee,
+		Failed:   meta.Err != nil,
+		SOL:      make([]SOLDelta, len(keys)),
+	}
+	for i, key := range keys {
+		pre, post := meta.PreBalances[i], meta.PostBalances[i]
+		out.SOL[i] = SOLDelta{Account: key, Pre: pre, Post: post, Delta: int64(post) - int64(pre)}
+	}
+
+	type ownerMint struct{ owner, mint solana.PublicKey }
+	positions := make(map[ownerMint]int)
+	add := func(balance rpc.TokenBalance, post bool) error {
+		if int(balance.AccountIndex) >= len(keys) {
+			return fmt.Errorf("token balance of account %d, out of %d", balance.AccountIndex, len(keys))
+		}
+		account := keys[balance.AccountIndex]
+		if balance.UiTokenAmount == nil {
+			return fmt.Errorf("token balance of %s has no amount", account)
+		}
+		amount, err := strconv.ParseUint(balance.UiTokenAmount.Amount, 10, 64)
+		if err != nil {
+			return fmt.Errorf("token balance of %s: %w", account, err)
+		}
+		// Nodes only omit the owner for old transactions: the account stands for it.
+		owner := account
+		if balance.Owner != nil {
+			owner = *balance.Owner
+		}
+		key := ownerMint{owner, balance.Mint}
+		pos, ok := positions[key]
+		if !ok {
+			pos = len(out.Tokens)
+			positions[key] = pos
+			out.Tokens = append(out.Tokens, TokenDelta{Owner: owner, Mint: balance.Mint, Decimals: balance.UiTokenAmount.Decimals})
+		}
+		delta := &out.Tokens[pos]
+		if !containsKey(delta.Accounts, account) {
+			delta.Accounts = append(delta.Accounts, account)
+		}
+		if post {
+			delta.Post += amount
+		} else {
+			delta.Pre += amount
+		}
+		return nil
+	}
+	for _, balance := range meta.PreTokenBalances {
+		if err := add(balance, false); err != nil {
+			return nil, err
+		}
+	}
+	for _, balance := range meta.PostTokenBalances {
+		if err := add(balance, true); err != nil {
+			return nil, err
+		}
+	}
+	for i := range out.Tokens {
+		delta := &out.Tokens[i]
+		delta.Delta = new(big.Int).Sub(new(big.Int).SetUint64(delta.Post), new(big.Int).SetUint64(delta.Pre))
+	}
+	return out, nil
+}
+
+// SOLDeltaOf returns the lamports change of an account, 0 if not in the transaction.
+func (changes *BalanceChanges) SOLDeltaOf(account solana.PublicKey) int64 {
+	for _, delta := range changes.SOL {
+		if delta.Account == account {
+			return delta.Delta
+		}
+	}
+	return 0
+}
+
+// TokenDeltaOf returns the balance change of a mint held by an owner, nil if unchanged or not in the transaction.
+func (changes *BalanceChanges) TokenDeltaOf(owner, mint solana.PublicKey) *TokenDelta {
+	for i := range changes.Tokens {
+		if changes.Tokens[i].Owner == owner && changes.Tokens[i].Mint == mint {
+			if changes.Tokens[i].Delta.Sign() == 0 {
+				return nil
+			}
+			return &changes.Tokens[i]
+		}
+	}
+	return nil
+}
+
+// Swap infers the swap executed by a trader: the one asset whose balance decreased
+// for the one whose balance increased.
+//
+// The SOL side is the lamports change of the trader's wallet and of its token accounts,
+// so that wrapping and unwrapping, and the rent of the token accounts it opened or closed,
+// cancel out; the transaction fee is not part of it. When the trader swapped one token for
+// another, a SOL decrease (e.g. a tip) is ignored.
+func (changes *BalanceChanges) Swap(trader solana.PublicKey) (*Swap, error) {
+	if changes.Failed {
+		return nil, fmt.Errorf("%w: the transaction failed", ErrNoSwap)
+	}
+	lamports := changes.SOLDeltaOf(trader)
+	if trader == changes.FeePayer {
+		lamports += int64(changes.Fee)
+	}
+
+	type side struct {
+		mint     solana.PublicKey
+		amount   uint64
+		decimals uint8
+	}
+	var inputs, outputs []side
+	for _, delta := range changes.Tokens {
+		if delta.Owner != trader {
+			continue
+		}
+		for _, account := range delta.Accounts {
+			if account != trader {
+				lamports += changes.SOLDeltaOf(account)
+			}
+		}
+		if delta.Mint == solana.SolMint {
+			continue
+		}
+		switch {
+		case delta.Post < delta.Pre:
+			inputs = append(inputs, side{delta.Mint, delta.Pre - delta.Post, delta.Decimals})
+		case delta.Post > delta.Pre:
+			outputs = append(outputs, side{delta.Mint, delta.Post - delta.Pre, delta.Decimals})
+		}
+	}
+	switch {
+	case lamports < 0 && len(inputs) == 0:
+		inputs = append(inputs, side{solana.SolMint, uint64(-lamports), SOLDecimals})
+	case lamports > 0 && len(outputs) == 0:
+		outputs = append(outputs, side{solana.SolMint, uint64(lamports), SOLDecimals})
+	}
+	if len(inputs) != 1 || len(outputs) != 1 {
+		return nil, fmt.Errorf("%w: %s has %d decreased and %d increased balances", ErrNoSwap, trader, len(inputs), len(outputs))
+	}
+
+	in, out := inputs[0], outputs[0]
+	swap := &Swap{
+		Trader:         trader,
+		InputMint:      in.mint,
+		InputAmount:    in.amount,
+		InputDecimals:  in.decimals,
+		OutputMint:     out.mint,
+		OutputAmount:   out.amount,
+		OutputDecimals: out.decimals,
+		Price:          uiAmount(in.amount, in.decimals) / uiAmount(out.amount, out.decimals),
+	}
+	if trader == changes.FeePayer {
+		swap.Fee = changes.Fee
+	}
+	return swap, nil
+}
+
+// GetSwap infers the swap executed by the fee payer of a transaction
+// fetched with rpc.Client.GetTransaction (in a binary encoding).
+func GetSwap(result *rpc.GetTransactionResult) (*Swap, error) {
+	changes, err := GetBalanceChanges(result)
+	if err != nil {
+		return nil, err
+	}
+	return changes.Swap(changes.FeePayer)
+}
+
+func uiAmount(amount uint64, decimals uint8) float64 {
+	return float64(amount) / math.Pow10(int(decimals))
+}
+
+func containsKey(keys []solana.PublicKey, key solana.PublicKey) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
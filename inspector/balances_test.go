@@ -0,0 +1,162 @@
+package inspector
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/scatkit/pumpdexer/rpc"
+	"github.com/scatkit/pumpdexer/solana"
+	"github.com/stretchr/testify/require"
+)
+
+func tokenBalance(index uint16, owner, mint solana.PublicKey, amount string, decimals uint8) rpc.TokenBalance {
+	return rpc.TokenBalance{
+		AccountIndex:  index,
+		Owner:         &owner,
+		Mint:          mint,
+		UiTokenAmount: &rpc.UiTokenAmount{Amount: amount, Decimals: decimals},
+	}
+}
+
+func TestTokenDeltaOf_Unchanged(t *testing.T) {
+	owner := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+	changes := &BalanceChanges{Tokens: []TokenDelta{{Owner: owner, Mint: mint, Pre: 5, Post: 5, Delta: new(big.Int)}}}
+	require.Nil(t, changes.TokenDeltaOf(owner, mint))
+	require.Nil(t, changes.TokenDeltaOf(mint, owner))
+
+	changes.Tokens[0].Post, changes.Tokens[0].Delta = 7, big.NewInt(2)
+	require.Equal(t, big.NewInt(2), changes.TokenDeltaOf(owner, mint).Delta)
+}
+
+func TestBalanceChanges_Buy(t *testing.T) {
+	trader := solana.NewWallet().PublicKey()
+	traderATA := solana.NewWallet().PublicKey()
+	pool := solana.NewWallet().PublicKey()
+	poolWSOL := solana.NewWallet().PublicKey()
+	poolVault := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+	const rent = 2_039_280
+
+	tx, err := solana.NewTransaction([]solana.Instruction{rawInstruction{
+		programID: solana.NewWallet().PublicKey(),
+		accounts: []*solana.AccountMeta{
+			solana.Meta(trader).WRITE().SIGNER(),
+			solana.Meta(traderATA).WRITE(),
+			solana.Meta(poolWSOL).WRITE(),
+			solana.Meta(poolVault).WRITE(),
+		},
+	}}, solana.Hash{})
+	require.NoError(t, err)
+	require.Equal(t, solana.PublicKeySlice{trader, traderATA, poolWSOL, poolVault}, tx.Message.AccountKeys[:4])
+
+	// The trader opens its token account and pays 1 SOL (+ fee and rent) for 2_000 tokens.
+	meta := &rpc.TransactionMeta{
+		Fee:          5_000,
+		PreBalances:  []uint64{10_000_000_000, 0, rent + 50_000_000_000, rent, 1},
+		PostBalances: []uint64{10_000_000_000 - 1_000_000_000 - 5_000 - rent, rent, rent + 51_000_000_000, rent, 1},
+		PreTokenBalances: []rpc.TokenBalance{
+			tokenBalance(2, pool, solana.SolMint, "50000000000", 9),
+			tokenBalance(3, pool, mint, "1000000000000", 6),
+		},
+		PostTokenBalances: []rpc.TokenBalance{
+			tokenBalance(1, trader, mint, "2000000000", 6),
+			tokenBalance(2, pool, solana.SolMint, "51000000000", 9),
+			tokenBalance(3, pool, mint, "998000000000", 6),
+		},
+	}
+	changes, err := BalanceChangesOf(tx, meta)
+	require.NoError(t, err)
+
+	require.Equal(t, trader, changes.FeePayer)
+	require.Len(t, changes.SOL, 5)
+	require.Equal(t, int64(-1_000_000_000-5_000-rent), changes.SOLDeltaOf(trader))
+	require.Equal(t, int64(rent), changes.SOLDeltaOf(traderATA))
+
+	require.Len(t, changes.Tokens, 3)
+	bought := changes.TokenDeltaOf(trader, mint)
+	require.NotNil(t, bought)
+	require.Equal(t, []solana.PublicKey{traderATA}, bought.Accounts)
+	require.Equal(t, big.NewInt(2_000_000_000), bought.Delta)
+	require.Equal(t, big.NewInt(-2_000_000_000), changes.TokenDeltaOf(pool, mint).Delta)
+
+	swap, err := changes.Swap(trader)
+	require.NoError(t, err)
+	require.Equal(t, &Swap{
+		Trader:         trader,
+		InputMint:      solana.SolMint,
+		InputAmount:    1_000_000_000,
+		InputDecimals:  9,
+		OutputMint:     mint,
+		OutputAmount:   2_000_000_000,
+		OutputDecimals: 6,
+		Price:          0.0005,
+		Fee:            5_000,
+	}, swap)
+
+	// Seen from the pool, it is the opposite swap.
+	swap, err = changes.Swap(pool)
+	require.NoError(t, err)
+	require.Equal(t, mint, swap.InputMint)
+	require.Equal(t, solana.SolMint, swap.OutputMint)
+	require.Equal(t, uint64(1_000_000_000), swap.OutputAmount)
+	require.Zero(t, swap.Fee)
+
+	meta.Err = map[string]interface{}{"InstructionError": []interface{}{0, "Custom"}}
+	changes, err = BalanceChangesOf(tx, meta)
+	require.NoError(t, err)
+	_, err = changes.Swap(trader)
+	require.True(t, errors.Is(err, ErrNoSwap))
+}
+
+func TestBalanceChanges_TokenForToken(t *testing.T) {
+	trader := solana.NewWallet().PublicKey()
+	usdcAccount, bonkAccount := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	tip := solana.NewWallet().PublicKey()
+	usdc, bonk := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+
+	tx, err := solana.NewTransaction([]solana.Instruction{rawInstruction{
+		programID: solana.NewWallet().PublicKey(),
+		accounts: []*solana.AccountMeta{
+			solana.Meta(trader).WRITE().SIGNER(),
+			solana.Meta(usdcAccount).WRITE(),
+			solana.Meta(bonkAccount).WRITE(),
+			solana.Meta(tip).WRITE(),
+		},
+	}}, solana.Hash{})
+	require.NoError(t, err)
+
+	// 10 USDC for 500_000 BONK, with a 0.001 SOL tip.
+	meta := &rpc.TransactionMeta{
+		Fee:          5_000,
+		PreBalances:  []uint64{1_000_000_000, 2_039_280, 2_039_280, 0, 1},
+		PostBalances: []uint64{1_000_000_000 - 5_000 - 1_000_000, 2_039_280, 2_039_280, 1_000_000, 1},
+		PreTokenBalances: []rpc.TokenBalance{
+			tokenBalance(1, trader, usdc, "25000000", 6),
+			tokenBalance(2, trader, bonk, "0", 5),
+		},
+		PostTokenBalances: []rpc.TokenBalance{
+			tokenBalance(1, trader, usdc, "15000000", 6),
+			tokenBalance(2, trader, bonk, "50000000000", 5),
+		},
+	}
+	changes, err := BalanceChangesOf(tx, meta)
+	require.NoError(t, err)
+
+	swap, err := changes.Swap(trader)
+	require.NoError(t, err)
+	require.Equal(t, usdc, swap.InputMint)
+	require.Equal(t, uint64(10_000_000), swap.InputAmount)
+	require.Equal(t, bonk, swap.OutputMint)
+	require.Equal(t, uint64(50_000_000_000), swap.OutputAmount)
+	require.InDelta(t, 0.00002, swap.Price, 1e-12)
+
+	// The tip recipient only received SOL.
+	_, err = changes.Swap(tip)
+	require.True(t, errors.Is(err, ErrNoSwap))
+
+	meta.PostBalances = meta.PostBalances[:3]
+	_, err = BalanceChangesOf(tx, meta)
+	require.Error(t, err)
+}
@@ -0,0 +1,283 @@
+// Package inspector resolves the instructions of a transaction to their programs and accounts,
+// decodes them with the instruction decoder registry, and prints them as a tree.
+// It also extracts the balance changes of a confirmed transaction, and the swap they make.
+package inspector
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	addresslookuptable "github.com/scatkit/pumpdexer/programs/address-lookup-table"
+	associatedtokenaccount "github.com/scatkit/pumpdexer/programs/associated-token-account"
+	computebudget "github.com/scatkit/pumpdexer/programs/compute-budget"
+	"github.com/scatkit/pumpdexer/programs/memo"
+	"github.com/scatkit/pumpdexer/programs/system"
+	"github.com/scatkit/pumpdexer/programs/token"
+	"github.com/scatkit/pumpdexer/programs/token2022"
+	"github.com/scatkit/pumpdexer/rpc"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+var (
+	programNamesMu sync.RWMutex
+	programNames   = map[solana.PublicKey]string{
+		system.ProgramID:                 system.ProgramName,
+		token.ProgramID:                  token.ProgramName,
+		token2022.ProgramID:              token2022.ProgramName,
+		associatedtokenaccount.ProgramID: associatedtokenaccount.ProgramName,
+		computebudget.ProgramID:          computebudget.ProgramName,
+		addresslookuptable.ProgramID:     addresslookuptable.ProgramName,
+		memo.ProgramID:                   memo.ProgramName,
+		memo.ProgramIDV1:                 memo.ProgramName,
+	}
+)
+
+// RegisterProgramName names a program in the output (e.g. a DEX, whose decoder
+// is registered with solana.RegisterInstructionDecoder).
+func RegisterProgramName(programID solana.PublicKey, name string) {
+	programNamesMu.Lock()
+	defer programNamesMu.Unlock()
+	programNames[programID] = name
+}
+
+// ProgramName returns the name of a known program, or "" if unknown.
+func ProgramName(programID solana.PublicKey) string {
+	programNamesMu.RLock()
+	defer programNamesMu.RUnlock()
+	return programNames[programID]
+}
+
+// Transaction is an inspected transaction.
+type Transaction struct {
+	Signatures   []solana.Signature `json:"signatures"`
+	Instructions []*Instruction     `json:"instructions"`
+}
+
+// Account is an account of an instruction.
+type Account struct {
+	PublicKey  solana.PublicKey `json:"pubkey"`
+	IsWritable bool             `json:"isWritable"`
+	IsSigner   bool             `json:"isSigner"`
+}
+
+// Instruction is a resolved (and, when a decoder is registered, decoded) instruction.
+type Instruction struct {
+	// Position of the instruction: "2" for the second one of the transaction,
+	// "2.1" for the first one it invoked.
+	Index string `json:"index"`
+
+	ProgramID solana.PublicKey `json:"programId"`
+	// Name of the program, "" if unknown.
+	Program string `json:"program,omitempty"`
+
+	// Name of the instruction, "" if not decoded.
+	Name string `json:"name,omitempty"`
+	// Parameters of the decoded instruction, by field name.
+	Params map[string]interface{} `json:"params,omitempty"`
+	// Instruction returned by the decoder; nil if there is none for the program.
+	Decoded interface{} `json:"-"`
+	// Error of the decoder.
+	DecodeError string `json:"decodeError,omitempty"`
+
+	Accounts []Account     `json:"accounts"`
+	Data     solana.Base58 `json:"data"`
+
+	// Instructions invoked by this one, from TransactionMeta.InnerInstructions.
+	Inner []*Instruction `json:"inner,omitempty"`
+}
+
+// InspectResult inspects a transaction fetched with rpc.Client.GetTransaction (in a binary encoding).
+func InspectResult(result *rpc.GetTransactionResult) (*Transaction, error) {
+	if result == nil || result.Transaction == nil {
+		return nil, errors.New("transaction result is empty")
+	}
+	tx, err := result.Transaction.GetTransaction()
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode transaction: %w", err)
+	}
+	if tx == nil {
+		return nil, errors.New("transaction result is empty")
+	}
+	return Inspect(tx, result.Meta)
+}
+
+// Inspect resolves and decodes the instructions of the transaction.
+// The meta is optional: it provides the inner instructions, and the accounts a v0 message
+// loads from address tables (unless the tables are set on the message).
+func Inspect(tx *solana.Transaction, meta *rpc.TransactionMeta) (*Transaction, error) {
+	message := tx.Message
+	if message.NumLookups() > 0 && message.GetAddressTables() == nil {
+		if meta == nil {
+			return nil, errors.New("the message loads accounts from address tables: the transaction meta is required")
+		}
+		tables, err := tablesFromLoadedAddresses(message.AddressTableLookups, meta.LoadedAddresses)
+		if err != nil {
+			return nil, err
+		}
+		if err := message.SetAddressTables(tables); err != nil {
+			return nil, err
+		}
+	}
+	keys, err := message.GetAllKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	out := &Transaction{
+		Signatures:   tx.Signatures,
+		Instructions: make([]*Instruction, len(message.Instructions)),
+	}
+	for i := range message.Instructions {
+		if out.Instructions[i], err = inspectInstruction(&message, keys, message.Instructions[i], fmt.Sprint(i+1)); err != nil {
+			return nil, fmt.Errorf("instruction %d: %w", i+1, err)
+		}
+	}
+	if meta == nil {
+		return out, nil
+	}
+
+	for _, inner := range meta.InnerInstructions {
+		if int(inner.Index) >= len(out.Instructions) {
+			return nil, fmt.Errorf("inner instructions of instruction %d, out of %d", inner.Index+1, len(out.Instructions))
+		}
+		// Invoked instructions are listed in order, with the depth of the call (1 for the transaction's).
+		path := []*Instruction{out.Instructions[inner.Index]}
+		for _, compiled := range inner.Instructions {
+			depth := int(compiled.StackHeight)
+			if depth < 2 {
+				depth = 2
+			}
+			for len(path) > 1 && len(path) >= depth {
+				path = path[:len(path)-1]
+			}
+			parent := path[len(path)-1]
+			index := fmt.Sprintf("%s.%d", parent.Index, len(parent.Inner)+1)
+			instruction, err := inspectInstruction(&message, keys, solana.CompiledInstruction{
+				ProgramIDIndex: compiled.ProgramIDIndex,
+				Accounts:       compiled.Accounts,
+				Data:           compiled.Data,
+			}, index)
+			if err != nil {
+				return nil, fmt.Errorf("instruction %s: %w", index, err)
+			}
+			parent.Inner = append(parent.Inner, instruction)
+			path = append(path, instruction)
+		}
+	}
+	return out, nil
+}
+
+func inspectInstruction(message *solana.Message, keys solana.PublicKeySlice, compiled solana.CompiledInstruction, index string) (*Instruction, error) {
+	if int(compiled.ProgramIDIndex) >= len(keys) {
+		return nil, fmt.Errorf("program index %d out of range: the message has %d accounts", compiled.ProgramIDIndex, len(keys))
+	}
+	metas, err := compiled.ResolveInstructionAccounts(message)
+	if err != nil {
+		return nil, err
+	}
+	programID := keys[compiled.ProgramIDIndex]
+	out := &Instruction{
+		Index:     index,
+		ProgramID: programID,
+		Program:   ProgramName(programID),
+		Accounts:  make([]Account, len(metas)),
+		Data:      compiled.Data,
+	}
+	for i, meta := range metas {
+		out.Accounts[i] = Account{PublicKey: meta.PublicKey, IsWritable: meta.IsWritable, IsSigner: meta.IsSigner}
+	}
+
+	decoded, err := solana.DecodeInstruction(programID, metas, compiled.Data)
+	switch {
+	case errors.Is(err, solana.ErrInstructionDecoderNotFound):
+	case err != nil:
+		out.DecodeError = err.Error()
+	default:
+		out.Decoded = decoded
+		out.Name, out.Params = describe(decoded)
+	}
+	return out, nil
+}
+
+// tablesFromLoadedAddresses rebuilds the part of the address tables the message uses
+// from the addresses the node loaded for it: the writable ones of each lookup in order, then the readonly ones.
+func tablesFromLoadedAddresses(lookups solana.MessageAddressTableLookupSlice, loaded rpc.LoadedAddresses) (map[solana.PublicKey]solana.PublicKeySlice, error) {
+	if len(loaded.Writable) != lookups.NumWritableLookups() || len(loaded.Writable)+len(loaded.ReadOnly) != lookups.NumLookups() {
+		return nil, fmt.Errorf("the meta has %d writable and %d readonly loaded addresses, the message looks up %d and %d",
+			len(loaded.Writable), len(loaded.ReadOnly), lookups.NumWritableLookups(), lookups.NumLookups()-lookups.NumWritableLookups())
+	}
+	tables := make(map[solana.PublicKey]solana.PublicKeySlice, len(lookups))
+	set := func(table solana.PublicKey, index uint8, key solana.PublicKey) {
+		addresses := tables[table]
+		for len(addresses) <= int(index) {
+			addresses = append(addresses, solana.PublicKey{})
+		}
+		addresses[index] = key
+		tables[table] = addresses
+	}
+	var writable, readonly int
+	for _, lookup := range lookups {
+		for _, index := range lookup.WritableIndexes {
+			set(lookup.AccountKey, index, loaded.Writable[writable])
+			writable++
+		}
+	}
+	for _, lookup := range lookups {
+		for _, index := range lookup.ReadonlyIndexes {
+			set(lookup.AccountKey, index, loaded.ReadOnly[readonly])
+			readonly++
+		}
+	}
+	return tables, nil
+}
+
+var accountMetasType = reflect.TypeOf([]*solana.AccountMeta(nil))
+
+// describe returns the name and parameters of a decoded instruction:
+// the type and exported fields of its variant implementation, without its accounts
+// (the fields not serialized in the data, or holding account metas).
+func describe(decoded interface{}) (string, map[string]interface{}) {
+	impl := reflect.ValueOf(decoded)
+	for impl.Kind() == reflect.Ptr && !impl.IsNil() {
+		impl = impl.Elem()
+	}
+	if impl.Kind() == reflect.Struct {
+		if variant := impl.FieldByName("Impl"); variant.IsValid() && variant.Kind() == reflect.Interface && !variant.IsNil() {
+			impl = variant.Elem()
+			for impl.Kind() == reflect.Ptr && !impl.IsNil() {
+				impl = impl.Elem()
+			}
+		}
+	}
+	if !impl.IsValid() {
+		return "", nil
+	}
+	if impl.Kind() != reflect.Struct {
+		return impl.Type().Name(), nil
+	}
+
+	params := make(map[string]interface{})
+	for i := 0; i < impl.NumField(); i++ {
+		field := impl.Type().Field(i)
+		if field.Anonymous || field.PkgPath != "" || field.Tag.Get("bin") == "-" {
+			continue
+		}
+		if field.Type.ConvertibleTo(accountMetasType) {
+			continue
+		}
+		value := impl.Field(i)
+		if value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				continue
+			}
+			value = value.Elem()
+		}
+		params[field.Name] = value.Interface()
+	}
+	if len(params) == 0 {
+		params = nil
+	}
+	return impl.Type().Name(), params
+}
@@ -0,0 +1,177 @@
+package inspector
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	computebudget "github.com/scatkit/pumpdexer/programs/compute-budget"
+	"github.com/scatkit/pumpdexer/programs/memo"
+	"github.com/scatkit/pumpdexer/programs/system"
+	"github.com/scatkit/pumpdexer/programs/token"
+	"github.com/scatkit/pumpdexer/rpc"
+	"github.com/scatkit/pumpdexer/solana"
+	"github.com/stretchr/testify/require"
+)
+
+type rawInstruction struct {
+	programID solana.PublicKey
+	accounts  []*solana.AccountMeta
+	data      []byte
+}
+
+func (inst rawInstruction) ProgramID() solana.PublicKey     { return inst.programID }
+func (inst rawInstruction) Accounts() []*solana.AccountMeta { return inst.accounts }
+func (inst rawInstruction) Data() ([]byte, error)           { return inst.data, nil }
+
+func TestInspect(t *testing.T) {
+	payer := solana.NewWallet().PublicKey()
+	recipient := solana.NewWallet().PublicKey()
+	unknownProgram := solana.NewWallet().PublicKey()
+
+	tx, err := solana.NewTransaction([]solana.Instruction{
+		computebudget.NewSetComputeUnitLimitInstruction(200_000).Build(),
+		system.NewTransferInstruction(1_000, payer, recipient).Build(),
+		memo.NewMemoInstruction([]byte("strategy:42"), payer).Build(),
+		rawInstruction{programID: unknownProgram, accounts: []*solana.AccountMeta{solana.Meta(recipient).WRITE()}, data: []byte{0xde, 0xad}},
+	}, solana.Hash{}, solana.TransactionPayer(payer))
+	require.NoError(t, err)
+
+	inspected, err := Inspect(tx, nil)
+	require.NoError(t, err)
+	require.Len(t, inspected.Instructions, 4)
+
+	limit := inspected.Instructions[0]
+	require.Equal(t, "1", limit.Index)
+	require.Equal(t, "ComputeBudget", limit.Program)
+	require.Equal(t, "SetComputeUnitLimit", limit.Name)
+	require.Equal(t, map[string]interface{}{"Units": uint32(200_000)}, limit.Params)
+	require.Empty(t, limit.Accounts)
+
+	transfer := inspected.Instructions[1]
+	require.Equal(t, "System", transfer.Program)
+	require.Equal(t, "Transfer", transfer.Name)
+	require.Equal(t, map[string]interface{}{"Lamports": uint64(1_000)}, transfer.Params)
+	require.Equal(t, []Account{
+		{PublicKey: payer, IsWritable: true, IsSigner: true},
+		{PublicKey: recipient, IsWritable: true},
+	}, transfer.Accounts)
+
+	require.Equal(t, "Memo", inspected.Instructions[2].Name)
+
+	unknown := inspected.Instructions[3]
+	require.Equal(t, "", unknown.Program)
+	require.Nil(t, unknown.Decoded)
+	require.Equal(t, solana.Base58{0xde, 0xad}, unknown.Data)
+
+	out := inspected.String()
+	require.Contains(t, out, "#2 System.Transfer (11111111111111111111111111111111)\n    Lamports: 1000\n")
+	require.Contains(t, out, fmt.Sprintf("    [0] %s (writable, signer)\n", payer))
+	require.Contains(t, out, `Message: "strategy:42"`)
+	require.Contains(t, out, fmt.Sprintf("#4 Unknown (%s)\n    data: dead\n", unknownProgram))
+
+	data, err := json.Marshal(inspected)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"name":"Transfer","params":{"Lamports":1000}`)
+}
+
+func TestInspect_TokenInstruction(t *testing.T) {
+	owner := solana.NewWallet().PublicKey()
+	cosigner := solana.NewWallet().PublicKey()
+	source := solana.NewWallet().PublicKey()
+	destination := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+
+	transfer, err := token.NewTransferCheckedInstruction(2_500, 6, source, mint, destination, owner, []solana.PublicKey{cosigner}).ValidateAndBuild()
+	require.NoError(t, err)
+	tx, err := solana.NewTransaction([]solana.Instruction{transfer}, solana.Hash{}, solana.TransactionPayer(cosigner))
+	require.NoError(t, err)
+
+	inspected, err := Inspect(tx, nil)
+	require.NoError(t, err)
+	inst := inspected.Instructions[0]
+	require.Equal(t, "Token", inst.Program)
+	require.Equal(t, "TransferChecked", inst.Name)
+	require.Equal(t, map[string]interface{}{"Amount": uint64(2_500), "Decimals": uint8(6)}, inst.Params)
+	require.Len(t, inst.Accounts, 5)
+
+	data, err := json.Marshal(inst)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"params":{"Amount":2500,"Decimals":6}`)
+	require.NotContains(t, string(data), "Signers")
+}
+
+func TestDescribe_Nil(t *testing.T) {
+	name, params := describe(nil)
+	require.Equal(t, "", name)
+	require.Nil(t, params)
+
+	name, params = describe((*token.Instruction)(nil))
+	require.Equal(t, "", name)
+	require.Nil(t, params)
+}
+
+func TestInspect_LookupsAndInnerInstructions(t *testing.T) {
+	payer := solana.NewWallet().PublicKey()
+	dex := solana.NewWallet().PublicKey()
+	table := solana.NewWallet().PublicKey()
+	pool, vault := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	RegisterProgramName(dex, "Dex")
+
+	message := solana.Message{
+		Header:      solana.MessageHeader{NumRequiredSignatures: 1, NumReadonlyUnsignedAccounts: 2},
+		AccountKeys: solana.PublicKeySlice{payer, dex, solana.SystemProgramID},
+		Instructions: []solana.CompiledInstruction{
+			{ProgramIDIndex: 1, Accounts: []uint16{0, 3, 4}, Data: []byte{9}},
+		},
+	}
+	message.SetAddressTableLookups([]solana.MessageAddressTableLookup{
+		{AccountKey: table, WritableIndexes: []uint8{7}, ReadonlyIndexes: []uint8{2}},
+	})
+	tx := &solana.Transaction{Signatures: []solana.Signature{{1}}, Message: message}
+
+	_, err := Inspect(tx, nil)
+	require.Error(t, err, "the loaded addresses are required")
+
+	transferData := make([]byte, 12)
+	binary.LittleEndian.PutUint32(transferData, system.Instruction_Transfer)
+	binary.LittleEndian.PutUint64(transferData[4:], 5)
+	meta := &rpc.TransactionMeta{
+		LoadedAddresses: rpc.LoadedAddresses{Writable: solana.PublicKeySlice{pool}, ReadOnly: solana.PublicKeySlice{vault}},
+		InnerInstructions: []rpc.InnerInstruction{{
+			Index: 0,
+			Instructions: []rpc.CompiledInstruction{
+				{ProgramIDIndex: 1, Accounts: []uint16{3}, Data: solana.Base58{1}, StackHeight: 2},
+				{ProgramIDIndex: 2, Accounts: []uint16{0, 3}, Data: transferData, StackHeight: 3},
+				{ProgramIDIndex: 1, Accounts: []uint16{4}, Data: solana.Base58{2}, StackHeight: 2},
+			},
+		}},
+	}
+	inspected, err := Inspect(tx, meta)
+	require.NoError(t, err)
+
+	swap := inspected.Instructions[0]
+	require.Equal(t, "Dex", swap.Program)
+	require.Equal(t, []Account{
+		{PublicKey: payer, IsWritable: true, IsSigner: true},
+		{PublicKey: pool, IsWritable: true},
+		{PublicKey: vault},
+	}, swap.Accounts)
+
+	require.Len(t, swap.Inner, 2)
+	require.Equal(t, "1.1", swap.Inner[0].Index)
+	require.Equal(t, "1.2", swap.Inner[1].Index)
+	require.Len(t, swap.Inner[0].Inner, 1)
+	nested := swap.Inner[0].Inner[0]
+	require.Equal(t, "1.1.1", nested.Index)
+	require.Equal(t, "Transfer", nested.Name)
+	require.Equal(t, map[string]interface{}{"Lamports": uint64(5)}, nested.Params)
+	require.Equal(t, pool, nested.Accounts[1].PublicKey)
+
+	require.Contains(t, inspected.String(), "\n    #1.1 Dex (")
+	require.Contains(t, inspected.String(), "\n        #1.1.1 System.Transfer (")
+
+	// The transaction itself is left as is.
+	require.Nil(t, tx.Message.GetAddressTables())
+}
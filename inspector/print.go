@@ -0,0 +1,108 @@
+package inspector
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// String returns the instruction tree, as printed by Fprint.
+func (tx *Transaction) String() string {
+	buf := new(bytes.Buffer)
+	tx.Fprint(buf)
+	return buf.String()
+}
+
+// Fprint writes the instruction tree, one indented block per instruction:
+//
+//	#1 System.Transfer (11111111111111111111111111111111)
+//	    Lamports: 1000
+//	    [0] 9hFt... (writable, signer)
+//	    [1] 6FzX... (writable)
+//	    #1.1 ...
+//
+// A Transaction also marshals to JSON, with the same tree.
+func (tx *Transaction) Fprint(w io.Writer) error {
+	for _, signature := range tx.Signatures {
+		if _, err := fmt.Fprintf(w, "Signature: %s\n", signature); err != nil {
+			return err
+		}
+	}
+	for _, instruction := range tx.Instructions {
+		if err := instruction.fprint(w, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (inst *Instruction) fprint(w io.Writer, indent string) error {
+	var b strings.Builder
+	program := inst.Program
+	if program == "" {
+		program = "Unknown"
+	}
+	fmt.Fprintf(&b, "%s#%s %s", indent, inst.Index, program)
+	if inst.Name != "" {
+		fmt.Fprintf(&b, ".%s", inst.Name)
+	}
+	fmt.Fprintf(&b, " (%s)\n", inst.ProgramID)
+
+	detail := indent + "    "
+	names := make([]string, 0, len(inst.Params))
+	for name := range inst.Params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s%s: %s\n", detail, name, formatValue(inst.Params[name]))
+	}
+	if inst.DecodeError != "" {
+		fmt.Fprintf(&b, "%sdecode error: %s\n", detail, inst.DecodeError)
+	}
+	if inst.Decoded == nil && len(inst.Data) > 0 {
+		fmt.Fprintf(&b, "%sdata: %s\n", detail, hex.EncodeToString(inst.Data))
+	}
+	for i, account := range inst.Accounts {
+		fmt.Fprintf(&b, "%s[%d] %s%s\n", detail, i, account.PublicKey, formatFlags(account))
+	}
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return err
+	}
+	for _, inner := range inst.Inner {
+		if err := inner.fprint(w, detail); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func formatFlags(account Account) string {
+	switch {
+	case account.IsWritable && account.IsSigner:
+		return " (writable, signer)"
+	case account.IsWritable:
+		return " (writable)"
+	case account.IsSigner:
+		return " (signer)"
+	default:
+		return ""
+	}
+}
+
+func formatValue(value interface{}) string {
+	if data, ok := value.([]byte); ok {
+		if utf8.Valid(data) {
+			return fmt.Sprintf("%q", data)
+		}
+		return hex.EncodeToString(data)
+	}
+	if stringer, ok := value.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,186 @@
+// Package keystore keeps private keys encrypted at rest.
+//
+// A passphrase is stretched with scrypt into the key of an AES-256-GCM cipher, which seals the
+// 64 bytes of the private key; the public key is stored in clear (and authenticated),
+// so that keys can be listed and picked without the passphrase.
+//
+// EncryptedKey is a single key (a file of its own with WriteKeyFile); Keystore is a file
+// of many labelled keys, such as the wallets of a fleet of bots.
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	crypto_rand "crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/scatkit/pumpdexer/solana"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	keyVersion = 1
+	kdfScrypt  = "scrypt"
+	cipherGCM  = "aes-256-gcm"
+)
+
+// ErrWrongPassphrase is returned when a key can't be decrypted: wrong passphrase, or tampered file.
+var ErrWrongPassphrase = errors.New("keystore: wrong passphrase")
+
+// ScryptParams are the cost parameters of scrypt, which uses 128*N*R bytes of memory.
+type ScryptParams struct {
+	N int `json:"n"`
+	R int `json:"r"`
+	P int `json:"p"`
+}
+
+var (
+	// DefaultScryptParams take about 64MB and a fraction of a second to unlock a key.
+	DefaultScryptParams = ScryptParams{N: 1 << 16, R: 8, P: 1}
+	// LightScryptParams are fast, for tests and low-value keys.
+	LightScryptParams = ScryptParams{N: 1 << 12, R: 8, P: 1}
+)
+
+type kdfParams struct {
+	ScryptParams
+	Salt []byte `json:"salt"`
+}
+
+// EncryptedKey is a private key sealed with a passphrase; it marshals to JSON.
+type EncryptedKey struct {
+	Version    int              `json:"version"`
+	PublicKey  solana.PublicKey `json:"publicKey"`
+	KDF        string           `json:"kdf"`
+	KDFParams  kdfParams        `json:"kdfParams"`
+	Cipher     string           `json:"cipher"`
+	Nonce      []byte           `json:"nonce"`
+	Ciphertext []byte           `json:"ciphertext"`
+}
+
+// EncryptKey seals the key with the passphrase; params are DefaultScryptParams if nil.
+func EncryptKey(key solana.PrivateKey, passphrase string, params *ScryptParams) (*EncryptedKey, error) {
+	if err := key.Validate(); err != nil {
+		return nil, err
+	}
+	if params == nil {
+		params = &DefaultScryptParams
+	}
+	enc := &EncryptedKey{
+		Version:   keyVersion,
+		PublicKey: key.PublicKey(),
+		KDF:       kdfScrypt,
+		KDFParams: kdfParams{ScryptParams: *params, Salt: make([]byte, 32)},
+		Cipher:    cipherGCM,
+	}
+	if _, err := crypto_rand.Read(enc.KDFParams.Salt); err != nil {
+		return nil, err
+	}
+	aead, err := enc.aead(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	enc.Nonce = make([]byte, aead.NonceSize())
+	if _, err := crypto_rand.Read(enc.Nonce); err != nil {
+		return nil, err
+	}
+	enc.Ciphertext = aead.Seal(nil, enc.Nonce, key, enc.PublicKey[:])
+	return enc, nil
+}
+
+// Decrypt opens the key with the passphrase.
+func (k *EncryptedKey) Decrypt(passphrase string) (solana.PrivateKey, error) {
+	aead, err := k.aead(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return k.open(aead)
+}
+
+// aead derives the cipher of the key from the passphrase.
+func (k *EncryptedKey) aead(passphrase string) (cipher.AEAD, error) {
+	if k.Version != keyVersion {
+		return nil, fmt.Errorf("keystore: unsupported key version %d", k.Version)
+	}
+	if k.KDF != kdfScrypt || k.Cipher != cipherGCM {
+		return nil, fmt.Errorf("keystore: unsupported kdf %q or cipher %q", k.KDF, k.Cipher)
+	}
+	p := k.KDFParams
+	derived, err := scrypt.Key([]byte(passphrase), p.Salt, p.N, p.R, p.P, 32)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: scrypt: %w", err)
+	}
+	block, err := aes.NewCipher(derived)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (k *EncryptedKey) open(aead cipher.AEAD) (solana.PrivateKey, error) {
+	if len(k.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("keystore: invalid nonce size %d", len(k.Nonce))
+	}
+	plain, err := aead.Open(nil, k.Nonce, k.Ciphertext, k.PublicKey[:])
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	key := solana.PrivateKey(plain)
+	if err := key.Validate(); err != nil {
+		return nil, fmt.Errorf("keystore: %w", err)
+	}
+	if !key.PublicKey().Equals(k.PublicKey) {
+		return nil, fmt.Errorf("keystore: the key doesn't match the public key %s", k.PublicKey)
+	}
+	return key, nil
+}
+
+// WriteKeyFile writes an encrypted key to a JSON file readable by its owner only.
+func WriteKeyFile(path string, key *EncryptedKey) error {
+	data, err := json.MarshalIndent(key, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(path, data)
+}
+
+// ReadKeyFile reads a file written by WriteKeyFile.
+func ReadKeyFile(path string) (*EncryptedKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key := new(EncryptedKey)
+	if err := json.Unmarshal(data, key); err != nil {
+		return nil, fmt.Errorf("keystore: decode %s: %w", path, err)
+	}
+	return key, nil
+}
+
+// writeFile replaces the file atomically, so that a crash can't leave it half written.
+func writeFile(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
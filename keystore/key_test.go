@@ -0,0 +1,60 @@
+package keystore
+
+import (
+	"context"
+	"crypto/ed25519"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/scatkit/pumpdexer/solana"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptedKey(t *testing.T) {
+	key := solana.NewWallet().PrivateKey
+	enc, err := EncryptKey(key, "hunter2", &LightScryptParams)
+	require.NoError(t, err)
+	require.Equal(t, key.PublicKey(), enc.PublicKey)
+	require.NotContains(t, string(enc.Ciphertext), string(key))
+
+	decrypted, err := enc.Decrypt("hunter2")
+	require.NoError(t, err)
+	require.Equal(t, key, decrypted)
+	_, err = enc.Decrypt("hunter3")
+	require.ErrorIs(t, err, ErrWrongPassphrase)
+
+	// The public key is authenticated.
+	swapped := *enc
+	swapped.PublicKey = solana.NewWallet().PublicKey()
+	_, err = swapped.Decrypt("hunter2")
+	require.ErrorIs(t, err, ErrWrongPassphrase)
+
+	path := filepath.Join(t.TempDir(), "bot.json")
+	require.NoError(t, WriteKeyFile(path, enc))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	read, err := ReadKeyFile(path)
+	require.NoError(t, err)
+	require.Equal(t, enc, read)
+}
+
+func TestFileSigner(t *testing.T) {
+	key := solana.NewWallet().PrivateKey
+	enc, err := EncryptKey(key, "hunter2", &LightScryptParams)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "bot.json")
+	require.NoError(t, WriteKeyFile(path, enc))
+
+	_, err = NewFileSigner(path, "wrong")
+	require.ErrorIs(t, err, ErrWrongPassphrase)
+	signer, err := NewFileSigner(path, "hunter2")
+	require.NoError(t, err)
+	require.Equal(t, key.PublicKey(), signer.PublicKey())
+
+	sig, err := signer.SignMessage(context.Background(), []byte("hello"))
+	require.NoError(t, err)
+	pub := key.PublicKey()
+	require.True(t, ed25519.Verify(pub[:], []byte("hello"), sig[:]))
+}
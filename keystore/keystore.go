@@ -0,0 +1,239 @@
+package keystore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+const keystoreVersion = 1
+
+var (
+	ErrKeyNotFound  = errors.New("keystore: key not found")
+	ErrLabelInUse   = errors.New("keystore: label already in use")
+	ErrKeyDuplicate = errors.New("keystore: key already in the keystore")
+)
+
+type Options struct {
+	// Cost of the encryption of the keys added; DefaultScryptParams by default.
+	// Keys already in the keystore keep theirs.
+	ScryptParams *ScryptParams
+}
+
+// KeyInfo is what can be read of a key without its passphrase.
+type KeyInfo struct {
+	Label     string
+	PublicKey solana.PublicKey
+}
+
+type entry struct {
+	Label string `json:"label"`
+	*EncryptedKey
+}
+
+type keystoreFile struct {
+	Version int      `json:"version"`
+	Keys    []*entry `json:"keys"`
+}
+
+// Keystore is a file of labelled keys, each encrypted with its passphrase (which can be the
+// same for all). Every change is written to the file right away. It is safe for concurrent use,
+// but not for use by several processes.
+type Keystore struct {
+	path string
+	opts Options
+
+	lock    sync.Mutex
+	entries []*entry
+}
+
+// Open opens the keystore at path; it is created by the first key added if it doesn't exist.
+func Open(path string, opts *Options) (*Keystore, error) {
+	ks := &Keystore{path: path}
+	if opts != nil {
+		ks.opts = *opts
+	}
+	if ks.opts.ScryptParams == nil {
+		ks.opts.ScryptParams = &DefaultScryptParams
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return ks, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var file keystoreFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("keystore: decode %s: %w", path, err)
+	}
+	if file.Version != keystoreVersion {
+		return nil, fmt.Errorf("keystore: unsupported version %d", file.Version)
+	}
+	for i, e := range file.Keys {
+		if e == nil || e.EncryptedKey == nil {
+			return nil, fmt.Errorf("keystore: invalid key %d", i)
+		}
+	}
+	ks.entries = file.Keys
+	return ks, nil
+}
+
+// List returns the keys of the keystore, by label.
+func (ks *Keystore) List() []KeyInfo {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	out := make([]KeyInfo, 0, len(ks.entries))
+	for _, e := range ks.entries {
+		out = append(out, KeyInfo{Label: e.Label, PublicKey: e.PublicKey})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Label < out[j].Label })
+	return out
+}
+
+// Add encrypts the key with the passphrase and stores it under the label, which must be unique.
+func (ks *Keystore) Add(label string, key solana.PrivateKey, passphrase string) error {
+	if label == "" {
+		return errors.New("keystore: a label is required")
+	}
+	if err := key.Validate(); err != nil {
+		return err
+	}
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	publicKey := key.PublicKey()
+	for _, e := range ks.entries {
+		if e.Label == label {
+			return fmt.Errorf("%w: %q", ErrLabelInUse, label)
+		}
+		if e.PublicKey.Equals(publicKey) {
+			return fmt.Errorf("%w: %s is %q", ErrKeyDuplicate, publicKey, e.Label)
+		}
+	}
+	enc, err := EncryptKey(key, passphrase, ks.opts.ScryptParams)
+	if err != nil {
+		return err
+	}
+	return ks.save(append(ks.entries, &entry{Label: label, EncryptedKey: enc}))
+}
+
+// Generate adds a new random key under the label.
+func (ks *Keystore) Generate(label string, passphrase string) (solana.PublicKey, error) {
+	key, err := solana.NewPrivateKey()
+	if err != nil {
+		return solana.PublicKey{}, err
+	}
+	if err := ks.Add(label, key, passphrase); err != nil {
+		return solana.PublicKey{}, err
+	}
+	return key.PublicKey(), nil
+}
+
+// ImportBase58 adds a key given in base58, the format of the private keys of Phantom and of most bots.
+func (ks *Keystore) ImportBase58(label string, privateKey string, passphrase string) (solana.PublicKey, error) {
+	key, err := solana.PrivateKeyFromBase58(privateKey)
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("keystore: invalid private key: %w", err)
+	}
+	if err := ks.Add(label, key, passphrase); err != nil {
+		return solana.PublicKey{}, err
+	}
+	return key.PublicKey(), nil
+}
+
+// Remove deletes a key, found by label or base58 public key.
+func (ks *Keystore) Remove(labelOrPublicKey string) error {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	i := ks.find(labelOrPublicKey)
+	if i < 0 {
+		return fmt.Errorf("%w: %q", ErrKeyNotFound, labelOrPublicKey)
+	}
+	entries := append(append([]*entry{}, ks.entries[:i]...), ks.entries[i+1:]...)
+	return ks.save(entries)
+}
+
+// Key returns the encrypted key found by label or base58 public key.
+func (ks *Keystore) Key(labelOrPublicKey string) (*EncryptedKey, error) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	i := ks.find(labelOrPublicKey)
+	if i < 0 {
+		return nil, fmt.Errorf("%w: %q", ErrKeyNotFound, labelOrPublicKey)
+	}
+	return ks.entries[i].EncryptedKey, nil
+}
+
+// Unlock decrypts a key, found by label or base58 public key.
+func (ks *Keystore) Unlock(labelOrPublicKey string, passphrase string) (solana.PrivateKey, error) {
+	enc, err := ks.Key(labelOrPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return enc.Decrypt(passphrase)
+}
+
+// Signer returns a signer of a key, which only decrypts it to sign (see FileSigner).
+func (ks *Keystore) Signer(labelOrPublicKey string, passphrase string) (*FileSigner, error) {
+	enc, err := ks.Key(labelOrPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return NewSigner(enc, passphrase)
+}
+
+// Signers returns the signers of all the keys, by label, which must share the passphrase.
+func (ks *Keystore) Signers(passphrase string) ([]*FileSigner, error) {
+	infos := ks.List()
+	out := make([]*FileSigner, 0, len(infos))
+	for _, info := range infos {
+		signer, err := ks.Signer(info.PublicKey.String(), passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("unlock %q: %w", info.Label, err)
+		}
+		out = append(out, signer)
+	}
+	return out, nil
+}
+
+// ExportSolanaKeygenFile decrypts a key and writes it, in clear, to a keypair file of the Solana CLI.
+func (ks *Keystore) ExportSolanaKeygenFile(labelOrPublicKey string, passphrase string, path string) error {
+	key, err := ks.Unlock(labelOrPublicKey, passphrase)
+	if err != nil {
+		return err
+	}
+	defer wipe(key)
+	return key.WriteSolanaKeygenFile(path)
+}
+
+func (ks *Keystore) find(labelOrPublicKey string) int {
+	for i, e := range ks.entries {
+		if e.Label == labelOrPublicKey {
+			return i
+		}
+	}
+	for i, e := range ks.entries {
+		if e.PublicKey.String() == labelOrPublicKey {
+			return i
+		}
+	}
+	return -1
+}
+
+// save writes the entries, and makes them the ones of the keystore if it succeeded.
+func (ks *Keystore) save(entries []*entry) error {
+	data, err := json.MarshalIndent(&keystoreFile{Version: keystoreVersion, Keys: entries}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFile(ks.path, data); err != nil {
+		return fmt.Errorf("keystore: write %s: %w", ks.path, err)
+	}
+	ks.entries = entries
+	return nil
+}
@@ -0,0 +1,70 @@
+package keystore
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/scatkit/pumpdexer/solana"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeystore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wallets.json")
+	ks, err := Open(path, &Options{ScryptParams: &LightScryptParams})
+	require.NoError(t, err)
+	require.Empty(t, ks.List())
+
+	sniper1, err := ks.Generate("sniper-1", "pass")
+	require.NoError(t, err)
+	imported := solana.NewWallet().PrivateKey
+	sniper2, err := ks.ImportBase58("sniper-2", imported.String(), "pass")
+	require.NoError(t, err)
+	require.Equal(t, imported.PublicKey(), sniper2)
+	_, err = ks.Generate("treasury", "other pass")
+	require.NoError(t, err)
+
+	require.ErrorIs(t, ks.Add("sniper-1", solana.NewWallet().PrivateKey, "pass"), ErrLabelInUse)
+	require.ErrorIs(t, ks.Add("sniper-3", imported, "pass"), ErrKeyDuplicate)
+	_, err = ks.ImportBase58("sniper-3", "not a key", "pass")
+	require.Error(t, err)
+
+	// Everything is on disk.
+	ks, err = Open(path, nil)
+	require.NoError(t, err)
+	list := ks.List()
+	require.Len(t, list, 3)
+	require.Equal(t, KeyInfo{Label: "sniper-1", PublicKey: sniper1}, list[0])
+	require.Equal(t, "treasury", list[2].Label)
+
+	key, err := ks.Unlock("sniper-2", "pass")
+	require.NoError(t, err)
+	require.Equal(t, imported, key)
+	key, err = ks.Unlock(sniper1.String(), "pass")
+	require.NoError(t, err)
+	require.Equal(t, sniper1, key.PublicKey())
+	_, err = ks.Unlock("sniper-1", "wrong")
+	require.ErrorIs(t, err, ErrWrongPassphrase)
+	_, err = ks.Unlock("sniper-9", "pass")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	// The passphrase of the treasury differs.
+	_, err = ks.Signers("pass")
+	require.ErrorIs(t, err, ErrWrongPassphrase)
+	require.NoError(t, ks.Remove("treasury"))
+	signers, err := ks.Signers("pass")
+	require.NoError(t, err)
+	require.Len(t, signers, 2)
+	_, err = signers[1].SignMessage(context.Background(), []byte("hello"))
+	require.NoError(t, err)
+
+	exported := filepath.Join(t.TempDir(), "id.json")
+	require.NoError(t, ks.ExportSolanaKeygenFile("sniper-2", "pass", exported))
+	fromFile, err := solana.PrivateKeyFromSolanaKeygenFile(exported)
+	require.NoError(t, err)
+	require.Equal(t, imported, fromFile)
+
+	ks, err = Open(path, nil)
+	require.NoError(t, err)
+	require.Len(t, ks.List(), 2)
+}
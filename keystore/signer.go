@@ -0,0 +1,62 @@
+package keystore
+
+import (
+	"context"
+	"crypto/cipher"
+
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// FileSigner is a solana.Signer of an encrypted key: the plaintext key is opened for
+// each signature and wiped right after, so that it doesn't linger between signatures.
+// This doesn't protect the key from whoever can read the process memory: the signer
+// keeps the AES-GCM cipher derived from the passphrase (once, when the signer is made,
+// as scrypt is too slow to run per signature), and the cipher decrypts the key.
+type FileSigner struct {
+	key  *EncryptedKey
+	aead cipher.AEAD
+}
+
+var _ solana.Signer = (*FileSigner)(nil)
+
+// NewFileSigner reads an encrypted key file and checks the passphrase.
+func NewFileSigner(path string, passphrase string) (*FileSigner, error) {
+	key, err := ReadKeyFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewSigner(key, passphrase)
+}
+
+// NewSigner returns a signer of an encrypted key, checking the passphrase.
+func NewSigner(key *EncryptedKey, passphrase string) (*FileSigner, error) {
+	aead, err := key.aead(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	private, err := key.open(aead)
+	if err != nil {
+		return nil, err
+	}
+	wipe(private)
+	return &FileSigner{key: key, aead: aead}, nil
+}
+
+func (s *FileSigner) PublicKey() solana.PublicKey {
+	return s.key.PublicKey
+}
+
+func (s *FileSigner) SignMessage(ctx context.Context, msg []byte) (solana.Signature, error) {
+	private, err := s.key.open(s.aead)
+	if err != nil {
+		return solana.Signature{}, err
+	}
+	defer wipe(private)
+	return private.SignMessage(ctx, msg)
+}
+
+func wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
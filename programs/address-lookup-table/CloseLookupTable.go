@@ -0,0 +1,123 @@
+package addresslookuptable
+
+import (
+	"errors"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// Close an address lookup table account.
+// The table must be deactivated first, and its deactivation slot must be old enough.
+type CloseLookupTable struct {
+	// [0] = [WRITE] lookupTable
+	// ··········· Address lookup table account to close.
+	//
+	// [1] = [SIGNER] authority
+	// ··········· Current authority.
+	//
+	// [2] = [WRITE] recipient
+	// ··········· Recipient of the closed account's lamports.
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewCloseLookupTableInstructionBuilder creates a new `CloseLookupTable` instruction builder.
+func NewCloseLookupTableInstructionBuilder() *CloseLookupTable {
+	nd := &CloseLookupTable{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 3),
+	}
+	return nd
+}
+
+// SetLookupTableAccount sets the "lookupTable" account.
+// Address lookup table account to close.
+func (inst *CloseLookupTable) SetLookupTableAccount(lookupTable solana.PublicKey) *CloseLookupTable {
+	inst.AccountMetaSlice[0] = solana.Meta(lookupTable).WRITE()
+	return inst
+}
+
+// GetLookupTableAccount gets the "lookupTable" account.
+// Address lookup table account to close.
+func (inst *CloseLookupTable) GetLookupTableAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// SetAuthorityAccount sets the "authority" account.
+// Current authority.
+func (inst *CloseLookupTable) SetAuthorityAccount(authority solana.PublicKey) *CloseLookupTable {
+	inst.AccountMetaSlice[1] = solana.Meta(authority).SIGNER()
+	return inst
+}
+
+// GetAuthorityAccount gets the "authority" account.
+// Current authority.
+func (inst *CloseLookupTable) GetAuthorityAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// SetRecipientAccount sets the "recipient" account.
+// Recipient of the closed account's lamports.
+func (inst *CloseLookupTable) SetRecipientAccount(recipient solana.PublicKey) *CloseLookupTable {
+	inst.AccountMetaSlice[2] = solana.Meta(recipient).WRITE()
+	return inst
+}
+
+// GetRecipientAccount gets the "recipient" account.
+// Recipient of the closed account's lamports.
+func (inst *CloseLookupTable) GetRecipientAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+func (inst CloseLookupTable) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint32(Instruction_CloseLookupTable, bin.LE),
+	}}
+}
+
+// ValidateAndBuild validates the instruction accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst CloseLookupTable) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *CloseLookupTable) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.LookupTable is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return errors.New("accounts.Authority is not set")
+		}
+		if inst.AccountMetaSlice[2] == nil {
+			return errors.New("accounts.Recipient is not set")
+		}
+	}
+	return nil
+}
+
+func (obj CloseLookupTable) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	return nil
+}
+
+func (obj *CloseLookupTable) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	return nil
+}
+
+// NewCloseLookupTableInstruction declares a new CloseLookupTable instruction with the provided accounts.
+func NewCloseLookupTableInstruction(
+	// Accounts:
+	lookupTable solana.PublicKey,
+	authority solana.PublicKey,
+	recipient solana.PublicKey,
+) *CloseLookupTable {
+	return NewCloseLookupTableInstructionBuilder().
+		SetLookupTableAccount(lookupTable).
+		SetAuthorityAccount(authority).
+		SetRecipientAccount(recipient)
+}
@@ -0,0 +1,203 @@
+package addresslookuptable
+
+import (
+	"errors"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// Create an address lookup table.
+// The table address is derived from the authority and a recent slot (see DeriveLookupTableAddress).
+type CreateLookupTable struct {
+	// A recent slot must be used in the derivation path
+	// for each initialized table. When closing table accounts,
+	// the initialization slot must no longer be "recent" to prevent
+	// address tables from being recreated with reordered or
+	// otherwise malicious addresses.
+	RecentSlot *uint64
+
+	// Address tables are always initialized at program-derived
+	// addresses using the funding address, recent blockhash, and
+	// the user-passed `bump_seed`.
+	BumpSeed *uint8
+
+	// [0] = [WRITE] lookupTable
+	// ··········· Uninitialized address lookup table account.
+	//
+	// [1] = [] authority
+	// ··········· Account used to derive and control the new address lookup table.
+	//
+	// [2] = [WRITE, SIGNER] payer
+	// ··········· Account that will fund the new address lookup table.
+	//
+	// [3] = [] systemProgram
+	// ··········· System program for CPI.
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewCreateLookupTableInstructionBuilder creates a new `CreateLookupTable` instruction builder.
+func NewCreateLookupTableInstructionBuilder() *CreateLookupTable {
+	nd := &CreateLookupTable{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 4),
+	}
+	nd.AccountMetaSlice[3] = solana.Meta(solana.SystemProgramID)
+	return nd
+}
+
+// SetRecentSlot sets the "recentSlot" parameter.
+func (inst *CreateLookupTable) SetRecentSlot(recentSlot uint64) *CreateLookupTable {
+	inst.RecentSlot = &recentSlot
+	return inst
+}
+
+// SetBumpSeed sets the "bumpSeed" parameter.
+func (inst *CreateLookupTable) SetBumpSeed(bumpSeed uint8) *CreateLookupTable {
+	inst.BumpSeed = &bumpSeed
+	return inst
+}
+
+// SetLookupTableAccount sets the "lookupTable" account.
+// Uninitialized address lookup table account.
+func (inst *CreateLookupTable) SetLookupTableAccount(lookupTable solana.PublicKey) *CreateLookupTable {
+	inst.AccountMetaSlice[0] = solana.Meta(lookupTable).WRITE()
+	return inst
+}
+
+// GetLookupTableAccount gets the "lookupTable" account.
+// Uninitialized address lookup table account.
+func (inst *CreateLookupTable) GetLookupTableAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// SetAuthorityAccount sets the "authority" account.
+// Account used to derive and control the new address lookup table.
+func (inst *CreateLookupTable) SetAuthorityAccount(authority solana.PublicKey) *CreateLookupTable {
+	inst.AccountMetaSlice[1] = solana.Meta(authority)
+	return inst
+}
+
+// GetAuthorityAccount gets the "authority" account.
+// Account used to derive and control the new address lookup table.
+func (inst *CreateLookupTable) GetAuthorityAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// SetPayerAccount sets the "payer" account.
+// Account that will fund the new address lookup table.
+func (inst *CreateLookupTable) SetPayerAccount(payer solana.PublicKey) *CreateLookupTable {
+	inst.AccountMetaSlice[2] = solana.Meta(payer).WRITE().SIGNER()
+	return inst
+}
+
+// GetPayerAccount gets the "payer" account.
+// Account that will fund the new address lookup table.
+func (inst *CreateLookupTable) GetPayerAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+// SetSystemProgramAccount sets the "systemProgram" account.
+// System program for CPI.
+func (inst *CreateLookupTable) SetSystemProgramAccount(systemProgram solana.PublicKey) *CreateLookupTable {
+	inst.AccountMetaSlice[3] = solana.Meta(systemProgram)
+	return inst
+}
+
+// GetSystemProgramAccount gets the "systemProgram" account.
+// System program for CPI.
+func (inst *CreateLookupTable) GetSystemProgramAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[3]
+}
+
+func (inst CreateLookupTable) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint32(Instruction_CreateLookupTable, bin.LE),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst CreateLookupTable) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *CreateLookupTable) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.RecentSlot == nil {
+			return errors.New("RecentSlot parameter is not set")
+		}
+		if inst.BumpSeed == nil {
+			return errors.New("BumpSeed parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.LookupTable is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return errors.New("accounts.Authority is not set")
+		}
+		if inst.AccountMetaSlice[2] == nil {
+			return errors.New("accounts.Payer is not set")
+		}
+		if inst.AccountMetaSlice[3] == nil {
+			return errors.New("accounts.SystemProgram is not set")
+		}
+	}
+	return nil
+}
+
+func (obj CreateLookupTable) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	// Serialize `RecentSlot` param:
+	if err = encoder.WriteUint64(*obj.RecentSlot, bin.LE); err != nil {
+		return err
+	}
+	// Serialize `BumpSeed` param:
+	return encoder.WriteUint8(*obj.BumpSeed)
+}
+
+func (obj *CreateLookupTable) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	// Deserialize `RecentSlot`:
+	recentSlot, err := decoder.ReadUint64(bin.LE)
+	if err != nil {
+		return err
+	}
+	obj.RecentSlot = &recentSlot
+	// Deserialize `BumpSeed`:
+	bumpSeed, err := decoder.ReadUint8()
+	if err != nil {
+		return err
+	}
+	obj.BumpSeed = &bumpSeed
+	return nil
+}
+
+// NewCreateLookupTableInstruction declares a new CreateLookupTable instruction for the table
+// of the given authority and recent slot, whose address it derives.
+// The address is available with GetLookupTableAccount.
+func NewCreateLookupTableInstruction(
+	// Parameters:
+	recentSlot uint64,
+	// Accounts:
+	authority solana.PublicKey,
+	payer solana.PublicKey,
+) (*CreateLookupTable, error) {
+	lookupTable, bumpSeed, err := DeriveLookupTableAddress(authority, recentSlot)
+	if err != nil {
+		return nil, err
+	}
+	return NewCreateLookupTableInstructionBuilder().
+		SetRecentSlot(recentSlot).
+		SetBumpSeed(bumpSeed).
+		SetLookupTableAccount(lookupTable).
+		SetAuthorityAccount(authority).
+		SetPayerAccount(payer), nil
+}
@@ -0,0 +1,103 @@
+package addresslookuptable
+
+import (
+	"errors"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// Deactivate an address lookup table, making it unusable and
+// eligible for closure after a short period of time (once the deactivation slot
+// is no longer in the slot hashes sysvar, about 513 slots).
+type DeactivateLookupTable struct {
+	// [0] = [WRITE] lookupTable
+	// ··········· Address lookup table account to deactivate.
+	//
+	// [1] = [SIGNER] authority
+	// ··········· Current authority.
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewDeactivateLookupTableInstructionBuilder creates a new `DeactivateLookupTable` instruction builder.
+func NewDeactivateLookupTableInstructionBuilder() *DeactivateLookupTable {
+	nd := &DeactivateLookupTable{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 2),
+	}
+	return nd
+}
+
+// SetLookupTableAccount sets the "lookupTable" account.
+// Address lookup table account to deactivate.
+func (inst *DeactivateLookupTable) SetLookupTableAccount(lookupTable solana.PublicKey) *DeactivateLookupTable {
+	inst.AccountMetaSlice[0] = solana.Meta(lookupTable).WRITE()
+	return inst
+}
+
+// GetLookupTableAccount gets the "lookupTable" account.
+// Address lookup table account to deactivate.
+func (inst *DeactivateLookupTable) GetLookupTableAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// SetAuthorityAccount sets the "authority" account.
+// Current authority.
+func (inst *DeactivateLookupTable) SetAuthorityAccount(authority solana.PublicKey) *DeactivateLookupTable {
+	inst.AccountMetaSlice[1] = solana.Meta(authority).SIGNER()
+	return inst
+}
+
+// GetAuthorityAccount gets the "authority" account.
+// Current authority.
+func (inst *DeactivateLookupTable) GetAuthorityAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+func (inst DeactivateLookupTable) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint32(Instruction_DeactivateLookupTable, bin.LE),
+	}}
+}
+
+// ValidateAndBuild validates the instruction accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst DeactivateLookupTable) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *DeactivateLookupTable) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.LookupTable is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return errors.New("accounts.Authority is not set")
+		}
+	}
+	return nil
+}
+
+func (obj DeactivateLookupTable) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	return nil
+}
+
+func (obj *DeactivateLookupTable) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	return nil
+}
+
+// NewDeactivateLookupTableInstruction declares a new DeactivateLookupTable instruction with the provided accounts.
+func NewDeactivateLookupTableInstruction(
+	// Accounts:
+	lookupTable solana.PublicKey,
+	authority solana.PublicKey,
+) *DeactivateLookupTable {
+	return NewDeactivateLookupTableInstructionBuilder().
+		SetLookupTableAccount(lookupTable).
+		SetAuthorityAccount(authority)
+}
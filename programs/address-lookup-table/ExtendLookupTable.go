@@ -0,0 +1,177 @@
+package addresslookuptable
+
+import (
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// Extend an address lookup table with new addresses. Funding account and
+// system program account references are only required if the lookup table
+// account requires additional lamports to cover the rent-exempt balance
+// after being extended.
+type ExtendLookupTable struct {
+	// Addresses to append to the table.
+	NewAddresses solana.PublicKeySlice
+
+	// [0] = [WRITE] lookupTable
+	// ··········· Address lookup table account to extend.
+	//
+	// [1] = [SIGNER] authority
+	// ··········· Current authority.
+	//
+	// [2] = [WRITE, SIGNER] payer (optional)
+	// ··········· Account that will fund the table reallocation.
+	//
+	// [3] = [] systemProgram (optional)
+	// ··········· System program for CPI.
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewExtendLookupTableInstructionBuilder creates a new `ExtendLookupTable` instruction builder.
+func NewExtendLookupTableInstructionBuilder() *ExtendLookupTable {
+	nd := &ExtendLookupTable{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 4),
+	}
+	return nd
+}
+
+// SetNewAddresses sets the "newAddresses" parameter.
+// Addresses to append to the table.
+func (inst *ExtendLookupTable) SetNewAddresses(newAddresses ...solana.PublicKey) *ExtendLookupTable {
+	inst.NewAddresses = newAddresses
+	return inst
+}
+
+// SetLookupTableAccount sets the "lookupTable" account.
+// Address lookup table account to extend.
+func (inst *ExtendLookupTable) SetLookupTableAccount(lookupTable solana.PublicKey) *ExtendLookupTable {
+	inst.AccountMetaSlice[0] = solana.Meta(lookupTable).WRITE()
+	return inst
+}
+
+// GetLookupTableAccount gets the "lookupTable" account.
+// Address lookup table account to extend.
+func (inst *ExtendLookupTable) GetLookupTableAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice.Get(0)
+}
+
+// SetAuthorityAccount sets the "authority" account.
+// Current authority.
+func (inst *ExtendLookupTable) SetAuthorityAccount(authority solana.PublicKey) *ExtendLookupTable {
+	inst.AccountMetaSlice[1] = solana.Meta(authority).SIGNER()
+	return inst
+}
+
+// GetAuthorityAccount gets the "authority" account.
+// Current authority.
+func (inst *ExtendLookupTable) GetAuthorityAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice.Get(1)
+}
+
+// SetPayerAccount sets the "payer" account, and the system program along with it.
+// Account that will fund the table reallocation.
+func (inst *ExtendLookupTable) SetPayerAccount(payer solana.PublicKey) *ExtendLookupTable {
+	inst.AccountMetaSlice[2] = solana.Meta(payer).WRITE().SIGNER()
+	inst.AccountMetaSlice[3] = solana.Meta(solana.SystemProgramID)
+	return inst
+}
+
+// GetPayerAccount gets the "payer" account (nil if not set).
+// Account that will fund the table reallocation.
+func (inst *ExtendLookupTable) GetPayerAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice.Get(2)
+}
+
+func (inst ExtendLookupTable) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint32(Instruction_ExtendLookupTable, bin.LE),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst ExtendLookupTable) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *ExtendLookupTable) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if len(inst.NewAddresses) == 0 {
+			return errors.New("NewAddresses parameter is not set")
+		}
+		if len(inst.NewAddresses) > LOOKUP_TABLE_MAX_ADDRESSES {
+			return fmt.Errorf("too many addresses; got %v, but max is %v", len(inst.NewAddresses), LOOKUP_TABLE_MAX_ADDRESSES)
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice.Get(0) == nil {
+			return errors.New("accounts.LookupTable is not set")
+		}
+		if inst.AccountMetaSlice.Get(1) == nil {
+			return errors.New("accounts.Authority is not set")
+		}
+	}
+	return nil
+}
+
+func (obj ExtendLookupTable) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	// Serialize `NewAddresses` param (bincode: u64 length prefix):
+	if err = encoder.WriteUint64(uint64(len(obj.NewAddresses)), bin.LE); err != nil {
+		return err
+	}
+	for _, address := range obj.NewAddresses {
+		if err = encoder.WriteBytes(address[:], false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (obj *ExtendLookupTable) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	// Deserialize `NewAddresses`:
+	count, err := decoder.ReadUint64(bin.LE)
+	if err != nil {
+		return err
+	}
+	if count > uint64(decoder.Remaining()/32) {
+		return fmt.Errorf("%d addresses don't fit in %d bytes", count, decoder.Remaining())
+	}
+	obj.NewAddresses = make(solana.PublicKeySlice, count)
+	for i := range obj.NewAddresses {
+		if _, err = decoder.Read(obj.NewAddresses[i][:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewExtendLookupTableInstruction declares a new ExtendLookupTable instruction with the provided parameters and accounts.
+// The payer is only needed when the table must be topped up for rent; pass a zero key to omit it.
+func NewExtendLookupTableInstruction(
+	// Parameters:
+	newAddresses []solana.PublicKey,
+	// Accounts:
+	lookupTable solana.PublicKey,
+	authority solana.PublicKey,
+	payer solana.PublicKey,
+) *ExtendLookupTable {
+	inst := NewExtendLookupTableInstructionBuilder().
+		SetNewAddresses(newAddresses...).
+		SetLookupTableAccount(lookupTable).
+		SetAuthorityAccount(authority)
+	if !payer.IsZero() {
+		inst.SetPayerAccount(payer)
+	}
+	return inst
+}
@@ -0,0 +1,102 @@
+package addresslookuptable
+
+import (
+	"errors"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// Permanently freeze an address lookup table, making it immutable.
+// The table must not be empty.
+type FreezeLookupTable struct {
+	// [0] = [WRITE] lookupTable
+	// ··········· Address lookup table account to freeze.
+	//
+	// [1] = [SIGNER] authority
+	// ··········· Current authority.
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewFreezeLookupTableInstructionBuilder creates a new `FreezeLookupTable` instruction builder.
+func NewFreezeLookupTableInstructionBuilder() *FreezeLookupTable {
+	nd := &FreezeLookupTable{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 2),
+	}
+	return nd
+}
+
+// SetLookupTableAccount sets the "lookupTable" account.
+// Address lookup table account to freeze.
+func (inst *FreezeLookupTable) SetLookupTableAccount(lookupTable solana.PublicKey) *FreezeLookupTable {
+	inst.AccountMetaSlice[0] = solana.Meta(lookupTable).WRITE()
+	return inst
+}
+
+// GetLookupTableAccount gets the "lookupTable" account.
+// Address lookup table account to freeze.
+func (inst *FreezeLookupTable) GetLookupTableAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// SetAuthorityAccount sets the "authority" account.
+// Current authority.
+func (inst *FreezeLookupTable) SetAuthorityAccount(authority solana.PublicKey) *FreezeLookupTable {
+	inst.AccountMetaSlice[1] = solana.Meta(authority).SIGNER()
+	return inst
+}
+
+// GetAuthorityAccount gets the "authority" account.
+// Current authority.
+func (inst *FreezeLookupTable) GetAuthorityAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+func (inst FreezeLookupTable) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint32(Instruction_FreezeLookupTable, bin.LE),
+	}}
+}
+
+// ValidateAndBuild validates the instruction accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst FreezeLookupTable) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *FreezeLookupTable) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.LookupTable is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return errors.New("accounts.Authority is not set")
+		}
+	}
+	return nil
+}
+
+func (obj FreezeLookupTable) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	return nil
+}
+
+func (obj *FreezeLookupTable) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	return nil
+}
+
+// NewFreezeLookupTableInstruction declares a new FreezeLookupTable instruction with the provided accounts.
+func NewFreezeLookupTableInstruction(
+	// Accounts:
+	lookupTable solana.PublicKey,
+	authority solana.PublicKey,
+) *FreezeLookupTable {
+	return NewFreezeLookupTableInstructionBuilder().
+		SetLookupTableAccount(lookupTable).
+		SetAuthorityAccount(authority)
+}
@@ -0,0 +1,125 @@
+package addresslookuptable
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/scatkit/pumpdexer/solana"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_RoundTrip(t *testing.T) {
+	authority := solana.NewWallet().PublicKey()
+	payer := solana.NewWallet().PublicKey()
+	table := solana.NewWallet().PublicKey()
+	addresses := []solana.PublicKey{solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()}
+
+	create, err := NewCreateLookupTableInstruction(300_000_000, authority, payer)
+	require.NoError(t, err)
+
+	extendData := []byte{byte(Instruction_ExtendLookupTable), 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0}
+	extendData = append(append(extendData, addresses[0][:]...), addresses[1][:]...)
+
+	tests := []struct {
+		name         string
+		builder      interface{ ValidateAndBuild() (*Instruction, error) }
+		wantData     []byte
+		wantAccounts int
+	}{
+		{
+			name:         "CreateLookupTable",
+			builder:      create,
+			wantData:     []byte{byte(Instruction_CreateLookupTable), 0, 0, 0, 0x00, 0xa3, 0xe1, 0x11, 0, 0, 0, 0, *create.BumpSeed},
+			wantAccounts: 4,
+		},
+		{
+			name:         "ExtendLookupTable",
+			builder:      NewExtendLookupTableInstruction(addresses, table, authority, payer),
+			wantData:     extendData,
+			wantAccounts: 4,
+		},
+		{
+			name:         "ExtendLookupTable without payer",
+			builder:      NewExtendLookupTableInstruction(addresses, table, authority, solana.PublicKey{}),
+			wantData:     extendData,
+			wantAccounts: 2,
+		},
+		{
+			name:         "FreezeLookupTable",
+			builder:      NewFreezeLookupTableInstruction(table, authority),
+			wantData:     []byte{byte(Instruction_FreezeLookupTable), 0, 0, 0},
+			wantAccounts: 2,
+		},
+		{
+			name:         "DeactivateLookupTable",
+			builder:      NewDeactivateLookupTableInstruction(table, authority),
+			wantData:     []byte{byte(Instruction_DeactivateLookupTable), 0, 0, 0},
+			wantAccounts: 2,
+		},
+		{
+			name:         "CloseLookupTable",
+			builder:      NewCloseLookupTableInstruction(table, authority, payer),
+			wantData:     []byte{byte(Instruction_CloseLookupTable), 0, 0, 0},
+			wantAccounts: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			inst, err := test.builder.ValidateAndBuild()
+			require.NoError(t, err)
+			require.Len(t, inst.Accounts(), test.wantAccounts)
+
+			data, err := inst.Data()
+			require.NoError(t, err)
+			require.Equal(t, test.wantData, data)
+
+			decoded, err := solana.DecodeInstruction(ProgramID, inst.Accounts(), data)
+			require.NoError(t, err)
+			got := decoded.(*Instruction)
+			require.Equal(t, inst.TypeID, got.TypeID)
+			require.Equal(t, InstructionIDToName(inst.TypeID.Uint32()), InstructionIDToName(got.TypeID.Uint32()))
+			require.Equal(t, inst.Accounts(), got.Accounts())
+
+			reencoded, err := got.Data()
+			require.NoError(t, err)
+			require.Equal(t, data, reencoded)
+		})
+	}
+}
+
+func TestDeriveLookupTableAddress(t *testing.T) {
+	authority := solana.NewWallet().PublicKey()
+
+	address, bump, err := DeriveLookupTableAddress(authority, 42)
+	require.NoError(t, err)
+
+	slot := make([]byte, 8)
+	binary.LittleEndian.PutUint64(slot, 42)
+	expected, err := solana.CreateProgramAddress([][]byte{authority[:], slot, {bump}}, solana.AddressLookupTableProgramID)
+	require.NoError(t, err)
+	require.Equal(t, expected, address)
+
+	other, _, err := DeriveLookupTableAddress(authority, 43)
+	require.NoError(t, err)
+	require.NotEqual(t, address, other)
+
+	create, err := NewCreateLookupTableInstruction(42, authority, solana.NewWallet().PublicKey())
+	require.NoError(t, err)
+	require.Equal(t, address, create.GetLookupTableAccount().PublicKey)
+	require.False(t, create.GetAuthorityAccount().IsSigner)
+}
+
+func TestExtendLookupTable_Validate(t *testing.T) {
+	table := solana.NewWallet().PublicKey()
+	authority := solana.NewWallet().PublicKey()
+
+	_, err := NewExtendLookupTableInstruction(nil, table, authority, solana.PublicKey{}).ValidateAndBuild()
+	require.EqualError(t, err, "NewAddresses parameter is not set")
+
+	_, err = NewExtendLookupTableInstructionBuilder().
+		SetNewAddresses(table).
+		SetLookupTableAccount(table).
+		ValidateAndBuild()
+	require.EqualError(t, err, "accounts.Authority is not set")
+}
@@ -0,0 +1,59 @@
+package addresslookuptable
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/scatkit/pumpdexer/rpc"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// GetAddressLookupTables fetches the given tables in a single call and returns their addresses,
+// in the form solana.TransactionAddressTables and Message.SetAddressTables take.
+// It fails if a table doesn't exist.
+func GetAddressLookupTables(
+	ctx context.Context,
+	rpcClient *rpc.Client,
+	addresses ...solana.PublicKey,
+) (map[solana.PublicKey]solana.PublicKeySlice, error) {
+	tables := make(map[solana.PublicKey]solana.PublicKeySlice, len(addresses))
+	if len(addresses) == 0 {
+		return tables, nil
+	}
+	res, err := rpcClient.GetMultipleAccountsWithOpts(ctx, addresses, &rpc.GetMultipleAccountsOpts{Encoding: solana.EncodingBase64})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Value) != len(addresses) {
+		return nil, fmt.Errorf("expected %d accounts, got %d", len(addresses), len(res.Value))
+	}
+	for i, account := range res.Value {
+		if account == nil {
+			return nil, fmt.Errorf("address lookup table %s not found", addresses[i])
+		}
+		if !account.Owner.Equals(ProgramID) {
+			return nil, fmt.Errorf("account %s is owned by %s, not by the address lookup table program", addresses[i], account.Owner)
+		}
+		state, err := DecodeAddressLookupTableState(account.Data.GetBinary())
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode address lookup table %s: %w", addresses[i], err)
+		}
+		tables[addresses[i]] = state.Addresses
+	}
+	return tables, nil
+}
+
+// ResolveAddressTables fetches every table referenced by the (v0) message
+// and sets them with Message.SetAddressTables, so its accounts can be read with Message.GetAllKeys.
+// A message without lookups is left as is.
+func ResolveAddressTables(ctx context.Context, rpcClient *rpc.Client, message *solana.Message) error {
+	ids := message.AddressTableLookups.GetTableIDs()
+	if len(ids) == 0 {
+		return nil
+	}
+	tables, err := GetAddressLookupTables(ctx, rpcClient, ids...)
+	if err != nil {
+		return err
+	}
+	return message.SetAddressTables(tables)
+}
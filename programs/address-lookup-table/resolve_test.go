@@ -0,0 +1,119 @@
+package addresslookuptable
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/rpc"
+	"github.com/scatkit/pumpdexer/solana"
+	"github.com/scatkit/pumpdexer/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// encodeTable lays out a lookup table account: the 56 bytes of metadata, then the addresses.
+func encodeTable(authority *solana.PublicKey, addresses ...solana.PublicKey) []byte {
+	data := make([]byte, LOOKUP_TABLE_META_SIZE)
+	binary.LittleEndian.PutUint32(data[0:], 1)
+	binary.LittleEndian.PutUint64(data[4:], math.MaxUint64)
+	if authority != nil {
+		data[21] = 1
+		copy(data[22:], authority[:])
+	}
+	for _, address := range addresses {
+		data = append(data, address[:]...)
+	}
+	return data
+}
+
+func newKeys(n int) solana.PublicKeySlice {
+	keys := make(solana.PublicKeySlice, n)
+	for i := range keys {
+		keys[i] = solana.NewWallet().PublicKey()
+	}
+	return keys
+}
+
+func TestResolveAddressTables(t *testing.T) {
+	srv := testutil.NewServer()
+	defer srv.Close()
+	client := rpc.New(srv.URL)
+
+	authority := solana.NewWallet().PublicKey()
+	tableA, tableB := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	addressesA, addressesB := newKeys(3), newKeys(2)
+	srv.SetAccount(tableA, &testutil.Account{Owner: ProgramID, Data: encodeTable(&authority, addressesA...)})
+	srv.SetAccount(tableB, &testutil.Account{Owner: ProgramID, Data: encodeTable(nil, addressesB...)})
+
+	static := newKeys(2)
+	message := solana.Message{
+		Header:      solana.MessageHeader{NumRequiredSignatures: 1, NumReadonlyUnsignedAccounts: 1},
+		AccountKeys: static,
+		Instructions: []solana.CompiledInstruction{
+			{ProgramIDIndex: 1, Accounts: []uint16{0, 2, 3, 4, 5, 6}, Data: []byte{1}},
+		},
+	}
+	message.SetAddressTableLookups([]solana.MessageAddressTableLookup{
+		{AccountKey: tableA, WritableIndexes: []uint8{2}, ReadonlyIndexes: []uint8{0}},
+		{AccountKey: tableB, WritableIndexes: []uint8{1}, ReadonlyIndexes: []uint8{0}},
+	})
+
+	// Round trip through the wire format, as when inspecting a fetched transaction.
+	data, err := message.MarshalBinary()
+	require.NoError(t, err)
+	decoded := new(solana.Message)
+	require.NoError(t, decoded.UnmarshalWithDecoder(bin.NewBinDecoder(data)))
+
+	_, err = decoded.GetAllKeys()
+	require.Error(t, err, "tables not set yet")
+
+	require.NoError(t, ResolveAddressTables(context.Background(), client, decoded))
+	require.Len(t, decoded.GetAddressTables(), 2)
+
+	keys, err := decoded.GetAllKeys()
+	require.NoError(t, err)
+	require.Equal(t, solana.PublicKeySlice{
+		static[0], static[1],
+		addressesA[2], addressesB[1], // writable, table after table
+		addressesA[0], addressesB[0], // then readonly
+	}, keys)
+
+	require.NoError(t, decoded.ResolveLookups())
+	require.Equal(t, keys, decoded.AccountKeys)
+	reencoded, err := decoded.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, data, reencoded, "only the static keys are encoded")
+
+	require.Error(t, ResolveAddressTables(context.Background(), client, decoded), "tables already set")
+}
+
+func TestGetAddressLookupTables(t *testing.T) {
+	srv := testutil.NewServer()
+	defer srv.Close()
+	client := rpc.New(srv.URL)
+
+	table := solana.NewWallet().PublicKey()
+	addresses := newKeys(4)
+	srv.SetAccount(table, &testutil.Account{Owner: ProgramID, Data: encodeTable(nil, addresses...)})
+	notATable := solana.NewWallet().PublicKey()
+	srv.SetAccount(notATable, &testutil.Account{Owner: solana.SystemProgramID, Lamports: 1})
+
+	tables, err := GetAddressLookupTables(context.Background(), client, table)
+	require.NoError(t, err)
+	require.Equal(t, addresses, tables[table])
+
+	_, err = GetAddressLookupTables(context.Background(), client, table, solana.NewWallet().PublicKey())
+	require.ErrorContains(t, err, "not found")
+
+	_, err = GetAddressLookupTables(context.Background(), client, notATable)
+	require.ErrorContains(t, err, "not by the address lookup table program")
+
+	// A legacy message has nothing to resolve.
+	message := &solana.Message{AccountKeys: newKeys(2)}
+	require.NoError(t, ResolveAddressTables(context.Background(), client, message))
+	keys, err := message.GetAllKeys()
+	require.NoError(t, err)
+	require.Equal(t, message.AccountKeys, keys)
+}
@@ -12,19 +12,23 @@ type Create struct {
 	Wallet solana.PublicKey `bin:"-" borsh_skip:"true"`
 	Mint   solana.PublicKey `bin:"-" borsh_skip:"true"`
 
+	// Token program of the mint; the legacy token program if not set.
+	TokenProgram solana.PublicKey `bin:"-" borsh_skip:"true"`
+
   // [0] = [WRITE, SIGNER] Payer: `Funding account`
 	// [1] = [WRITE] AssociatedTokenAccount: `Associated token account address to be created`
 	// [2] = [] Wallet: `Wallet address for the new associated token account`
 	// [3] = [] TokenMint: `The token mint for the new associated token account`
 	// [4] = [] SystemProgram: `System program ID`
-  // [5] = [] TokenProgram: `SPL token program ID`
+  // [5] = [] TokenProgram: `SPL token program ID (Token or Token-2022)`
 	// [6] = [] SysVarRent: `SysVarRentPubkey`
 	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
 }
 
 func (inst Create) Build() *Instruction{
   // find the associated token address. It's created from user's wallet + token's mint address
-  associatedTokenAddress, _, _ := solana.FindAssociatedTokenAddress(inst.Wallet, inst.Mint)
+  tokenProgram := tokenProgramOrDefault(inst.TokenProgram)
+  associatedTokenAddress, _, _ := solana.FindAssociatedTokenAddressWithProgramID(inst.Wallet, inst.Mint, tokenProgram)
   
   keys := []*solana.AccountMeta{
     {
@@ -53,7 +57,7 @@ func (inst Create) Build() *Instruction{
       IsWritable:   false,
     },
     { 
-      PublicKey:    solana.TokenProgramID,
+      PublicKey:    tokenProgram,
       IsSigner:     false,
       IsWritable:   false,
     },
@@ -92,9 +96,10 @@ func (inst *Create) Validate() error {
 	if inst.Mint.IsZero() {
 		return errors.New("Mint not set")
 	}
-	_, _, err := solana.FindAssociatedTokenAddress(
+	_, _, err := solana.FindAssociatedTokenAddressWithProgramID(
 		inst.Wallet,
 		inst.Mint,
+		tokenProgramOrDefault(inst.TokenProgram),
 	)
 	if err != nil {
 		return fmt.Errorf("error while FindAssociatedTokenAddress: %w", err)
@@ -131,6 +136,12 @@ func (inst *Create) SetMint(mint solana.PublicKey) *Create {
 	return inst
 }
 
+// SetTokenProgram sets the token program of the mint, for Token-2022 mints.
+func (inst *Create) SetTokenProgram(tokenProgram solana.PublicKey) *Create {
+	inst.TokenProgram = tokenProgram
+	return inst
+}
+
 func NewCreateInstruction(
   payer solana.PublicKey,
   walletAddress solana.PublicKey,
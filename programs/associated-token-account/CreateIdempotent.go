@@ -0,0 +1,124 @@
+package associatedtokenaccount
+
+import (
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// Create the associated token account of the wallet for the mint, if it doesn't already exist.
+// Unlike Create, it succeeds when the account already exists (with the same owner).
+type CreateIdempotent struct {
+	Payer  solana.PublicKey `bin:"-" borsh_skip:"true"`
+	Wallet solana.PublicKey `bin:"-" borsh_skip:"true"`
+	Mint   solana.PublicKey `bin:"-" borsh_skip:"true"`
+
+	// Token program of the mint; the legacy token program if not set.
+	TokenProgram solana.PublicKey `bin:"-" borsh_skip:"true"`
+
+	// [0] = [WRITE, SIGNER] Payer: `Funding account`
+	// [1] = [WRITE] AssociatedTokenAccount: `Associated token account address to be created`
+	// [2] = [] Wallet: `Wallet address for the new associated token account`
+	// [3] = [] TokenMint: `The token mint for the new associated token account`
+	// [4] = [] SystemProgram: `System program ID`
+	// [5] = [] TokenProgram: `SPL token program ID (Token or Token-2022)`
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+func (inst CreateIdempotent) Build() *Instruction {
+	tokenProgram := tokenProgramOrDefault(inst.TokenProgram)
+	associatedTokenAddress, _, _ := solana.FindAssociatedTokenAddressWithProgramID(inst.Wallet, inst.Mint, tokenProgram)
+
+	inst.AccountMetaSlice = solana.AccountMetaSlice{
+		solana.Meta(inst.Payer).WRITE().SIGNER(),
+		solana.Meta(associatedTokenAddress).WRITE(),
+		solana.Meta(inst.Wallet),
+		solana.Meta(inst.Mint),
+		solana.Meta(solana.SystemProgramID),
+		solana.Meta(tokenProgram),
+	}
+
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint8(Instruction_CreateIdempotent),
+	}}
+}
+
+// ValidateAndBuild validates the instruction accounts.
+// If there is a validation error, return the error.
+// Otherwise, build and return the instruction.
+func (inst CreateIdempotent) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *CreateIdempotent) Validate() error {
+	if inst.Payer.IsZero() {
+		return errors.New("Payer not set")
+	}
+	if inst.Wallet.IsZero() {
+		return errors.New("Wallet not set")
+	}
+	if inst.Mint.IsZero() {
+		return errors.New("Mint not set")
+	}
+	_, _, err := solana.FindAssociatedTokenAddressWithProgramID(
+		inst.Wallet,
+		inst.Mint,
+		tokenProgramOrDefault(inst.TokenProgram),
+	)
+	if err != nil {
+		return fmt.Errorf("error while FindAssociatedTokenAddress: %w", err)
+	}
+	return nil
+}
+
+func (inst CreateIdempotent) MarshalWithEncoder(encoder *bin.Encoder) error {
+	return nil
+}
+
+func (inst *CreateIdempotent) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	return nil
+}
+
+// NewCreateIdempotentInstructionBuilder creates a new `CreateIdempotent` instruction builder.
+func NewCreateIdempotentInstructionBuilder() *CreateIdempotent {
+	nd := &CreateIdempotent{}
+	return nd
+}
+
+func (inst *CreateIdempotent) SetPayer(payer solana.PublicKey) *CreateIdempotent {
+	inst.Payer = payer
+	return inst
+}
+
+func (inst *CreateIdempotent) SetWallet(wallet solana.PublicKey) *CreateIdempotent {
+	inst.Wallet = wallet
+	return inst
+}
+
+func (inst *CreateIdempotent) SetMint(mint solana.PublicKey) *CreateIdempotent {
+	inst.Mint = mint
+	return inst
+}
+
+// SetTokenProgram sets the token program of the mint, for Token-2022 mints.
+func (inst *CreateIdempotent) SetTokenProgram(tokenProgram solana.PublicKey) *CreateIdempotent {
+	inst.TokenProgram = tokenProgram
+	return inst
+}
+
+func NewCreateIdempotentInstruction(
+	payer solana.PublicKey,
+	walletAddress solana.PublicKey,
+	splTokenMintAddress solana.PublicKey,
+) *CreateIdempotent {
+	return NewCreateIdempotentInstructionBuilder().
+		SetPayer(payer).
+		SetWallet(walletAddress).
+		SetMint(splTokenMintAddress)
+}
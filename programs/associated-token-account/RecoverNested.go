@@ -0,0 +1,121 @@
+package associatedtokenaccount
+
+import (
+	"errors"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// Transfer the tokens of a nested associated token account (the associated token account,
+// for the nested mint, of the wallet's associated token account for the owner mint)
+// to the wallet's associated token account for the nested mint, and close it.
+// The wallet must sign.
+type RecoverNested struct {
+	Wallet     solana.PublicKey `bin:"-" borsh_skip:"true"`
+	OwnerMint  solana.PublicKey `bin:"-" borsh_skip:"true"`
+	NestedMint solana.PublicKey `bin:"-" borsh_skip:"true"`
+
+	// Token program of the mints; the legacy token program if not set.
+	TokenProgram solana.PublicKey `bin:"-" borsh_skip:"true"`
+
+	// [0] = [WRITE] NestedAssociatedTokenAccount: `Nested associated token account, must be owned by [3]`
+	// [1] = [] NestedTokenMint: `Token mint for the nested associated token account`
+	// [2] = [WRITE] DestinationAssociatedTokenAccount: `Wallet's associated token account for the nested mint`
+	// [3] = [] OwnerAssociatedTokenAccount: `Owner associated token account address, must be owned by [5]`
+	// [4] = [] OwnerTokenMint: `Token mint for the owner associated token account`
+	// [5] = [WRITE, SIGNER] Wallet: `Wallet address for the owner associated token account`
+	// [6] = [] TokenProgram: `SPL token program ID (Token or Token-2022)`
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+func (inst RecoverNested) Build() *Instruction {
+	tokenProgram := tokenProgramOrDefault(inst.TokenProgram)
+	ownerAssociatedTokenAddress, _, _ := solana.FindAssociatedTokenAddressWithProgramID(inst.Wallet, inst.OwnerMint, tokenProgram)
+	nestedAssociatedTokenAddress, _, _ := solana.FindAssociatedTokenAddressWithProgramID(ownerAssociatedTokenAddress, inst.NestedMint, tokenProgram)
+	destinationAssociatedTokenAddress, _, _ := solana.FindAssociatedTokenAddressWithProgramID(inst.Wallet, inst.NestedMint, tokenProgram)
+
+	inst.AccountMetaSlice = solana.AccountMetaSlice{
+		solana.Meta(nestedAssociatedTokenAddress).WRITE(),
+		solana.Meta(inst.NestedMint),
+		solana.Meta(destinationAssociatedTokenAddress).WRITE(),
+		solana.Meta(ownerAssociatedTokenAddress),
+		solana.Meta(inst.OwnerMint),
+		solana.Meta(inst.Wallet).WRITE().SIGNER(),
+		solana.Meta(tokenProgram),
+	}
+
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint8(Instruction_RecoverNested),
+	}}
+}
+
+// ValidateAndBuild validates the instruction accounts.
+// If there is a validation error, return the error.
+// Otherwise, build and return the instruction.
+func (inst RecoverNested) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *RecoverNested) Validate() error {
+	if inst.Wallet.IsZero() {
+		return errors.New("Wallet not set")
+	}
+	if inst.OwnerMint.IsZero() {
+		return errors.New("OwnerMint not set")
+	}
+	if inst.NestedMint.IsZero() {
+		return errors.New("NestedMint not set")
+	}
+	return nil
+}
+
+func (inst RecoverNested) MarshalWithEncoder(encoder *bin.Encoder) error {
+	return nil
+}
+
+func (inst *RecoverNested) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	return nil
+}
+
+// NewRecoverNestedInstructionBuilder creates a new `RecoverNested` instruction builder.
+func NewRecoverNestedInstructionBuilder() *RecoverNested {
+	nd := &RecoverNested{}
+	return nd
+}
+
+func (inst *RecoverNested) SetWallet(wallet solana.PublicKey) *RecoverNested {
+	inst.Wallet = wallet
+	return inst
+}
+
+func (inst *RecoverNested) SetOwnerMint(ownerMint solana.PublicKey) *RecoverNested {
+	inst.OwnerMint = ownerMint
+	return inst
+}
+
+func (inst *RecoverNested) SetNestedMint(nestedMint solana.PublicKey) *RecoverNested {
+	inst.NestedMint = nestedMint
+	return inst
+}
+
+// SetTokenProgram sets the token program of the mints, for Token-2022 mints.
+func (inst *RecoverNested) SetTokenProgram(tokenProgram solana.PublicKey) *RecoverNested {
+	inst.TokenProgram = tokenProgram
+	return inst
+}
+
+func NewRecoverNestedInstruction(
+	walletAddress solana.PublicKey,
+	ownerMint solana.PublicKey,
+	nestedMint solana.PublicKey,
+) *RecoverNested {
+	return NewRecoverNestedInstructionBuilder().
+		SetWallet(walletAddress).
+		SetOwnerMint(ownerMint).
+		SetNestedMint(nestedMint)
+}
@@ -1,6 +1,7 @@
 package associatedtokenaccount
 
 import(
+  "bytes"
   "fmt"
   "github.com/scatkit/pumpdexer/solana"
   bin "github.com/gagliardetto/binary"
@@ -21,6 +22,42 @@ func init() {
 	solana.RegisterInstructionDecoder(ProgramID, registryDecodeInstruction)
 }
 
+const (
+	// Creates an associated token account for the given wallet address and token mint.
+	// Fails if the account exists.
+	Instruction_Create uint8 = iota
+
+	// Creates an associated token account for the given wallet address and token mint,
+	// if it doesn't already exist. Fails if the account exists, but with a different owner.
+	Instruction_CreateIdempotent
+
+	// Transfers from and closes a nested associated token account: an associated token
+	// account owned by an associated token account.
+	Instruction_RecoverNested
+)
+
+// InstructionIDToName returns the name of the instruction given its ID.
+func InstructionIDToName(id uint8) string {
+	switch id {
+	case Instruction_Create:
+		return "Create"
+	case Instruction_CreateIdempotent:
+		return "CreateIdempotent"
+	case Instruction_RecoverNested:
+		return "RecoverNested"
+	default:
+		return ""
+	}
+}
+
+// tokenProgramOrDefault returns the given token program, or the legacy one if not set.
+func tokenProgramOrDefault(tokenProgram solana.PublicKey) solana.PublicKey {
+	if tokenProgram.IsZero() {
+		return solana.TokenProgramID
+	}
+	return tokenProgram
+}
+
 func registryDecodeInstruction(accounts []*solana.AccountMeta, data []byte) (interface{}, error){
   inst, err := DecodeInstruction(accounts, data)
 	if err != nil {
@@ -45,23 +82,46 @@ func (inst *Instruction) Accounts() (out []*solana.AccountMeta) {
 
 // binary encoded transaction
 func (inst *Instruction) Data() ([]byte, error){
-  return []byte{}, nil
+  buf := new(bytes.Buffer)
+  if err := bin.NewBinEncoder(buf).Encode(inst); err != nil {
+    return nil, fmt.Errorf("unable to encode instruction: %w", err)
+  }
+  return buf.Bytes(), nil
 }
 
+// The variants are listed in ID order: the position of each is its type ID.
 var InstructionImplDef = bin.NewVariantDefinition(
-	bin.NoTypeIDEncoding, // NOTE: the associated-token-account program has no ID encoding.
+	bin.Uint8TypeIDEncoding,
 	[]bin.VariantType{
     {
       "Create", (*Create)(nil), // passing the nil poiner
     },
+    {
+      "CreateIdempotent", (*CreateIdempotent)(nil),
+    },
+    {
+      "RecoverNested", (*RecoverNested)(nil),
+    },
   },
 )
 
+// NOTE: Create is encoded without an ID (empty data), the original form of the instruction,
+// which the program still reads as Create.
 func (inst *Instruction) UnmarshalWithDecoder(decoder *bin.Decoder) error{
+  if decoder.Remaining() == 0 {
+    inst.TypeID = bin.TypeIDFromUint8(Instruction_Create)
+    inst.Impl = new(Create)
+    return nil
+  }
   return inst.BaseVariant.UnmarshalBinaryVariant(decoder, InstructionImplDef)
 }
 
 func (inst Instruction) MarshalWithEncoder(encoder *bin.Encoder) error {
+	if inst.TypeID.Uint8() != Instruction_Create {
+		if err := encoder.WriteUint8(inst.TypeID.Uint8()); err != nil {
+			return fmt.Errorf("unable to write variant type: %w", err)
+		}
+	}
 	return encoder.Encode(inst.Impl)
 }
 
@@ -0,0 +1,91 @@
+package associatedtokenaccount
+
+import (
+	"testing"
+
+	"github.com/scatkit/pumpdexer/solana"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_RoundTrip(t *testing.T) {
+	payer := solana.NewWallet().PublicKey()
+	wallet := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+	nestedMint := solana.NewWallet().PublicKey()
+
+	tests := []struct {
+		name         string
+		builder      interface{ ValidateAndBuild() (*Instruction, error) }
+		wantData     []byte
+		wantAccounts int
+	}{
+		{"Create", NewCreateInstruction(payer, wallet, mint), nil, 7}, // no ID, as originally
+		{"CreateIdempotent", NewCreateIdempotentInstruction(payer, wallet, mint), []byte{Instruction_CreateIdempotent}, 6},
+		{"RecoverNested", NewRecoverNestedInstruction(wallet, mint, nestedMint), []byte{Instruction_RecoverNested}, 7},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			inst, err := test.builder.ValidateAndBuild()
+			require.NoError(t, err)
+			require.Len(t, inst.Accounts(), test.wantAccounts)
+
+			data, err := inst.Data()
+			require.NoError(t, err)
+			require.Equal(t, test.wantData, data)
+
+			decoded, err := solana.DecodeInstruction(ProgramID, inst.Accounts(), data)
+			require.NoError(t, err)
+			got := decoded.(*Instruction)
+			require.Equal(t, test.name, InstructionIDToName(got.TypeID.Uint8()))
+			require.Equal(t, inst.Accounts(), got.Accounts())
+
+			reencoded, err := got.Data()
+			require.NoError(t, err)
+			require.Equal(t, data, reencoded)
+		})
+	}
+}
+
+func TestTokenProgram(t *testing.T) {
+	payer := solana.NewWallet().PublicKey()
+	wallet := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+
+	legacy, _, err := solana.FindAssociatedTokenAddress(wallet, mint)
+	require.NoError(t, err)
+	token2022, _, err := solana.FindAssociatedTokenAddressWithProgramID(wallet, mint, solana.Token2022ProgramID)
+	require.NoError(t, err)
+	require.NotEqual(t, legacy, token2022)
+
+	accounts := NewCreateIdempotentInstruction(payer, wallet, mint).Build().Accounts()
+	require.Equal(t, legacy, accounts[1].PublicKey)
+	require.Equal(t, solana.TokenProgramID, accounts[5].PublicKey)
+
+	accounts = NewCreateIdempotentInstruction(payer, wallet, mint).SetTokenProgram(solana.Token2022ProgramID).Build().Accounts()
+	require.Equal(t, token2022, accounts[1].PublicKey)
+	require.Equal(t, solana.Token2022ProgramID, accounts[5].PublicKey)
+
+	accounts = NewCreateInstruction(payer, wallet, mint).SetTokenProgram(solana.Token2022ProgramID).Build().Accounts()
+	require.Equal(t, token2022, accounts[1].PublicKey)
+	require.Equal(t, solana.Token2022ProgramID, accounts[5].PublicKey)
+}
+
+func TestRecoverNested_Accounts(t *testing.T) {
+	wallet := solana.NewWallet().PublicKey()
+	ownerMint := solana.NewWallet().PublicKey()
+	nestedMint := solana.NewWallet().PublicKey()
+
+	ownerATA, _, _ := solana.FindAssociatedTokenAddress(wallet, ownerMint)
+	nestedATA, _, _ := solana.FindAssociatedTokenAddress(ownerATA, nestedMint)
+	destinationATA, _, _ := solana.FindAssociatedTokenAddress(wallet, nestedMint)
+
+	accounts := NewRecoverNestedInstruction(wallet, ownerMint, nestedMint).Build().Accounts()
+	require.Equal(t, nestedATA, accounts[0].PublicKey)
+	require.Equal(t, destinationATA, accounts[2].PublicKey)
+	require.Equal(t, ownerATA, accounts[3].PublicKey)
+	require.True(t, accounts[5].IsSigner)
+
+	_, err := NewRecoverNestedInstructionBuilder().SetWallet(wallet).ValidateAndBuild()
+	require.EqualError(t, err, "OwnerMint not set")
+}
@@ -0,0 +1,89 @@
+package computebudget
+
+import (
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	solana "github.com/scatkit/pumpdexer/solana"
+)
+
+// Request a specific transaction-wide program heap region size in bytes.
+// The value requested must be a multiple of 1024. This new heap region
+// size applies to each program executed in the transaction, including all
+// calls to CPIs.
+type RequestHeapFrame struct {
+	// Heap size in bytes, a multiple of 1024 between 32KiB and 256KiB.
+	Bytes *uint32
+
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewRequestHeapFrameInstructionBuilder creates a new `RequestHeapFrame` instruction builder.
+func NewRequestHeapFrameInstructionBuilder() *RequestHeapFrame {
+	nd := &RequestHeapFrame{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 0),
+	}
+	return nd
+}
+
+// SetBytes sets the "bytes" parameter.
+// Heap size in bytes, a multiple of 1024 between 32KiB and 256KiB.
+func (inst *RequestHeapFrame) SetBytes(bytes uint32) *RequestHeapFrame {
+	inst.Bytes = &bytes
+	return inst
+}
+
+func (inst RequestHeapFrame) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint8(Instruction_RequestHeapFrame),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst RequestHeapFrame) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *RequestHeapFrame) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Bytes == nil {
+			return errors.New("Bytes parameter is not set")
+		}
+		if *inst.Bytes%HEAP_FRAME_BYTES_GRANULARITY != 0 || *inst.Bytes < MIN_HEAP_FRAME_BYTES || *inst.Bytes > MAX_HEAP_FRAME_BYTES {
+			return fmt.Errorf("Bytes is %v, but must be a multiple of %v between %v and %v", *inst.Bytes, HEAP_FRAME_BYTES_GRANULARITY, MIN_HEAP_FRAME_BYTES, MAX_HEAP_FRAME_BYTES)
+		}
+	}
+	return nil
+}
+
+func (obj RequestHeapFrame) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	// Serialize `Bytes` param:
+	return encoder.WriteUint32(*obj.Bytes, bin.LE)
+}
+
+func (obj *RequestHeapFrame) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	// Deserialize `Bytes`:
+	bytes, err := decoder.ReadUint32(bin.LE)
+	if err != nil {
+		return err
+	}
+	obj.Bytes = &bytes
+	return nil
+}
+
+// NewRequestHeapFrameInstruction declares a new RequestHeapFrame instruction with the provided parameters.
+func NewRequestHeapFrameInstruction(
+	// Parameters:
+	bytes uint32,
+) *RequestHeapFrame {
+	return NewRequestHeapFrameInstructionBuilder().
+		SetBytes(bytes)
+}
@@ -0,0 +1,86 @@
+package computebudget
+
+import (
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	solana "github.com/scatkit/pumpdexer/solana"
+)
+
+// Set a specific compute unit limit that the transaction is allowed to consume.
+type SetComputeUnitLimit struct {
+	// Compute units the whole transaction may consume, at most 1.4M.
+	Units *uint32
+
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewSetComputeUnitLimitInstructionBuilder creates a new `SetComputeUnitLimit` instruction builder.
+func NewSetComputeUnitLimitInstructionBuilder() *SetComputeUnitLimit {
+	nd := &SetComputeUnitLimit{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 0),
+	}
+	return nd
+}
+
+// SetUnits sets the "units" parameter.
+// Compute units the whole transaction may consume, at most 1.4M.
+func (inst *SetComputeUnitLimit) SetUnits(units uint32) *SetComputeUnitLimit {
+	inst.Units = &units
+	return inst
+}
+
+func (inst SetComputeUnitLimit) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint8(Instruction_SetComputeUnitLimit),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst SetComputeUnitLimit) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *SetComputeUnitLimit) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Units == nil {
+			return errors.New("Units parameter is not set")
+		}
+		if *inst.Units > MAX_COMPUTE_UNIT_LIMIT {
+			return fmt.Errorf("Units is %v, but max is %v", *inst.Units, MAX_COMPUTE_UNIT_LIMIT)
+		}
+	}
+	return nil
+}
+
+func (obj SetComputeUnitLimit) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	// Serialize `Units` param:
+	return encoder.WriteUint32(*obj.Units, bin.LE)
+}
+
+func (obj *SetComputeUnitLimit) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	// Deserialize `Units`:
+	units, err := decoder.ReadUint32(bin.LE)
+	if err != nil {
+		return err
+	}
+	obj.Units = &units
+	return nil
+}
+
+// NewSetComputeUnitLimitInstruction declares a new SetComputeUnitLimit instruction with the provided parameters.
+func NewSetComputeUnitLimitInstruction(
+	// Parameters:
+	units uint32,
+) *SetComputeUnitLimit {
+	return NewSetComputeUnitLimitInstructionBuilder().
+		SetUnits(units)
+}
@@ -0,0 +1,84 @@
+package computebudget
+
+import (
+	"errors"
+
+	bin "github.com/gagliardetto/binary"
+	solana "github.com/scatkit/pumpdexer/solana"
+)
+
+// Set a compute unit price in "micro-lamports" to pay a higher transaction
+// fee for higher transaction prioritization.
+// The priority fee is the price times the compute unit limit (not the units consumed).
+type SetComputeUnitPrice struct {
+	// Price of a compute unit, in micro-lamports (0.000001 lamports).
+	MicroLamports *uint64
+
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewSetComputeUnitPriceInstructionBuilder creates a new `SetComputeUnitPrice` instruction builder.
+func NewSetComputeUnitPriceInstructionBuilder() *SetComputeUnitPrice {
+	nd := &SetComputeUnitPrice{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 0),
+	}
+	return nd
+}
+
+// SetMicroLamports sets the "microLamports" parameter.
+// Price of a compute unit, in micro-lamports (0.000001 lamports).
+func (inst *SetComputeUnitPrice) SetMicroLamports(microLamports uint64) *SetComputeUnitPrice {
+	inst.MicroLamports = &microLamports
+	return inst
+}
+
+func (inst SetComputeUnitPrice) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint8(Instruction_SetComputeUnitPrice),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst SetComputeUnitPrice) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *SetComputeUnitPrice) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.MicroLamports == nil {
+			return errors.New("MicroLamports parameter is not set")
+		}
+	}
+	return nil
+}
+
+func (obj SetComputeUnitPrice) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	// Serialize `MicroLamports` param:
+	return encoder.WriteUint64(*obj.MicroLamports, bin.LE)
+}
+
+func (obj *SetComputeUnitPrice) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	// Deserialize `MicroLamports`:
+	microLamports, err := decoder.ReadUint64(bin.LE)
+	if err != nil {
+		return err
+	}
+	obj.MicroLamports = &microLamports
+	return nil
+}
+
+// NewSetComputeUnitPriceInstruction declares a new SetComputeUnitPrice instruction with the provided parameters.
+func NewSetComputeUnitPriceInstruction(
+	// Parameters:
+	microLamports uint64,
+) *SetComputeUnitPrice {
+	return NewSetComputeUnitPriceInstructionBuilder().
+		SetMicroLamports(microLamports)
+}
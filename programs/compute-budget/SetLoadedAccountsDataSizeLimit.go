@@ -0,0 +1,87 @@
+package computebudget
+
+import (
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	solana "github.com/scatkit/pumpdexer/solana"
+)
+
+// Set a specific transaction-wide account data size limit, in bytes, is allowed to load.
+// A tighter limit lowers the cost the scheduler assigns to the transaction.
+type SetLoadedAccountsDataSizeLimit struct {
+	// Total size of the accounts the transaction may load, at most 64MiB.
+	Bytes *uint32
+
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewSetLoadedAccountsDataSizeLimitInstructionBuilder creates a new `SetLoadedAccountsDataSizeLimit` instruction builder.
+func NewSetLoadedAccountsDataSizeLimitInstructionBuilder() *SetLoadedAccountsDataSizeLimit {
+	nd := &SetLoadedAccountsDataSizeLimit{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 0),
+	}
+	return nd
+}
+
+// SetBytes sets the "bytes" parameter.
+// Total size of the accounts the transaction may load, at most 64MiB.
+func (inst *SetLoadedAccountsDataSizeLimit) SetBytes(bytes uint32) *SetLoadedAccountsDataSizeLimit {
+	inst.Bytes = &bytes
+	return inst
+}
+
+func (inst SetLoadedAccountsDataSizeLimit) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint8(Instruction_SetLoadedAccountsDataSizeLimit),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst SetLoadedAccountsDataSizeLimit) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *SetLoadedAccountsDataSizeLimit) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Bytes == nil {
+			return errors.New("Bytes parameter is not set")
+		}
+		if *inst.Bytes == 0 || *inst.Bytes > MAX_LOADED_ACCOUNTS_DATA_SIZE_BYTES {
+			return fmt.Errorf("Bytes is %v, but must be between 1 and %v", *inst.Bytes, MAX_LOADED_ACCOUNTS_DATA_SIZE_BYTES)
+		}
+	}
+	return nil
+}
+
+func (obj SetLoadedAccountsDataSizeLimit) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	// Serialize `Bytes` param:
+	return encoder.WriteUint32(*obj.Bytes, bin.LE)
+}
+
+func (obj *SetLoadedAccountsDataSizeLimit) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	// Deserialize `Bytes`:
+	bytes, err := decoder.ReadUint32(bin.LE)
+	if err != nil {
+		return err
+	}
+	obj.Bytes = &bytes
+	return nil
+}
+
+// NewSetLoadedAccountsDataSizeLimitInstruction declares a new SetLoadedAccountsDataSizeLimit instruction with the provided parameters.
+func NewSetLoadedAccountsDataSizeLimitInstruction(
+	// Parameters:
+	bytes uint32,
+) *SetLoadedAccountsDataSizeLimit {
+	return NewSetLoadedAccountsDataSizeLimitInstructionBuilder().
+		SetBytes(bytes)
+}
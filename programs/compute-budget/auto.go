@@ -0,0 +1,157 @@
+package computebudget
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/scatkit/pumpdexer/rpc"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// DefaultMarginPercent is the room added on top of the simulated consumption
+// when AutoOpts.MarginPercent is zero.
+const DefaultMarginPercent = 10
+
+// AutoOpts configures WithComputeBudget and EstimateComputeUnits.
+type AutoOpts struct {
+	// Fee payer of the transaction; defaults to the first signer of the first instruction,
+	// as in solana.NewTransaction.
+	Payer solana.PublicKey
+
+	// Address tables the transaction will use (v0 transactions).
+	AddressTables map[solana.PublicKey]solana.PublicKeySlice
+
+	// Priority fee, in micro-lamports per compute unit.
+	// Zero adds no SetComputeUnitPrice instruction.
+	MicroLamports uint64
+
+	// Room added on top of the simulated consumption, in percent of it.
+	// Defaults to DefaultMarginPercent.
+	MarginPercent uint32
+
+	// Commitment of the bank the transaction is simulated against.
+	Commitment rpc.CommitmentType
+}
+
+// SimulationError is returned when the transaction fails in the simulation:
+// it would fail on chain too, so there is nothing to size.
+type SimulationError struct {
+	Err  interface{}
+	Logs []string
+}
+
+func (e *SimulationError) Error() string {
+	if len(e.Logs) == 0 {
+		return fmt.Sprintf("transaction simulation failed: %v", e.Err)
+	}
+	return fmt.Sprintf("transaction simulation failed: %v\n%s", e.Err, strings.Join(e.Logs, "\n"))
+}
+
+// WithComputeBudget returns the instructions prefixed with a SetComputeUnitLimit sized from a simulation
+// (the units consumed plus a margin) and, when a priority fee is set, a SetComputeUnitPrice.
+// SetComputeUnitLimit and SetComputeUnitPrice instructions already in the list are replaced.
+//
+// The first instruction no longer has a signer: pass the fee payer to solana.NewTransaction
+// with solana.TransactionPayer.
+func WithComputeBudget(ctx context.Context, rpcClient *rpc.Client, instructions []solana.Instruction, opts *AutoOpts) ([]solana.Instruction, error) {
+	if opts == nil {
+		opts = &AutoOpts{}
+	}
+	instructions = withoutLimitAndPrice(instructions)
+	consumed, err := simulate(ctx, rpcClient, instructions, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	margin := uint64(opts.MarginPercent)
+	if margin == 0 {
+		margin = DefaultMarginPercent
+	}
+	limit := consumed + (consumed*margin+99)/100
+	if limit > MAX_COMPUTE_UNIT_LIMIT {
+		limit = MAX_COMPUTE_UNIT_LIMIT
+	}
+	return prependLimitAndPrice(uint32(limit), opts.MicroLamports, instructions), nil
+}
+
+// EstimateComputeUnits simulates the instructions with the maximum compute unit limit
+// (and the priority fee of the options, if any) and returns the compute units they consumed.
+func EstimateComputeUnits(ctx context.Context, rpcClient *rpc.Client, instructions []solana.Instruction, opts *AutoOpts) (uint64, error) {
+	if opts == nil {
+		opts = &AutoOpts{}
+	}
+	return simulate(ctx, rpcClient, withoutLimitAndPrice(instructions), opts)
+}
+
+// simulate runs the instructions prefixed with the same compute budget instructions
+// WithComputeBudget adds, so that their own cost is accounted for.
+func simulate(ctx context.Context, rpcClient *rpc.Client, instructions []solana.Instruction, opts *AutoOpts) (uint64, error) {
+	if len(instructions) == 0 {
+		return 0, fmt.Errorf("no instructions to simulate")
+	}
+	payer := opts.Payer
+	if payer.IsZero() {
+		for _, acc := range instructions[0].Accounts() {
+			if acc.IsSigner {
+				payer = acc.PublicKey
+				break
+			}
+		}
+		if payer.IsZero() {
+			return 0, fmt.Errorf("cannot determine a fee payer: set AutoOpts.Payer")
+		}
+	}
+	txOpts := []solana.TransactionOption{solana.TransactionPayer(payer)}
+	if len(opts.AddressTables) > 0 {
+		txOpts = append(txOpts, solana.TransactionAddressTables(opts.AddressTables))
+	}
+	// The node replaces the blockhash and doesn't check the signatures,
+	// but it still expects one (empty) signature per signer.
+	tx, err := solana.NewTransaction(prependLimitAndPrice(MAX_COMPUTE_UNIT_LIMIT, opts.MicroLamports, instructions), solana.Hash{}, txOpts...)
+	if err != nil {
+		return 0, err
+	}
+	tx.Signatures = make([]solana.Signature, tx.Message.Header.NumRequiredSignatures)
+
+	res, err := rpcClient.SimulateTransactionWithOpts(ctx, tx, &rpc.SimulateTransactionOpts{
+		Commitment:             opts.Commitment,
+		ReplaceRecentBlockhash: true,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if res == nil || res.Value == nil {
+		return 0, fmt.Errorf("empty simulation result")
+	}
+	if res.Value.Err != nil {
+		return 0, &SimulationError{Err: res.Value.Err, Logs: res.Value.Logs}
+	}
+	if res.Value.UnitsConsumed == nil {
+		return 0, fmt.Errorf("simulation result has no unitsConsumed")
+	}
+	return *res.Value.UnitsConsumed, nil
+}
+
+func prependLimitAndPrice(units uint32, microLamports uint64, instructions []solana.Instruction) []solana.Instruction {
+	out := make([]solana.Instruction, 0, len(instructions)+2)
+	out = append(out, NewSetComputeUnitLimitInstruction(units).Build())
+	if microLamports > 0 {
+		out = append(out, NewSetComputeUnitPriceInstruction(microLamports).Build())
+	}
+	return append(out, instructions...)
+}
+
+func withoutLimitAndPrice(instructions []solana.Instruction) []solana.Instruction {
+	out := make([]solana.Instruction, 0, len(instructions))
+	for _, inst := range instructions {
+		if inst.ProgramID().Equals(ProgramID) {
+			data, err := inst.Data()
+			if err == nil && len(data) > 0 && (data[0] == Instruction_SetComputeUnitLimit || data[0] == Instruction_SetComputeUnitPrice) {
+				continue
+			}
+		}
+		out = append(out, inst)
+	}
+	return out
+}
@@ -0,0 +1,115 @@
+package computebudget
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/programs/system"
+	"github.com/scatkit/pumpdexer/rpc"
+	"github.com/scatkit/pumpdexer/solana"
+	"github.com/scatkit/pumpdexer/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// simulationHandler answers simulateTransaction with the given result
+// and records the decoded transactions.
+func simulationHandler(t *testing.T, txs *[]*solana.Transaction, value map[string]interface{}) testutil.Handler {
+	return func(req *testutil.Request) (interface{}, error) {
+		var b64 string
+		require.True(t, req.Param(0, &b64))
+		data, err := base64.StdEncoding.DecodeString(b64)
+		require.NoError(t, err)
+		tx := new(solana.Transaction)
+		require.NoError(t, bin.NewBinDecoder(data).Decode(tx))
+		*txs = append(*txs, tx)
+
+		require.Equal(t, true, req.Config()["replaceRecentBlockhash"])
+		return map[string]interface{}{
+			"context": map[string]interface{}{"slot": 1},
+			"value":   value,
+		}, nil
+	}
+}
+
+func TestWithComputeBudget(t *testing.T) {
+	srv := testutil.NewServer()
+	defer srv.Close()
+	client := rpc.New(srv.URL)
+
+	payer := solana.NewWallet().PublicKey()
+	transfer := system.NewTransferInstruction(1, payer, solana.NewWallet().PublicKey()).Build()
+
+	var simulated []*solana.Transaction
+	srv.Handle("simulateTransaction", simulationHandler(t, &simulated, map[string]interface{}{
+		"err":           nil,
+		"logs":          []string{},
+		"unitsConsumed": 1_000,
+	}))
+
+	instructions, err := WithComputeBudget(context.Background(), client, []solana.Instruction{
+		NewSetComputeUnitLimitInstruction(5).Build(),
+		transfer,
+	}, &AutoOpts{MicroLamports: 25_000})
+	require.NoError(t, err)
+	require.Len(t, instructions, 3, "the existing limit is replaced")
+
+	limit := instructions[0].(*Instruction).Impl.(SetComputeUnitLimit)
+	require.Equal(t, uint32(1_100), *limit.Units)
+	price := instructions[1].(*Instruction).Impl.(SetComputeUnitPrice)
+	require.Equal(t, uint64(25_000), *price.MicroLamports)
+	require.Equal(t, transfer, instructions[2])
+
+	// The simulation runs at the maximum limit with the same price.
+	require.Len(t, simulated, 1)
+	tx := simulated[0]
+	require.Equal(t, payer, tx.Message.AccountKeys[0])
+	require.Len(t, tx.Signatures, 1)
+	require.Len(t, tx.Message.Instructions, 3)
+	simulatedLimit, err := DecodeInstruction(nil, tx.Message.Instructions[0].Data)
+	require.NoError(t, err)
+	require.Equal(t, uint32(MAX_COMPUTE_UNIT_LIMIT), *simulatedLimit.Impl.(*SetComputeUnitLimit).Units)
+
+	// Without a price, only the limit is added.
+	instructions, err = WithComputeBudget(context.Background(), client, []solana.Instruction{transfer}, &AutoOpts{MarginPercent: 50})
+	require.NoError(t, err)
+	require.Len(t, instructions, 2)
+	require.Equal(t, uint32(1_500), *instructions[0].(*Instruction).Impl.(SetComputeUnitLimit).Units)
+
+	units, err := EstimateComputeUnits(context.Background(), client, []solana.Instruction{transfer}, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1_000), units)
+}
+
+func TestWithComputeBudget_Errors(t *testing.T) {
+	srv := testutil.NewServer()
+	defer srv.Close()
+	client := rpc.New(srv.URL)
+
+	payer := solana.NewWallet().PublicKey()
+	transfer := system.NewTransferInstruction(1, payer, solana.NewWallet().PublicKey()).Build()
+
+	var simulated []*solana.Transaction
+	srv.Handle("simulateTransaction", simulationHandler(t, &simulated, map[string]interface{}{
+		"err":           map[string]interface{}{"InstructionError": []interface{}{0, "Custom"}},
+		"logs":          []string{"Program log: insufficient funds"},
+		"unitsConsumed": 300,
+	}))
+	_, err := WithComputeBudget(context.Background(), client, []solana.Instruction{transfer}, nil)
+	var simErr *SimulationError
+	require.True(t, errors.As(err, &simErr))
+	require.Equal(t, []string{"Program log: insufficient funds"}, simErr.Logs)
+
+	_, err = WithComputeBudget(context.Background(), client, nil, nil)
+	require.Error(t, err)
+
+	priceOnly := []solana.Instruction{NewSetComputeUnitPriceInstruction(1).Build()}
+	_, err = WithComputeBudget(context.Background(), client, priceOnly, nil)
+	require.Error(t, err)
+
+	unsigned := []solana.Instruction{NewRequestHeapFrameInstruction(MIN_HEAP_FRAME_BYTES).Build()}
+	_, err = WithComputeBudget(context.Background(), client, unsigned, nil)
+	require.ErrorContains(t, err, "fee payer")
+}
@@ -0,0 +1,171 @@
+// The Compute Budget program on the Solana blockchain.
+// Its instructions take no accounts: the runtime reads them to set the compute unit limit
+// and price (priority fee), the heap size and the loaded accounts data size limit of a transaction.
+
+package computebudget
+
+import (
+	"bytes"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// Limits enforced by the runtime.
+const (
+	// Maximum compute units a transaction can request.
+	MAX_COMPUTE_UNIT_LIMIT = 1_400_000
+	// Compute units allotted to each instruction (other than compute budget ones)
+	// when the transaction doesn't set a limit.
+	DEFAULT_INSTRUCTION_COMPUTE_UNIT_LIMIT = 200_000
+	// Smallest heap frame a transaction can request (the default one).
+	MIN_HEAP_FRAME_BYTES = 32 * 1024
+	// Largest heap frame a transaction can request.
+	MAX_HEAP_FRAME_BYTES = 256 * 1024
+	// Heap frames are requested in multiples of this size.
+	HEAP_FRAME_BYTES_GRANULARITY = 1024
+	// Maximum loaded accounts data size a transaction can request.
+	MAX_LOADED_ACCOUNTS_DATA_SIZE_BYTES = 64 * 1024 * 1024
+)
+
+var ProgramID solana.PublicKey = solana.ComputeBudgetProgramID
+
+func SetProgramID(pubkey solana.PublicKey) {
+	ProgramID = pubkey
+	solana.RegisterInstructionDecoder(ProgramID, registryDecodeInstruction)
+}
+
+const ProgramName = "ComputeBudget"
+
+func init() {
+	if !ProgramID.IsZero() {
+		solana.RegisterInstructionDecoder(ProgramID, registryDecodeInstruction)
+	}
+}
+
+const (
+	// Deprecated variant, reserved value.
+	Instruction_Unused uint8 = iota
+
+	// Request a specific transaction-wide program heap region size in bytes.
+	Instruction_RequestHeapFrame
+
+	// Set a specific compute unit limit that the transaction is allowed to consume.
+	Instruction_SetComputeUnitLimit
+
+	// Set a compute unit price in "micro-lamports" to pay a higher transaction
+	// fee for higher transaction prioritization.
+	Instruction_SetComputeUnitPrice
+
+	// Set a specific transaction-wide account data size limit, in bytes, is allowed to load.
+	Instruction_SetLoadedAccountsDataSizeLimit
+)
+
+// InstructionIDToName returns the name of the instruction given its ID.
+func InstructionIDToName(id uint8) string {
+	switch id {
+	case Instruction_Unused:
+		return "Unused"
+	case Instruction_RequestHeapFrame:
+		return "RequestHeapFrame"
+	case Instruction_SetComputeUnitLimit:
+		return "SetComputeUnitLimit"
+	case Instruction_SetComputeUnitPrice:
+		return "SetComputeUnitPrice"
+	case Instruction_SetLoadedAccountsDataSizeLimit:
+		return "SetLoadedAccountsDataSizeLimit"
+	default:
+		return ""
+	}
+}
+
+type Instruction struct {
+	bin.BaseVariant
+}
+
+// The variants are listed in the order of their instruction ID:
+// the position in the list is the type ID.
+var InstructionImplDef = bin.NewVariantDefinition(
+	bin.Uint8TypeIDEncoding,
+	[]bin.VariantType{
+		{
+			"Unused", (*Unused)(nil),
+		},
+		{
+			"RequestHeapFrame", (*RequestHeapFrame)(nil),
+		},
+		{
+			"SetComputeUnitLimit", (*SetComputeUnitLimit)(nil),
+		},
+		{
+			"SetComputeUnitPrice", (*SetComputeUnitPrice)(nil),
+		},
+		{
+			"SetLoadedAccountsDataSizeLimit", (*SetLoadedAccountsDataSizeLimit)(nil),
+		},
+	},
+)
+
+func (inst *Instruction) ProgramID() solana.PublicKey {
+	return ProgramID
+}
+
+func (inst *Instruction) Accounts() (out []*solana.AccountMeta) {
+	return inst.Impl.(solana.AccountsGettable).GetAccounts()
+}
+
+func (inst *Instruction) Data() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := bin.NewBinEncoder(buf).Encode(inst); err != nil {
+		return nil, fmt.Errorf("unable to encode instruction: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (inst *Instruction) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	return inst.BaseVariant.UnmarshalBinaryVariant(decoder, InstructionImplDef)
+}
+
+func (inst Instruction) MarshalWithEncoder(encoder *bin.Encoder) error {
+	err := encoder.WriteUint8(inst.TypeID.Uint8())
+	if err != nil {
+		return fmt.Errorf("unable to write variant type: %w", err)
+	}
+	return encoder.Encode(inst.Impl)
+}
+
+func registryDecodeInstruction(accounts []*solana.AccountMeta, data []byte) (interface{}, error) {
+	inst, err := DecodeInstruction(accounts, data)
+	if err != nil {
+		return nil, err
+	}
+	return inst, nil
+}
+
+func DecodeInstruction(accounts []*solana.AccountMeta, data []byte) (*Instruction, error) {
+	inst := new(Instruction)
+	if err := bin.NewBinDecoder(data).Decode(inst); err != nil {
+		return nil, fmt.Errorf("unable to decode instruction: %w", err)
+	}
+	if v, ok := inst.Impl.(solana.AccountsSettable); ok {
+		err := v.SetAccounts(accounts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to set accounts for instruction: %w", err)
+		}
+	}
+	return inst, nil
+}
+
+// Unused is the deprecated `RequestUnits` instruction: the runtime rejects it.
+type Unused struct {
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+func (obj Unused) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	return nil
+}
+
+func (obj *Unused) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	return nil
+}
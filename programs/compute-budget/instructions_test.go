@@ -0,0 +1,78 @@
+package computebudget
+
+import (
+	"testing"
+
+	"github.com/scatkit/pumpdexer/solana"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		builder  interface{ ValidateAndBuild() (*Instruction, error) }
+		wantData []byte
+	}{
+		{
+			name:     "RequestHeapFrame",
+			builder:  NewRequestHeapFrameInstruction(64 * 1024),
+			wantData: []byte{Instruction_RequestHeapFrame, 0, 0, 1, 0},
+		},
+		{
+			name:     "SetComputeUnitLimit",
+			builder:  NewSetComputeUnitLimitInstruction(200_000),
+			wantData: []byte{Instruction_SetComputeUnitLimit, 0x40, 0x0d, 0x03, 0},
+		},
+		{
+			name:     "SetComputeUnitPrice",
+			builder:  NewSetComputeUnitPriceInstruction(1_000_000),
+			wantData: []byte{Instruction_SetComputeUnitPrice, 0x40, 0x42, 0x0f, 0, 0, 0, 0, 0},
+		},
+		{
+			name:     "SetLoadedAccountsDataSizeLimit",
+			builder:  NewSetLoadedAccountsDataSizeLimitInstruction(256 * 1024),
+			wantData: []byte{Instruction_SetLoadedAccountsDataSizeLimit, 0, 0, 4, 0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			inst, err := test.builder.ValidateAndBuild()
+			require.NoError(t, err)
+			require.Empty(t, inst.Accounts())
+
+			data, err := inst.Data()
+			require.NoError(t, err)
+			require.Equal(t, test.wantData, data)
+
+			decoded, err := solana.DecodeInstruction(ProgramID, nil, data)
+			require.NoError(t, err)
+			got := decoded.(*Instruction)
+			require.Equal(t, inst.TypeID, got.TypeID)
+			require.Equal(t, test.name, InstructionIDToName(got.TypeID.Uint8()))
+
+			reencoded, err := got.Data()
+			require.NoError(t, err)
+			require.Equal(t, data, reencoded)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	_, err := NewSetComputeUnitLimitInstructionBuilder().ValidateAndBuild()
+	require.EqualError(t, err, "Units parameter is not set")
+
+	_, err = NewSetComputeUnitLimitInstruction(MAX_COMPUTE_UNIT_LIMIT + 1).ValidateAndBuild()
+	require.Error(t, err)
+
+	_, err = NewRequestHeapFrameInstruction(MIN_HEAP_FRAME_BYTES + 1).ValidateAndBuild()
+	require.Error(t, err)
+	_, err = NewRequestHeapFrameInstruction(MAX_HEAP_FRAME_BYTES + HEAP_FRAME_BYTES_GRANULARITY).ValidateAndBuild()
+	require.Error(t, err)
+
+	_, err = NewSetLoadedAccountsDataSizeLimitInstruction(0).ValidateAndBuild()
+	require.Error(t, err)
+
+	_, err = NewSetComputeUnitPriceInstructionBuilder().ValidateAndBuild()
+	require.EqualError(t, err, "MicroLamports parameter is not set")
+}
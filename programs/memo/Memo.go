@@ -0,0 +1,109 @@
+package memo
+
+import (
+	"errors"
+	"unicode/utf8"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// Memo records a UTF-8 message in the transaction log.
+type Memo struct {
+	// The memo, as UTF-8 bytes.
+	Message []byte
+
+	// Program the memo is for; the current version if not set.
+	ProgramID solana.PublicKey `bin:"-" borsh_skip:"true"`
+
+	// [0..N] = [SIGNER] Signers
+	// ··········· Optional accounts that must sign the transaction (not checked by v1).
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewMemoInstructionBuilder creates a new `Memo` instruction builder.
+func NewMemoInstructionBuilder() *Memo {
+	nd := &Memo{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 0),
+	}
+	return nd
+}
+
+// SetMessage sets the memo.
+func (inst *Memo) SetMessage(message []byte) *Memo {
+	inst.Message = message
+	return inst
+}
+
+// SetSigners sets the accounts that must sign the transaction.
+func (inst *Memo) SetSigners(signers ...solana.PublicKey) *Memo {
+	inst.AccountMetaSlice = make(solana.AccountMetaSlice, 0, len(signers))
+	for _, signer := range signers {
+		inst.AccountMetaSlice.Append(solana.Meta(signer).SIGNER())
+	}
+	return inst
+}
+
+// SetProgramID sets the program the memo is for, ProgramIDV1 for the first version.
+func (inst *Memo) SetProgramID(programID solana.PublicKey) *Memo {
+	inst.ProgramID = programID
+	return inst
+}
+
+func (inst Memo) Build() *Instruction {
+	return &Instruction{
+		BaseVariant: bin.BaseVariant{
+			Impl:   inst,
+			TypeID: bin.NoTypeIDDefaultID,
+		},
+		programID: inst.ProgramID,
+	}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst Memo) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *Memo) Validate() error {
+	if len(inst.Message) == 0 {
+		return errors.New("Message parameter is not set")
+	}
+	if !utf8.Valid(inst.Message) {
+		return errors.New("Message is not valid UTF-8")
+	}
+	for _, signer := range inst.AccountMetaSlice {
+		if signer == nil {
+			return errors.New("accounts.Signers has a nil account")
+		}
+	}
+	return nil
+}
+
+func (inst Memo) MarshalWithEncoder(encoder *bin.Encoder) error {
+	// Serialize `Message` param (no length prefix: the data is the memo):
+	return encoder.WriteBytes(inst.Message, false)
+}
+
+func (inst *Memo) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	// Deserialize `Message`:
+	inst.Message, err = decoder.ReadNBytes(decoder.Remaining())
+	return err
+}
+
+// NewMemoInstruction declares a new Memo instruction with the provided memo and signers.
+func NewMemoInstruction(
+	// Parameters:
+	message []byte,
+	// Accounts:
+	signers ...solana.PublicKey,
+) *Memo {
+	return NewMemoInstructionBuilder().
+		SetMessage(message).
+		SetSigners(signers...)
+}
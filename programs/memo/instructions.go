@@ -0,0 +1,113 @@
+package memo
+
+import (
+	"bytes"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// The Memo program validates a string of UTF-8 encoded characters
+// and verifies that any accounts provided are signers of the transaction.
+var ProgramID solana.PublicKey = solana.MemoProgramID
+
+// ProgramIDV1 is the first version of the Memo program. It doesn't check signers.
+var ProgramIDV1 solana.PublicKey = solana.MemoV1ProgramID
+
+func SetProgramID(pubkey solana.PublicKey) {
+	ProgramID = pubkey
+	solana.RegisterInstructionDecoder(ProgramID, registryDecodeInstruction)
+}
+
+const ProgramName = "Memo"
+
+func init() {
+	solana.RegisterInstructionDecoder(ProgramID, registryDecodeInstruction)
+	solana.RegisterInstructionDecoder(ProgramIDV1, registryDecodeInstructionV1)
+}
+
+// IsMemoProgram tells whether the program is either version of the Memo program.
+func IsMemoProgram(programID solana.PublicKey) bool {
+	return programID.Equals(ProgramID) || programID.Equals(ProgramIDV1)
+}
+
+func registryDecodeInstruction(accounts []*solana.AccountMeta, data []byte) (interface{}, error) {
+	inst, err := DecodeInstruction(accounts, data)
+	if err != nil {
+		return nil, err
+	}
+	return inst, nil
+}
+
+func registryDecodeInstructionV1(accounts []*solana.AccountMeta, data []byte) (interface{}, error) {
+	inst, err := DecodeInstruction(accounts, data)
+	if err != nil {
+		return nil, err
+	}
+	inst.programID = ProgramIDV1
+	if memo, ok := inst.Impl.(*Memo); ok {
+		memo.ProgramID = ProgramIDV1
+	}
+	return inst, nil
+}
+
+type Instruction struct {
+	bin.BaseVariant
+
+	// Program the instruction is for; ProgramID if not set.
+	programID solana.PublicKey
+}
+
+// the programID instruction acts on
+func (inst *Instruction) ProgramID() solana.PublicKey {
+	if !inst.programID.IsZero() {
+		return inst.programID
+	}
+	return ProgramID
+}
+
+// list of accounts the instructions require
+func (inst *Instruction) Accounts() (out []*solana.AccountMeta) {
+	return inst.Impl.(solana.AccountsGettable).GetAccounts()
+}
+
+// binary encoded transaction
+func (inst *Instruction) Data() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := bin.NewBinEncoder(buf).Encode(inst); err != nil {
+		return nil, fmt.Errorf("unable to encode instruction: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+var InstructionImplDef = bin.NewVariantDefinition(
+	bin.NoTypeIDEncoding, // NOTE: the memo program has a single instruction, the data is the memo itself.
+	[]bin.VariantType{
+		{
+			"Memo", (*Memo)(nil),
+		},
+	},
+)
+
+func (inst *Instruction) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	return inst.BaseVariant.UnmarshalBinaryVariant(decoder, InstructionImplDef)
+}
+
+func (inst Instruction) MarshalWithEncoder(encoder *bin.Encoder) error {
+	return encoder.Encode(inst.Impl)
+}
+
+func DecodeInstruction(accounts []*solana.AccountMeta, data []byte) (*Instruction, error) {
+	inst := new(Instruction)
+	if err := bin.NewBinDecoder(data).Decode(inst); err != nil {
+		return nil, fmt.Errorf("unable to decode instruction: %w", err)
+	}
+	if v, ok := inst.Impl.(solana.AccountsSettable); ok {
+		err := v.SetAccounts(accounts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to set accounts for instruction: %w", err)
+		}
+	}
+	return inst, nil
+}
@@ -0,0 +1,80 @@
+package memo
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/mr-tron/base58"
+	"github.com/scatkit/pumpdexer/programs/system"
+	"github.com/scatkit/pumpdexer/rpc"
+	"github.com/scatkit/pumpdexer/solana"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemo_RoundTrip(t *testing.T) {
+	signer := solana.NewWallet().PublicKey()
+
+	inst, err := NewMemoInstruction([]byte("strategy:42"), signer).ValidateAndBuild()
+	require.NoError(t, err)
+	require.Equal(t, ProgramID, inst.ProgramID())
+	require.Equal(t, []*solana.AccountMeta{solana.Meta(signer).SIGNER()}, inst.Accounts())
+
+	data, err := inst.Data()
+	require.NoError(t, err)
+	require.Equal(t, []byte("strategy:42"), data)
+
+	decoded, err := solana.DecodeInstruction(ProgramID, inst.Accounts(), data)
+	require.NoError(t, err)
+	got := decoded.(*Instruction)
+	require.Equal(t, []byte("strategy:42"), got.Impl.(*Memo).Message)
+	require.Equal(t, inst.Accounts(), got.Accounts())
+
+	v1 := NewMemoInstruction([]byte("hello")).SetProgramID(ProgramIDV1).Build()
+	require.Equal(t, ProgramIDV1, v1.ProgramID())
+	require.Empty(t, v1.Accounts())
+	decoded, err = solana.DecodeInstruction(ProgramIDV1, nil, []byte("hello"))
+	require.NoError(t, err)
+	require.Equal(t, ProgramIDV1, decoded.(*Instruction).ProgramID())
+}
+
+func TestMemo_Validate(t *testing.T) {
+	_, err := NewMemoInstruction(nil).ValidateAndBuild()
+	require.EqualError(t, err, "Message parameter is not set")
+
+	_, err = NewMemoInstruction([]byte{0xff, 0xfe}).ValidateAndBuild()
+	require.EqualError(t, err, "Message is not valid UTF-8")
+}
+
+func TestGetMemos(t *testing.T) {
+	payer := solana.NewWallet().PublicKey()
+	tx, err := solana.NewTransaction([]solana.Instruction{
+		system.NewTransferInstruction(1, payer, solana.NewWallet().PublicKey()).Build(),
+		NewMemoInstruction([]byte("strategy:42"), payer).Build(),
+	}, solana.Hash{})
+	require.NoError(t, err)
+	b64, err := tx.ToBase64()
+	require.NoError(t, err)
+
+	// A program invoked by the transfer (index 0) logged a memo with the first version.
+	keys := len(tx.Message.AccountKeys)
+	raw := fmt.Sprintf(`{
+		"slot": 1,
+		"transaction": [%q, "base64"],
+		"meta": {
+			"err": null,
+			"innerInstructions": [{"index": 0, "instructions": [{"programIdIndex": %d, "accounts": [], "data": %q}]}],
+			"loadedAddresses": {"writable": [], "readonly": [%q]}
+		}
+	}`, b64, keys, base58.Encode([]byte("inner")), ProgramIDV1)
+
+	var result rpc.GetTransactionResult
+	require.NoError(t, json.Unmarshal([]byte(raw), &result))
+
+	memos, err := GetMemos(&result)
+	require.NoError(t, err)
+	require.Equal(t, []string{"inner", "strategy:42"}, memos)
+
+	_, err = GetMemos(&rpc.GetTransactionResult{})
+	require.Error(t, err)
+}
@@ -0,0 +1,55 @@
+package memo
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/scatkit/pumpdexer/rpc"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// GetMemos returns the memos of a transaction fetched with rpc.Client.GetTransaction
+// (in a binary encoding), in execution order: each instruction is followed
+// by the instructions it invoked, when the meta records them.
+func GetMemos(result *rpc.GetTransactionResult) ([]string, error) {
+	if result == nil || result.Transaction == nil {
+		return nil, errors.New("transaction result is empty")
+	}
+	tx, err := result.Transaction.GetTransaction()
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode transaction: %w", err)
+	}
+	if tx == nil {
+		return nil, errors.New("transaction result is empty")
+	}
+
+	// Inner instructions index into the static keys, then the keys loaded from lookup tables.
+	keys := tx.Message.AccountKeys
+	var inner map[uint16][]rpc.CompiledInstruction
+	if result.Meta != nil {
+		keys = append(append(append(solana.PublicKeySlice{}, keys...),
+			result.Meta.LoadedAddresses.Writable...),
+			result.Meta.LoadedAddresses.ReadOnly...)
+		inner = make(map[uint16][]rpc.CompiledInstruction, len(result.Meta.InnerInstructions))
+		for _, instructions := range result.Meta.InnerInstructions {
+			inner[instructions.Index] = append(inner[instructions.Index], instructions.Instructions...)
+		}
+	}
+
+	isMemo := func(programIDIndex uint16) bool {
+		return int(programIDIndex) < len(keys) && IsMemoProgram(keys[programIDIndex])
+	}
+
+	var memos []string
+	for i, instruction := range tx.Message.Instructions {
+		if isMemo(instruction.ProgramIDIndex) {
+			memos = append(memos, string(instruction.Data))
+		}
+		for _, invoked := range inner[uint16(i)] {
+			if isMemo(invoked.ProgramIDIndex) {
+				memos = append(memos, string(invoked.Data))
+			}
+		}
+	}
+	return memos, nil
+}
@@ -0,0 +1,112 @@
+package system
+
+import (
+	"encoding/binary"
+	"errors"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// Consumes a stored nonce, replacing it with a successor
+type AdvanceNonceAccount struct {
+	// [0] = [WRITE] NonceAccount
+	// ··········· Nonce account
+	//
+	// [1] = [] $(SysVarRecentBlockHashesPubkey)
+	// ··········· RecentBlockhashes sysvar
+	//
+	// [2] = [SIGNER] NonceAuthorityAccount
+	// ··········· Nonce authority
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewAdvanceNonceAccountInstructionBuilder creates a new `AdvanceNonceAccount` instruction builder.
+func NewAdvanceNonceAccountInstructionBuilder() *AdvanceNonceAccount {
+	nd := &AdvanceNonceAccount{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 3),
+	}
+	nd.AccountMetaSlice[1] = solana.Meta(solana.SysVarRecentBlockHashesPubkey)
+	return nd
+}
+
+// Nonce account
+func (inst *AdvanceNonceAccount) SetNonceAccount(nonceAccount solana.PublicKey) *AdvanceNonceAccount {
+	inst.AccountMetaSlice[0] = solana.Meta(nonceAccount).WRITE()
+	return inst
+}
+
+func (inst *AdvanceNonceAccount) GetNonceAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// RecentBlockhashes sysvar
+func (inst *AdvanceNonceAccount) SetSysVarRecentBlockHashesPubkeyAccount(sysVarRecentBlockHashesPubkey solana.PublicKey) *AdvanceNonceAccount {
+	inst.AccountMetaSlice[1] = solana.Meta(sysVarRecentBlockHashesPubkey)
+	return inst
+}
+
+func (inst *AdvanceNonceAccount) GetSysVarRecentBlockHashesPubkeyAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// Nonce authority
+func (inst *AdvanceNonceAccount) SetNonceAuthorityAccount(nonceAuthorityAccount solana.PublicKey) *AdvanceNonceAccount {
+	inst.AccountMetaSlice[2] = solana.Meta(nonceAuthorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *AdvanceNonceAccount) GetNonceAuthorityAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+func (inst AdvanceNonceAccount) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint32(Instruction_AdvanceNonceAccount, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst AdvanceNonceAccount) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *AdvanceNonceAccount) Validate() error {
+	// Check whether all accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("NonceAccount is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return errors.New("SysVarRecentBlockHashesPubkey is not set")
+		}
+		if inst.AccountMetaSlice[2] == nil {
+			return errors.New("NonceAuthorityAccount is not set")
+		}
+	}
+	return nil
+}
+
+func (inst AdvanceNonceAccount) MarshalWithEncoder(encoder *bin.Encoder) error {
+	return nil
+}
+
+func (inst *AdvanceNonceAccount) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	return nil
+}
+
+// NewAdvanceNonceAccountInstruction declares a new AdvanceNonceAccount instruction with the provided parameters and accounts.
+func NewAdvanceNonceAccountInstruction(
+	// Accounts:
+	nonceAccount solana.PublicKey,
+	nonceAuthorityAccount solana.PublicKey) *AdvanceNonceAccount {
+	return NewAdvanceNonceAccountInstructionBuilder().
+		SetNonceAccount(nonceAccount).
+		SetNonceAuthorityAccount(nonceAuthorityAccount)
+}
@@ -0,0 +1,29 @@
+package system
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	"github.com/gagliardetto/gofuzz"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecode_AdvanceNonceAccount(t *testing.T) {
+	fz := fuzz.New().NilChance(0)
+	for i := 0; i < 1; i++ {
+		t.Run("AdvanceNonceAccount"+strconv.Itoa(i), func(t *testing.T) {
+			params := new(AdvanceNonceAccount)
+			fz.Fuzz(params)
+			params.AccountMetaSlice = nil
+			buf := new(bytes.Buffer)
+			err := encodeT(*params, buf)
+			require.NoError(t, err)
+			got := new(AdvanceNonceAccount)
+			err = decodeT(got, buf.Bytes())
+			got.AccountMetaSlice = nil
+			require.NoError(t, err)
+			require.Equal(t, params, got)
+		})
+	}
+}
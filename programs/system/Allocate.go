@@ -0,0 +1,98 @@
+package system
+
+import (
+	"encoding/binary"
+	"errors"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// Allocate space in a (possibly new) account without funding
+type Allocate struct {
+	// Number of bytes of memory to allocate
+	Space *uint64
+
+	// [0] = [WRITE, SIGNER] NewAccount
+	// ··········· New account
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewAllocateInstructionBuilder creates a new `Allocate` instruction builder.
+func NewAllocateInstructionBuilder() *Allocate {
+	nd := &Allocate{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 1),
+	}
+	return nd
+}
+
+// Number of bytes of memory to allocate
+func (inst *Allocate) SetSpace(space uint64) *Allocate {
+	inst.Space = &space
+	return inst
+}
+
+// New account
+func (inst *Allocate) SetNewAccount(newAccount solana.PublicKey) *Allocate {
+	inst.AccountMetaSlice[0] = solana.Meta(newAccount).WRITE().SIGNER()
+	return inst
+}
+
+func (inst *Allocate) GetNewAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+func (inst Allocate) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint32(Instruction_Allocate, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst Allocate) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *Allocate) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Space == nil {
+			return errors.New("Space parameter is not set")
+		}
+	}
+
+	// Check whether all accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("NewAccount is not set")
+		}
+	}
+	return nil
+}
+
+func (inst Allocate) MarshalWithEncoder(encoder *bin.Encoder) error {
+	// Serialize `Space` param:
+	return encoder.Encode(*inst.Space)
+}
+
+func (inst *Allocate) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	// Deserialize `Space` param:
+	return decoder.Decode(&inst.Space)
+}
+
+// NewAllocateInstruction declares a new Allocate instruction with the provided parameters and accounts.
+func NewAllocateInstruction(
+	// Parameters:
+	space uint64,
+	// Accounts:
+	newAccount solana.PublicKey) *Allocate {
+	return NewAllocateInstructionBuilder().
+		SetSpace(space).
+		SetNewAccount(newAccount)
+}
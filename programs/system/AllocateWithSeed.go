@@ -0,0 +1,213 @@
+package system
+
+import (
+	"encoding/binary"
+	"errors"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// Allocate space for and assign an account at an address derived from a base public key and a seed
+type AllocateWithSeed struct {
+	// Base public key
+	Base *solana.PublicKey
+
+	// String of ASCII chars, no longer than pubkey::MAX_SEED_LEN
+	Seed *string
+
+	// Number of bytes of memory to allocate
+	Space *uint64
+
+	// Owner program account address
+	Owner *solana.PublicKey
+
+	// [0] = [WRITE] AllocatedAccount
+	// ··········· Allocated account
+	//
+	// [1] = [SIGNER] BaseAccount
+	// ··········· Base account
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewAllocateWithSeedInstructionBuilder creates a new `AllocateWithSeed` instruction builder.
+func NewAllocateWithSeedInstructionBuilder() *AllocateWithSeed {
+	nd := &AllocateWithSeed{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 2),
+	}
+	return nd
+}
+
+// Base public key
+func (inst *AllocateWithSeed) SetBase(base solana.PublicKey) *AllocateWithSeed {
+	inst.Base = &base
+	return inst
+}
+
+// String of ASCII chars, no longer than pubkey::MAX_SEED_LEN
+func (inst *AllocateWithSeed) SetSeed(seed string) *AllocateWithSeed {
+	inst.Seed = &seed
+	return inst
+}
+
+// Number of bytes of memory to allocate
+func (inst *AllocateWithSeed) SetSpace(space uint64) *AllocateWithSeed {
+	inst.Space = &space
+	return inst
+}
+
+// Owner program account address
+func (inst *AllocateWithSeed) SetOwner(owner solana.PublicKey) *AllocateWithSeed {
+	inst.Owner = &owner
+	return inst
+}
+
+// Allocated account
+func (inst *AllocateWithSeed) SetAllocatedAccount(allocatedAccount solana.PublicKey) *AllocateWithSeed {
+	inst.AccountMetaSlice[0] = solana.Meta(allocatedAccount).WRITE()
+	return inst
+}
+
+func (inst *AllocateWithSeed) GetAllocatedAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Base account
+func (inst *AllocateWithSeed) SetBaseAccount(baseAccount solana.PublicKey) *AllocateWithSeed {
+	inst.AccountMetaSlice[1] = solana.Meta(baseAccount).SIGNER()
+	return inst
+}
+
+func (inst *AllocateWithSeed) GetBaseAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+func (inst AllocateWithSeed) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint32(Instruction_AllocateWithSeed, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst AllocateWithSeed) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *AllocateWithSeed) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Base == nil {
+			return errors.New("Base parameter is not set")
+		}
+		if inst.Seed == nil {
+			return errors.New("Seed parameter is not set")
+		}
+		if inst.Space == nil {
+			return errors.New("Space parameter is not set")
+		}
+		if inst.Owner == nil {
+			return errors.New("Owner parameter is not set")
+		}
+	}
+
+	// Check whether all accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("AllocatedAccount is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return errors.New("BaseAccount is not set")
+		}
+	}
+	return nil
+}
+
+func (inst AllocateWithSeed) MarshalWithEncoder(encoder *bin.Encoder) error {
+	// Serialize `Base` param:
+	{
+		err := encoder.Encode(*inst.Base)
+		if err != nil {
+			return err
+		}
+	}
+	// Serialize `Seed` param:
+	{
+		err := encoder.WriteRustString(*inst.Seed)
+		if err != nil {
+			return err
+		}
+	}
+	// Serialize `Space` param:
+	{
+		err := encoder.Encode(*inst.Space)
+		if err != nil {
+			return err
+		}
+	}
+	// Serialize `Owner` param:
+	{
+		err := encoder.Encode(*inst.Owner)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (inst *AllocateWithSeed) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	// Deserialize `Base` param:
+	{
+		err := decoder.Decode(&inst.Base)
+		if err != nil {
+			return err
+		}
+	}
+	// Deserialize `Seed` param:
+	{
+		value, err := decoder.ReadRustString()
+		if err != nil {
+			return err
+		}
+		inst.Seed = &value
+	}
+	// Deserialize `Space` param:
+	{
+		err := decoder.Decode(&inst.Space)
+		if err != nil {
+			return err
+		}
+	}
+	// Deserialize `Owner` param:
+	{
+		err := decoder.Decode(&inst.Owner)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewAllocateWithSeedInstruction declares a new AllocateWithSeed instruction with the provided parameters and accounts.
+func NewAllocateWithSeedInstruction(
+	// Parameters:
+	base solana.PublicKey,
+	seed string,
+	space uint64,
+	owner solana.PublicKey,
+	// Accounts:
+	allocatedAccount solana.PublicKey,
+	baseAccount solana.PublicKey) *AllocateWithSeed {
+	return NewAllocateWithSeedInstructionBuilder().
+		SetBase(base).
+		SetSeed(seed).
+		SetSpace(space).
+		SetOwner(owner).
+		SetAllocatedAccount(allocatedAccount).
+		SetBaseAccount(baseAccount)
+}
@@ -0,0 +1,98 @@
+package system
+
+import (
+	"encoding/binary"
+	"errors"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// Assign account to a program
+type Assign struct {
+	// Owner program account
+	Owner *solana.PublicKey
+
+	// [0] = [WRITE, SIGNER] AssignedAccount
+	// ··········· Assigned account public key
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewAssignInstructionBuilder creates a new `Assign` instruction builder.
+func NewAssignInstructionBuilder() *Assign {
+	nd := &Assign{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 1),
+	}
+	return nd
+}
+
+// Owner program account
+func (inst *Assign) SetOwner(owner solana.PublicKey) *Assign {
+	inst.Owner = &owner
+	return inst
+}
+
+// Assigned account public key
+func (inst *Assign) SetAssignedAccount(assignedAccount solana.PublicKey) *Assign {
+	inst.AccountMetaSlice[0] = solana.Meta(assignedAccount).WRITE().SIGNER()
+	return inst
+}
+
+func (inst *Assign) GetAssignedAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+func (inst Assign) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint32(Instruction_Assign, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst Assign) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *Assign) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Owner == nil {
+			return errors.New("Owner parameter is not set")
+		}
+	}
+
+	// Check whether all accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("AssignedAccount is not set")
+		}
+	}
+	return nil
+}
+
+func (inst Assign) MarshalWithEncoder(encoder *bin.Encoder) error {
+	// Serialize `Owner` param:
+	return encoder.Encode(*inst.Owner)
+}
+
+func (inst *Assign) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	// Deserialize `Owner` param:
+	return decoder.Decode(&inst.Owner)
+}
+
+// NewAssignInstruction declares a new Assign instruction with the provided parameters and accounts.
+func NewAssignInstruction(
+	// Parameters:
+	owner solana.PublicKey,
+	// Accounts:
+	assignedAccount solana.PublicKey) *Assign {
+	return NewAssignInstructionBuilder().
+		SetOwner(owner).
+		SetAssignedAccount(assignedAccount)
+}
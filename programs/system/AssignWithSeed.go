@@ -0,0 +1,185 @@
+package system
+
+import (
+	"encoding/binary"
+	"errors"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// Assign account to a program based on a seed
+type AssignWithSeed struct {
+	// Base public key
+	Base *solana.PublicKey
+
+	// String of ASCII chars, no longer than pubkey::MAX_SEED_LEN
+	Seed *string
+
+	// Owner program account
+	Owner *solana.PublicKey
+
+	// [0] = [WRITE] AssignedAccount
+	// ··········· Assigned account
+	//
+	// [1] = [SIGNER] BaseAccount
+	// ··········· Base account
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewAssignWithSeedInstructionBuilder creates a new `AssignWithSeed` instruction builder.
+func NewAssignWithSeedInstructionBuilder() *AssignWithSeed {
+	nd := &AssignWithSeed{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 2),
+	}
+	return nd
+}
+
+// Base public key
+func (inst *AssignWithSeed) SetBase(base solana.PublicKey) *AssignWithSeed {
+	inst.Base = &base
+	return inst
+}
+
+// String of ASCII chars, no longer than pubkey::MAX_SEED_LEN
+func (inst *AssignWithSeed) SetSeed(seed string) *AssignWithSeed {
+	inst.Seed = &seed
+	return inst
+}
+
+// Owner program account
+func (inst *AssignWithSeed) SetOwner(owner solana.PublicKey) *AssignWithSeed {
+	inst.Owner = &owner
+	return inst
+}
+
+// Assigned account
+func (inst *AssignWithSeed) SetAssignedAccount(assignedAccount solana.PublicKey) *AssignWithSeed {
+	inst.AccountMetaSlice[0] = solana.Meta(assignedAccount).WRITE()
+	return inst
+}
+
+func (inst *AssignWithSeed) GetAssignedAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Base account
+func (inst *AssignWithSeed) SetBaseAccount(baseAccount solana.PublicKey) *AssignWithSeed {
+	inst.AccountMetaSlice[1] = solana.Meta(baseAccount).SIGNER()
+	return inst
+}
+
+func (inst *AssignWithSeed) GetBaseAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+func (inst AssignWithSeed) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint32(Instruction_AssignWithSeed, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst AssignWithSeed) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *AssignWithSeed) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Base == nil {
+			return errors.New("Base parameter is not set")
+		}
+		if inst.Seed == nil {
+			return errors.New("Seed parameter is not set")
+		}
+		if inst.Owner == nil {
+			return errors.New("Owner parameter is not set")
+		}
+	}
+
+	// Check whether all accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("AssignedAccount is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return errors.New("BaseAccount is not set")
+		}
+	}
+	return nil
+}
+
+func (inst AssignWithSeed) MarshalWithEncoder(encoder *bin.Encoder) error {
+	// Serialize `Base` param:
+	{
+		err := encoder.Encode(*inst.Base)
+		if err != nil {
+			return err
+		}
+	}
+	// Serialize `Seed` param:
+	{
+		err := encoder.WriteRustString(*inst.Seed)
+		if err != nil {
+			return err
+		}
+	}
+	// Serialize `Owner` param:
+	{
+		err := encoder.Encode(*inst.Owner)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (inst *AssignWithSeed) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	// Deserialize `Base` param:
+	{
+		err := decoder.Decode(&inst.Base)
+		if err != nil {
+			return err
+		}
+	}
+	// Deserialize `Seed` param:
+	{
+		value, err := decoder.ReadRustString()
+		if err != nil {
+			return err
+		}
+		inst.Seed = &value
+	}
+	// Deserialize `Owner` param:
+	{
+		err := decoder.Decode(&inst.Owner)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewAssignWithSeedInstruction declares a new AssignWithSeed instruction with the provided parameters and accounts.
+func NewAssignWithSeedInstruction(
+	// Parameters:
+	base solana.PublicKey,
+	seed string,
+	owner solana.PublicKey,
+	// Accounts:
+	assignedAccount solana.PublicKey,
+	baseAccount solana.PublicKey) *AssignWithSeed {
+	return NewAssignWithSeedInstructionBuilder().
+		SetBase(base).
+		SetSeed(seed).
+		SetOwner(owner).
+		SetAssignedAccount(assignedAccount).
+		SetBaseAccount(baseAccount)
+}
@@ -0,0 +1,116 @@
+package system
+
+import (
+	"encoding/binary"
+	"errors"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// Change the entity authorized to execute nonce instructions on the account
+type AuthorizeNonceAccount struct {
+	// The Pubkey parameter identifies the entity to authorize.
+	Authority *solana.PublicKey
+
+	// [0] = [WRITE] NonceAccount
+	// ··········· Nonce account
+	//
+	// [1] = [SIGNER] NonceAuthorityAccount
+	// ··········· Nonce authority
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewAuthorizeNonceAccountInstructionBuilder creates a new `AuthorizeNonceAccount` instruction builder.
+func NewAuthorizeNonceAccountInstructionBuilder() *AuthorizeNonceAccount {
+	nd := &AuthorizeNonceAccount{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 2),
+	}
+	return nd
+}
+
+// The Pubkey parameter identifies the entity to authorize.
+func (inst *AuthorizeNonceAccount) SetAuthority(authority solana.PublicKey) *AuthorizeNonceAccount {
+	inst.Authority = &authority
+	return inst
+}
+
+// Nonce account
+func (inst *AuthorizeNonceAccount) SetNonceAccount(nonceAccount solana.PublicKey) *AuthorizeNonceAccount {
+	inst.AccountMetaSlice[0] = solana.Meta(nonceAccount).WRITE()
+	return inst
+}
+
+func (inst *AuthorizeNonceAccount) GetNonceAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Nonce authority
+func (inst *AuthorizeNonceAccount) SetNonceAuthorityAccount(nonceAuthorityAccount solana.PublicKey) *AuthorizeNonceAccount {
+	inst.AccountMetaSlice[1] = solana.Meta(nonceAuthorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *AuthorizeNonceAccount) GetNonceAuthorityAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+func (inst AuthorizeNonceAccount) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint32(Instruction_AuthorizeNonceAccount, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst AuthorizeNonceAccount) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *AuthorizeNonceAccount) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Authority == nil {
+			return errors.New("Authority parameter is not set")
+		}
+	}
+
+	// Check whether all accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("NonceAccount is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return errors.New("NonceAuthorityAccount is not set")
+		}
+	}
+	return nil
+}
+
+func (inst AuthorizeNonceAccount) MarshalWithEncoder(encoder *bin.Encoder) error {
+	// Serialize `Authority` param:
+	return encoder.Encode(*inst.Authority)
+}
+
+func (inst *AuthorizeNonceAccount) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	// Deserialize `Authority` param:
+	return decoder.Decode(&inst.Authority)
+}
+
+// NewAuthorizeNonceAccountInstruction declares a new AuthorizeNonceAccount instruction with the provided parameters and accounts.
+func NewAuthorizeNonceAccountInstruction(
+	// Parameters:
+	authority solana.PublicKey,
+	// Accounts:
+	nonceAccount solana.PublicKey,
+	nonceAuthorityAccount solana.PublicKey) *AuthorizeNonceAccount {
+	return NewAuthorizeNonceAccountInstructionBuilder().
+		SetAuthority(authority).
+		SetNonceAccount(nonceAccount).
+		SetNonceAuthorityAccount(nonceAuthorityAccount)
+}
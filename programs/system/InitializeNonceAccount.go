@@ -0,0 +1,133 @@
+package system
+
+import (
+	"encoding/binary"
+	"errors"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// Drive state of Uninitalized nonce account to Initialized, setting the nonce value
+type InitializeNonceAccount struct {
+	// The Pubkey parameter specifies the entity authorized to execute nonce instruction on the account.
+	// No signatures are required to execute this instruction, enabling derived nonce account addresses.
+	Authority *solana.PublicKey
+
+	// [0] = [WRITE] NonceAccount
+	// ··········· Nonce account
+	//
+	// [1] = [] $(SysVarRecentBlockHashesPubkey)
+	// ··········· RecentBlockhashes sysvar
+	//
+	// [2] = [] $(SysVarRentPubkey)
+	// ··········· Rent sysvar
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewInitializeNonceAccountInstructionBuilder creates a new `InitializeNonceAccount` instruction builder.
+func NewInitializeNonceAccountInstructionBuilder() *InitializeNonceAccount {
+	nd := &InitializeNonceAccount{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 3),
+	}
+	nd.AccountMetaSlice[1] = solana.Meta(solana.SysVarRecentBlockHashesPubkey)
+	nd.AccountMetaSlice[2] = solana.Meta(solana.SysVarRentPubkey)
+	return nd
+}
+
+// The Pubkey parameter specifies the entity authorized to execute nonce instruction on the account.
+func (inst *InitializeNonceAccount) SetAuthority(authority solana.PublicKey) *InitializeNonceAccount {
+	inst.Authority = &authority
+	return inst
+}
+
+// Nonce account
+func (inst *InitializeNonceAccount) SetNonceAccount(nonceAccount solana.PublicKey) *InitializeNonceAccount {
+	inst.AccountMetaSlice[0] = solana.Meta(nonceAccount).WRITE()
+	return inst
+}
+
+func (inst *InitializeNonceAccount) GetNonceAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// RecentBlockhashes sysvar
+func (inst *InitializeNonceAccount) SetSysVarRecentBlockHashesPubkeyAccount(sysVarRecentBlockHashesPubkey solana.PublicKey) *InitializeNonceAccount {
+	inst.AccountMetaSlice[1] = solana.Meta(sysVarRecentBlockHashesPubkey)
+	return inst
+}
+
+func (inst *InitializeNonceAccount) GetSysVarRecentBlockHashesPubkeyAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// Rent sysvar
+func (inst *InitializeNonceAccount) SetSysVarRentPubkeyAccount(sysVarRentPubkey solana.PublicKey) *InitializeNonceAccount {
+	inst.AccountMetaSlice[2] = solana.Meta(sysVarRentPubkey)
+	return inst
+}
+
+func (inst *InitializeNonceAccount) GetSysVarRentPubkeyAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+func (inst InitializeNonceAccount) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint32(Instruction_InitializeNonceAccount, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst InitializeNonceAccount) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *InitializeNonceAccount) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Authority == nil {
+			return errors.New("Authority parameter is not set")
+		}
+	}
+
+	// Check whether all accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("NonceAccount is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return errors.New("SysVarRecentBlockHashesPubkey is not set")
+		}
+		if inst.AccountMetaSlice[2] == nil {
+			return errors.New("SysVarRentPubkey is not set")
+		}
+	}
+	return nil
+}
+
+func (inst InitializeNonceAccount) MarshalWithEncoder(encoder *bin.Encoder) error {
+	// Serialize `Authority` param:
+	return encoder.Encode(*inst.Authority)
+}
+
+func (inst *InitializeNonceAccount) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	// Deserialize `Authority` param:
+	return decoder.Decode(&inst.Authority)
+}
+
+// NewInitializeNonceAccountInstruction declares a new InitializeNonceAccount instruction with the provided parameters and accounts.
+func NewInitializeNonceAccountInstruction(
+	// Parameters:
+	authority solana.PublicKey,
+	// Accounts:
+	nonceAccount solana.PublicKey) *InitializeNonceAccount {
+	return NewInitializeNonceAccountInstructionBuilder().
+		SetAuthority(authority).
+		SetNonceAccount(nonceAccount)
+}
@@ -0,0 +1,203 @@
+package system
+
+import (
+	"encoding/binary"
+	"errors"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// Transfer lamports from a derived address
+type TransferWithSeed struct {
+	// Amount to transfer
+	Lamports *uint64
+
+	// Seed to use to derive the funding account address
+	FromSeed *string
+
+	// Owner to use to derive the funding account address
+	FromOwner *solana.PublicKey
+
+	// [0] = [WRITE] FundingAccount
+	// ··········· Funding account
+	//
+	// [1] = [SIGNER] BaseForFundingAccount
+	// ··········· Base for funding account
+	//
+	// [2] = [WRITE] RecipientAccount
+	// ··········· Recipient account
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewTransferWithSeedInstructionBuilder creates a new `TransferWithSeed` instruction builder.
+func NewTransferWithSeedInstructionBuilder() *TransferWithSeed {
+	nd := &TransferWithSeed{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 3),
+	}
+	return nd
+}
+
+// Amount to transfer
+func (inst *TransferWithSeed) SetLamports(lamports uint64) *TransferWithSeed {
+	inst.Lamports = &lamports
+	return inst
+}
+
+// Seed to use to derive the funding account address
+func (inst *TransferWithSeed) SetFromSeed(fromSeed string) *TransferWithSeed {
+	inst.FromSeed = &fromSeed
+	return inst
+}
+
+// Owner to use to derive the funding account address
+func (inst *TransferWithSeed) SetFromOwner(fromOwner solana.PublicKey) *TransferWithSeed {
+	inst.FromOwner = &fromOwner
+	return inst
+}
+
+// Funding account
+func (inst *TransferWithSeed) SetFundingAccount(fundingAccount solana.PublicKey) *TransferWithSeed {
+	inst.AccountMetaSlice[0] = solana.Meta(fundingAccount).WRITE()
+	return inst
+}
+
+func (inst *TransferWithSeed) GetFundingAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Base for funding account
+func (inst *TransferWithSeed) SetBaseForFundingAccount(baseForFundingAccount solana.PublicKey) *TransferWithSeed {
+	inst.AccountMetaSlice[1] = solana.Meta(baseForFundingAccount).SIGNER()
+	return inst
+}
+
+func (inst *TransferWithSeed) GetBaseForFundingAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// Recipient account
+func (inst *TransferWithSeed) SetRecipientAccount(recipientAccount solana.PublicKey) *TransferWithSeed {
+	inst.AccountMetaSlice[2] = solana.Meta(recipientAccount).WRITE()
+	return inst
+}
+
+func (inst *TransferWithSeed) GetRecipientAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+func (inst TransferWithSeed) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint32(Instruction_TransferWithSeed, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst TransferWithSeed) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *TransferWithSeed) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Lamports == nil {
+			return errors.New("Lamports parameter is not set")
+		}
+		if inst.FromSeed == nil {
+			return errors.New("FromSeed parameter is not set")
+		}
+		if inst.FromOwner == nil {
+			return errors.New("FromOwner parameter is not set")
+		}
+	}
+
+	// Check whether all accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("FundingAccount is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return errors.New("BaseForFundingAccount is not set")
+		}
+		if inst.AccountMetaSlice[2] == nil {
+			return errors.New("RecipientAccount is not set")
+		}
+	}
+	return nil
+}
+
+func (inst TransferWithSeed) MarshalWithEncoder(encoder *bin.Encoder) error {
+	// Serialize `Lamports` param:
+	{
+		err := encoder.Encode(*inst.Lamports)
+		if err != nil {
+			return err
+		}
+	}
+	// Serialize `FromSeed` param:
+	{
+		err := encoder.WriteRustString(*inst.FromSeed)
+		if err != nil {
+			return err
+		}
+	}
+	// Serialize `FromOwner` param:
+	{
+		err := encoder.Encode(*inst.FromOwner)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (inst *TransferWithSeed) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	// Deserialize `Lamports` param:
+	{
+		err := decoder.Decode(&inst.Lamports)
+		if err != nil {
+			return err
+		}
+	}
+	// Deserialize `FromSeed` param:
+	{
+		value, err := decoder.ReadRustString()
+		if err != nil {
+			return err
+		}
+		inst.FromSeed = &value
+	}
+	// Deserialize `FromOwner` param:
+	{
+		err := decoder.Decode(&inst.FromOwner)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewTransferWithSeedInstruction declares a new TransferWithSeed instruction with the provided parameters and accounts.
+func NewTransferWithSeedInstruction(
+	// Parameters:
+	lamports uint64,
+	fromSeed string,
+	fromOwner solana.PublicKey,
+	// Accounts:
+	fundingAccount solana.PublicKey,
+	baseForFundingAccount solana.PublicKey,
+	recipientAccount solana.PublicKey) *TransferWithSeed {
+	return NewTransferWithSeedInstructionBuilder().
+		SetLamports(lamports).
+		SetFromSeed(fromSeed).
+		SetFromOwner(fromOwner).
+		SetFundingAccount(fundingAccount).
+		SetBaseForFundingAccount(baseForFundingAccount).
+		SetRecipientAccount(recipientAccount)
+}
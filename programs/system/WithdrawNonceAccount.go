@@ -0,0 +1,168 @@
+package system
+
+import (
+	"encoding/binary"
+	"errors"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// Withdraw funds from a nonce account
+type WithdrawNonceAccount struct {
+	// The u64 parameter is the lamports to withdraw, which must leave the account balance above the rent exempt reserve or at zero.
+	Lamports *uint64
+
+	// [0] = [WRITE] NonceAccount
+	// ··········· Nonce account
+	//
+	// [1] = [WRITE] RecipientAccount
+	// ··········· Recipient account
+	//
+	// [2] = [] $(SysVarRecentBlockHashesPubkey)
+	// ··········· RecentBlockhashes sysvar
+	//
+	// [3] = [] $(SysVarRentPubkey)
+	// ··········· Rent sysvar
+	//
+	// [4] = [SIGNER] NonceAuthorityAccount
+	// ··········· Nonce authority
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewWithdrawNonceAccountInstructionBuilder creates a new `WithdrawNonceAccount` instruction builder.
+func NewWithdrawNonceAccountInstructionBuilder() *WithdrawNonceAccount {
+	nd := &WithdrawNonceAccount{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 5),
+	}
+	nd.AccountMetaSlice[2] = solana.Meta(solana.SysVarRecentBlockHashesPubkey)
+	nd.AccountMetaSlice[3] = solana.Meta(solana.SysVarRentPubkey)
+	return nd
+}
+
+// The u64 parameter is the lamports to withdraw, which must leave the account balance above the rent exempt reserve or at zero.
+func (inst *WithdrawNonceAccount) SetLamports(lamports uint64) *WithdrawNonceAccount {
+	inst.Lamports = &lamports
+	return inst
+}
+
+// Nonce account
+func (inst *WithdrawNonceAccount) SetNonceAccount(nonceAccount solana.PublicKey) *WithdrawNonceAccount {
+	inst.AccountMetaSlice[0] = solana.Meta(nonceAccount).WRITE()
+	return inst
+}
+
+func (inst *WithdrawNonceAccount) GetNonceAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// Recipient account
+func (inst *WithdrawNonceAccount) SetRecipientAccount(recipientAccount solana.PublicKey) *WithdrawNonceAccount {
+	inst.AccountMetaSlice[1] = solana.Meta(recipientAccount).WRITE()
+	return inst
+}
+
+func (inst *WithdrawNonceAccount) GetRecipientAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// RecentBlockhashes sysvar
+func (inst *WithdrawNonceAccount) SetSysVarRecentBlockHashesPubkeyAccount(sysVarRecentBlockHashesPubkey solana.PublicKey) *WithdrawNonceAccount {
+	inst.AccountMetaSlice[2] = solana.Meta(sysVarRecentBlockHashesPubkey)
+	return inst
+}
+
+func (inst *WithdrawNonceAccount) GetSysVarRecentBlockHashesPubkeyAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+// Rent sysvar
+func (inst *WithdrawNonceAccount) SetSysVarRentPubkeyAccount(sysVarRentPubkey solana.PublicKey) *WithdrawNonceAccount {
+	inst.AccountMetaSlice[3] = solana.Meta(sysVarRentPubkey)
+	return inst
+}
+
+func (inst *WithdrawNonceAccount) GetSysVarRentPubkeyAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[3]
+}
+
+// Nonce authority
+func (inst *WithdrawNonceAccount) SetNonceAuthorityAccount(nonceAuthorityAccount solana.PublicKey) *WithdrawNonceAccount {
+	inst.AccountMetaSlice[4] = solana.Meta(nonceAuthorityAccount).SIGNER()
+	return inst
+}
+
+func (inst *WithdrawNonceAccount) GetNonceAuthorityAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[4]
+}
+
+func (inst WithdrawNonceAccount) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint32(Instruction_WithdrawNonceAccount, binary.LittleEndian),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst WithdrawNonceAccount) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *WithdrawNonceAccount) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Lamports == nil {
+			return errors.New("Lamports parameter is not set")
+		}
+	}
+
+	// Check whether all accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("NonceAccount is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return errors.New("RecipientAccount is not set")
+		}
+		if inst.AccountMetaSlice[2] == nil {
+			return errors.New("SysVarRecentBlockHashesPubkey is not set")
+		}
+		if inst.AccountMetaSlice[3] == nil {
+			return errors.New("SysVarRentPubkey is not set")
+		}
+		if inst.AccountMetaSlice[4] == nil {
+			return errors.New("NonceAuthorityAccount is not set")
+		}
+	}
+	return nil
+}
+
+func (inst WithdrawNonceAccount) MarshalWithEncoder(encoder *bin.Encoder) error {
+	// Serialize `Lamports` param:
+	return encoder.Encode(*inst.Lamports)
+}
+
+func (inst *WithdrawNonceAccount) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	// Deserialize `Lamports` param:
+	return decoder.Decode(&inst.Lamports)
+}
+
+// NewWithdrawNonceAccountInstruction declares a new WithdrawNonceAccount instruction with the provided parameters and accounts.
+func NewWithdrawNonceAccountInstruction(
+	// Parameters:
+	lamports uint64,
+	// Accounts:
+	nonceAccount solana.PublicKey,
+	recipientAccount solana.PublicKey,
+	nonceAuthorityAccount solana.PublicKey) *WithdrawNonceAccount {
+	return NewWithdrawNonceAccountInstructionBuilder().
+		SetLamports(lamports).
+		SetNonceAccount(nonceAccount).
+		SetRecipientAccount(recipientAccount).
+		SetNonceAuthorityAccount(nonceAuthorityAccount)
+}
@@ -62,17 +62,37 @@ const (
 	Instruction_TransferWithSeed
 )
 
-// Instruction that returns a name of a program instruction by ID
-//func InstructionIDToName(id uint32) string {
-//	switch id {
-//	case Instruction_CreateAccount:
-//		return "CreateAccount"
-//	case Instruction_Transfer:
-//		return "Transfer"
-//	default:
-//		return ""
-//	}
-//}
+// InstructionIDToName returns the name of the instruction given its ID.
+func InstructionIDToName(id uint32) string {
+	switch id {
+	case Instruction_CreateAccount:
+		return "CreateAccount"
+	case Instruction_Assign:
+		return "Assign"
+	case Instruction_Transfer:
+		return "Transfer"
+	case Instruction_CreateAccountWithSeed:
+		return "CreateAccountWithSeed"
+	case Instruction_AdvanceNonceAccount:
+		return "AdvanceNonceAccount"
+	case Instruction_WithdrawNonceAccount:
+		return "WithdrawNonceAccount"
+	case Instruction_InitializeNonceAccount:
+		return "InitializeNonceAccount"
+	case Instruction_AuthorizeNonceAccount:
+		return "AuthorizeNonceAccount"
+	case Instruction_Allocate:
+		return "Allocate"
+	case Instruction_AllocateWithSeed:
+		return "AllocateWithSeed"
+	case Instruction_AssignWithSeed:
+		return "AssignWithSeed"
+	case Instruction_TransferWithSeed:
+		return "TransferWithSeed"
+	default:
+		return ""
+	}
+}
 
 type Instruction struct {
   gg_binary.BaseVariant
@@ -116,18 +136,46 @@ func DecodeInstruction(accounts []*scatsol.AccountMeta, data []byte) (*Instructi
 	return inst, nil
 }
 
+// The variants are listed in ID order: the position of each is its type ID.
 var InstructionImplDef = gg_binary.NewVariantDefinition(
 	gg_binary.Uint32TypeIDEncoding,
 	[]gg_binary.VariantType{
-    {
-      "Transfer", (*Transfer)(nil),
+		{
+			"CreateAccount", (*CreateAccount)(nil),
+		},
+		{
+			"Assign", (*Assign)(nil),
 		},
-    {
-      "CreaetAccount", (*CreateAccount)(nil),
+		{
+			"Transfer", (*Transfer)(nil),
 		},
-    {
+		{
 			"CreateAccountWithSeed", (*CreateAccountWithSeed)(nil),
 		},
+		{
+			"AdvanceNonceAccount", (*AdvanceNonceAccount)(nil),
+		},
+		{
+			"WithdrawNonceAccount", (*WithdrawNonceAccount)(nil),
+		},
+		{
+			"InitializeNonceAccount", (*InitializeNonceAccount)(nil),
+		},
+		{
+			"AuthorizeNonceAccount", (*AuthorizeNonceAccount)(nil),
+		},
+		{
+			"Allocate", (*Allocate)(nil),
+		},
+		{
+			"AllocateWithSeed", (*AllocateWithSeed)(nil),
+		},
+		{
+			"AssignWithSeed", (*AssignWithSeed)(nil),
+		},
+		{
+			"TransferWithSeed", (*TransferWithSeed)(nil),
+		},
 	},
 )
 
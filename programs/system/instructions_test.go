@@ -0,0 +1,69 @@
+package system
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/scatkit/pumpdexer/solana"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_RoundTrip(t *testing.T) {
+	a, b, c := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	owner := solana.NewWallet().PublicKey()
+
+	tests := []struct {
+		id           uint32
+		inst         *Instruction
+		wantAccounts int
+	}{
+		{Instruction_CreateAccount, NewCreateAccountInstruction(1, 2, owner, a, b).Build(), 2},
+		{Instruction_Assign, NewAssignInstruction(owner, a).Build(), 1},
+		{Instruction_Transfer, NewTransferInstruction(1, a, b).Build(), 2},
+		{Instruction_CreateAccountWithSeed, NewCreateAccountWithSeedInstruction(c, "seed", 1, 2, owner, a, b, c).Build(), 3},
+		{Instruction_AdvanceNonceAccount, NewAdvanceNonceAccountInstruction(a, b).Build(), 3},
+		{Instruction_WithdrawNonceAccount, NewWithdrawNonceAccountInstruction(1, a, b, c).Build(), 5},
+		{Instruction_InitializeNonceAccount, NewInitializeNonceAccountInstruction(b, a).Build(), 3},
+		{Instruction_AuthorizeNonceAccount, NewAuthorizeNonceAccountInstruction(c, a, b).Build(), 2},
+		{Instruction_Allocate, NewAllocateInstruction(42, a).Build(), 1},
+		{Instruction_AllocateWithSeed, NewAllocateWithSeedInstruction(b, "seed", 42, owner, a, b).Build(), 2},
+		{Instruction_AssignWithSeed, NewAssignWithSeedInstruction(b, "seed", owner, a, b).Build(), 2},
+		{Instruction_TransferWithSeed, NewTransferWithSeedInstruction(1, "seed", owner, a, b, c).Build(), 3},
+	}
+
+	for _, test := range tests {
+		t.Run(InstructionIDToName(test.id), func(t *testing.T) {
+			require.NotEmpty(t, InstructionIDToName(test.id))
+			require.Len(t, test.inst.Accounts(), test.wantAccounts)
+
+			data, err := test.inst.Data()
+			require.NoError(t, err)
+			require.Equal(t, test.id, binary.LittleEndian.Uint32(data))
+
+			decoded, err := solana.DecodeInstruction(ProgramID, test.inst.Accounts(), data)
+			require.NoError(t, err)
+			got := decoded.(*Instruction)
+			require.Equal(t, test.id, got.TypeID.Uint32())
+			require.Equal(t, test.inst.Accounts(), got.Accounts())
+
+			reencoded, err := got.Data()
+			require.NoError(t, err)
+			require.Equal(t, data, reencoded)
+		})
+	}
+}
+
+func TestNonceInstructions_Sysvars(t *testing.T) {
+	nonce, authority := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+
+	advance := NewAdvanceNonceAccountInstruction(nonce, authority)
+	require.Equal(t, solana.SysVarRecentBlockHashesPubkey, advance.GetSysVarRecentBlockHashesPubkeyAccount().PublicKey)
+	require.True(t, advance.GetNonceAuthorityAccount().IsSigner)
+
+	initialize := NewInitializeNonceAccountInstruction(authority, nonce)
+	require.Equal(t, solana.SysVarRentPubkey, initialize.GetSysVarRentPubkeyAccount().PublicKey)
+	require.NoError(t, initialize.Validate())
+
+	_, err := NewWithdrawNonceAccountInstructionBuilder().SetNonceAccount(nonce).ValidateAndBuild()
+	require.EqualError(t, err, "Lamports parameter is not set")
+}
@@ -0,0 +1,166 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/rpc"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// NONCE_ACCOUNT_LENGTH is the serialized size of a nonce account.
+const NONCE_ACCOUNT_LENGTH = 80
+
+// Versions of the nonce account layout.
+const (
+	NonceVersionLegacy uint32 = iota
+	NonceVersionCurrent
+)
+
+// States of a nonce account.
+const (
+	NonceStateUninitialized uint32 = iota
+	NonceStateInitialized
+)
+
+// NonceAccount is the state of a durable nonce account.
+type NonceAccount struct {
+	Version uint32
+	State   uint32
+
+	// Entity authorized to advance, withdraw from and re-authorize the nonce account.
+	AuthorizedPubkey solana.PublicKey
+
+	// Stored nonce value; used in place of the recent blockhash of a durable transaction.
+	Nonce solana.Hash
+
+	// Fee calculator of the nonce.
+	LamportsPerSignature uint64
+}
+
+// DecodeNonceAccount decodes the given account bytes into a NonceAccount.
+func DecodeNonceAccount(data []byte) (*NonceAccount, error) {
+	if len(data) < NONCE_ACCOUNT_LENGTH {
+		return nil, fmt.Errorf("nonce account data is too short: %d bytes, expected %d", len(data), NONCE_ACCOUNT_LENGTH)
+	}
+	var nonce NonceAccount
+	if err := nonce.UnmarshalWithDecoder(bin.NewBinDecoder(data)); err != nil {
+		return nil, err
+	}
+	return &nonce, nil
+}
+
+// IsInitialized tells whether the account holds a nonce.
+func (nonce *NonceAccount) IsInitialized() bool {
+	return nonce.State == NonceStateInitialized
+}
+
+func (nonce *NonceAccount) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	if nonce.Version, err = dec.ReadUint32(bin.LE); err != nil {
+		return fmt.Errorf("failed to decode Version: %w", err)
+	}
+	if nonce.State, err = dec.ReadUint32(bin.LE); err != nil {
+		return fmt.Errorf("failed to decode State: %w", err)
+	}
+	if _, err = dec.Read(nonce.AuthorizedPubkey[:]); err != nil {
+		return fmt.Errorf("failed to decode AuthorizedPubkey: %w", err)
+	}
+	if _, err = dec.Read(nonce.Nonce[:]); err != nil {
+		return fmt.Errorf("failed to decode Nonce: %w", err)
+	}
+	if nonce.LamportsPerSignature, err = dec.ReadUint64(bin.LE); err != nil {
+		return fmt.Errorf("failed to decode LamportsPerSignature: %w", err)
+	}
+	return nil
+}
+
+func (nonce NonceAccount) MarshalWithEncoder(enc *bin.Encoder) (err error) {
+	if err = enc.WriteUint32(nonce.Version, bin.LE); err != nil {
+		return err
+	}
+	if err = enc.WriteUint32(nonce.State, bin.LE); err != nil {
+		return err
+	}
+	if err = enc.WriteBytes(nonce.AuthorizedPubkey[:], false); err != nil {
+		return err
+	}
+	if err = enc.WriteBytes(nonce.Nonce[:], false); err != nil {
+		return err
+	}
+	return enc.WriteUint64(nonce.LamportsPerSignature, bin.LE)
+}
+
+// GetNonceAccount fetches and decodes a nonce account.
+func GetNonceAccount(ctx context.Context, rpcClient *rpc.Client, address solana.PublicKey) (*NonceAccount, error) {
+	account, err := rpcClient.GetAccountInfo(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil || account.Value == nil {
+		return nil, fmt.Errorf("account %s not found", address)
+	}
+	if !account.Value.Owner.Equals(ProgramID) {
+		return nil, fmt.Errorf("account %s is owned by %s, not by the system program", address, account.Value.Owner)
+	}
+	return DecodeNonceAccount(account.GetBinary())
+}
+
+// NewCreateNonceAccountInstructions returns the instructions that create the nonce account,
+// funded with the given lamports by the payer, and initialize it for the authority.
+// Both the payer and the nonce account must sign.
+func NewCreateNonceAccountInstructions(
+	lamports uint64,
+	payer solana.PublicKey,
+	nonceAccount solana.PublicKey,
+	authority solana.PublicKey,
+) []solana.Instruction {
+	return []solana.Instruction{
+		NewCreateAccountInstruction(lamports, NONCE_ACCOUNT_LENGTH, ProgramID, payer, nonceAccount).Build(),
+		NewInitializeNonceAccountInstruction(authority, nonceAccount).Build(),
+	}
+}
+
+// CreateNonceAccount is like NewCreateNonceAccountInstructions,
+// funding the nonce account with the minimum balance for rent exemption.
+func CreateNonceAccount(
+	ctx context.Context,
+	rpcClient *rpc.Client,
+	payer solana.PublicKey,
+	nonceAccount solana.PublicKey,
+	authority solana.PublicKey,
+) ([]solana.Instruction, error) {
+	lamports, err := rpcClient.GetMinimumBalanceForRentExemption(ctx, NONCE_ACCOUNT_LENGTH, "")
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the rent exempt balance: %w", err)
+	}
+	return NewCreateNonceAccountInstructions(lamports, payer, nonceAccount, authority), nil
+}
+
+// NewNonceTransaction creates a durable transaction: the stored nonce is used
+// in place of the recent blockhash and AdvanceNonceAccount is placed first,
+// so the transaction stays valid until the nonce is advanced.
+// The fee payer defaults to the first signer of the first of the given instructions,
+// unless set with solana.TransactionPayer.
+func NewNonceTransaction(
+	instructions []solana.Instruction,
+	nonceAccount solana.PublicKey,
+	nonce *NonceAccount,
+	opts ...solana.TransactionOption,
+) (*solana.Transaction, error) {
+	if nonce == nil || !nonce.IsInitialized() {
+		return nil, errors.New("nonce account is not initialized")
+	}
+	advance := NewAdvanceNonceAccountInstruction(nonceAccount, nonce.AuthorizedPubkey).Build()
+
+	if len(instructions) > 0 {
+		for _, acc := range instructions[0].Accounts() {
+			if acc.IsSigner {
+				opts = append([]solana.TransactionOption{solana.TransactionPayer(acc.PublicKey)}, opts...)
+				break
+			}
+		}
+	}
+	return solana.NewTransaction(append([]solana.Instruction{advance}, instructions...), nonce.Nonce, opts...)
+}
@@ -0,0 +1,99 @@
+package system
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/rpc"
+	"github.com/scatkit/pumpdexer/solana"
+	"github.com/scatkit/pumpdexer/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeNonceAccount(t *testing.T, nonce NonceAccount) []byte {
+	buf := new(bytes.Buffer)
+	require.NoError(t, nonce.MarshalWithEncoder(bin.NewBinEncoder(buf)))
+	require.Equal(t, NONCE_ACCOUNT_LENGTH, buf.Len())
+	return buf.Bytes()
+}
+
+func TestDecodeNonceAccount(t *testing.T) {
+	nonce := NonceAccount{
+		Version:              NonceVersionCurrent,
+		State:                NonceStateInitialized,
+		AuthorizedPubkey:     solana.NewWallet().PublicKey(),
+		Nonce:                solana.Hash(solana.NewWallet().PublicKey()),
+		LamportsPerSignature: 5000,
+	}
+	got, err := DecodeNonceAccount(encodeNonceAccount(t, nonce))
+	require.NoError(t, err)
+	require.Equal(t, &nonce, got)
+	require.True(t, got.IsInitialized())
+
+	_, err = DecodeNonceAccount(make([]byte, NONCE_ACCOUNT_LENGTH-1))
+	require.Error(t, err)
+
+	uninitialized, err := DecodeNonceAccount(make([]byte, NONCE_ACCOUNT_LENGTH))
+	require.NoError(t, err)
+	require.False(t, uninitialized.IsInitialized())
+}
+
+func TestNonceWorkflow(t *testing.T) {
+	srv := testutil.NewServer()
+	defer srv.Close()
+	client := rpc.New(srv.URL)
+
+	payer := solana.NewWallet().PublicKey()
+	nonceAccount := solana.NewWallet().PublicKey()
+	authority := solana.NewWallet().PublicKey()
+
+	srv.SetResult("getMinimumBalanceForRentExemption", 1_447_680)
+	instructions, err := CreateNonceAccount(context.Background(), client, payer, nonceAccount, authority)
+	require.NoError(t, err)
+	require.Len(t, instructions, 2)
+	create := instructions[0].(*Instruction).Impl.(CreateAccount)
+	require.Equal(t, uint64(1_447_680), *create.Lamports)
+	require.Equal(t, uint64(NONCE_ACCOUNT_LENGTH), *create.Space)
+	require.Equal(t, ProgramID, *create.Owner)
+	initialize := instructions[1].(*Instruction).Impl.(InitializeNonceAccount)
+	require.Equal(t, authority, *initialize.Authority)
+
+	stored := NonceAccount{
+		Version:          NonceVersionCurrent,
+		State:            NonceStateInitialized,
+		AuthorizedPubkey: authority,
+		Nonce:            solana.Hash(solana.NewWallet().PublicKey()),
+	}
+	srv.SetAccount(nonceAccount, &testutil.Account{Owner: ProgramID, Data: encodeNonceAccount(t, stored)})
+	nonce, err := GetNonceAccount(context.Background(), client, nonceAccount)
+	require.NoError(t, err)
+	require.Equal(t, &stored, nonce)
+
+	recipient := solana.NewWallet().PublicKey()
+	tx, err := NewNonceTransaction([]solana.Instruction{
+		NewTransferInstruction(1, payer, recipient).Build(),
+	}, nonceAccount, nonce)
+	require.NoError(t, err)
+	require.Equal(t, stored.Nonce, tx.Message.RecentBlockhash)
+	require.Equal(t, payer, tx.Message.AccountKeys[0], "the payer of the first instruction pays, not the nonce authority")
+	require.Equal(t, uint8(2), tx.Message.Header.NumRequiredSignatures)
+
+	require.Len(t, tx.Message.Instructions, 2)
+	first := tx.Message.Instructions[0]
+	require.Equal(t, ProgramID, tx.Message.AccountKeys[first.ProgramIDIndex])
+	advance, err := DecodeInstruction(nil, first.Data)
+	require.NoError(t, err)
+	require.Equal(t, Instruction_AdvanceNonceAccount, advance.TypeID.Uint32())
+	require.Equal(t, nonceAccount, tx.Message.AccountKeys[first.Accounts[0]])
+	require.Equal(t, solana.SysVarRecentBlockHashesPubkey, tx.Message.AccountKeys[first.Accounts[1]])
+	require.Equal(t, authority, tx.Message.AccountKeys[first.Accounts[2]])
+
+	_, err = NewNonceTransaction(nil, nonceAccount, &NonceAccount{})
+	require.EqualError(t, err, "nonce account is not initialized")
+
+	srv.SetAccount(nonceAccount, &testutil.Account{Owner: solana.TokenProgramID, Data: encodeNonceAccount(t, stored)})
+	_, err = GetNonceAccount(context.Background(), client, nonceAccount)
+	require.ErrorContains(t, err, "not by the system program")
+}
@@ -0,0 +1,120 @@
+package tokenmetadata
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/scatkit/pumpdexer/programs/token2022"
+	"github.com/scatkit/pumpdexer/rpc"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// GetMetadata fetches the metadata of the mint: from its Metaplex metadata account,
+// or else from its Token-2022 metadata extension, or the account its metadata pointer
+// points to. It fails if the mint has none of them.
+func GetMetadata(ctx context.Context, rpcClient *rpc.Client, mint solana.PublicKey) (*Metadata, error) {
+	metadatas, err := GetMetadatas(ctx, rpcClient, mint)
+	if err != nil {
+		return nil, err
+	}
+	if metadatas[0] == nil {
+		return nil, fmt.Errorf("no metadata found for mint %s", mint)
+	}
+	return metadatas[0], nil
+}
+
+// GetMetadatas is like GetMetadata for several mints, fetched in a single call.
+// The result has one entry per mint, nil when the mint has no metadata.
+func GetMetadatas(ctx context.Context, rpcClient *rpc.Client, mints ...solana.PublicKey) ([]*Metadata, error) {
+	if len(mints) == 0 {
+		return nil, nil
+	}
+	// The mints, then their metadata accounts.
+	addresses := make([]solana.PublicKey, 0, 2*len(mints))
+	addresses = append(addresses, mints...)
+	for _, mint := range mints {
+		address, _, err := FindMetadataAddress(mint)
+		if err != nil {
+			return nil, fmt.Errorf("unable to derive the metadata address of %s: %w", mint, err)
+		}
+		addresses = append(addresses, address)
+	}
+	res, err := rpcClient.GetMultipleAccountsWithOpts(ctx, addresses, &rpc.GetMultipleAccountsOpts{Encoding: solana.EncodingBase64})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Value) != len(addresses) {
+		return nil, fmt.Errorf("expected %d accounts, got %d", len(addresses), len(res.Value))
+	}
+
+	out := make([]*Metadata, len(mints))
+	// Mints whose metadata pointer points to another account, by index.
+	pointed := make(map[int]solana.PublicKey)
+	for i, mint := range mints {
+		if account := res.Value[len(mints)+i]; account != nil && account.Owner.Equals(ProgramID) {
+			if out[i], err = DecodeMetadata(account.Data.GetBinary()); err != nil {
+				return nil, fmt.Errorf("unable to decode the metadata of %s: %w", mint, err)
+			}
+			continue
+		}
+		if account := res.Value[i]; account != nil && account.Owner.Equals(solana.Token2022ProgramID) {
+			decoded, err := token2022.DecodeMint(account.Data.GetBinary())
+			if err != nil {
+				return nil, fmt.Errorf("unable to decode mint %s: %w", mint, err)
+			}
+			if decoded.TokenMetadata != nil {
+				out[i] = MetadataFromTokenMetadata(decoded.TokenMetadata)
+			} else if pointer := decoded.MetadataPointer; pointer != nil && pointer.MetadataAddress != nil && !pointer.MetadataAddress.Equals(mint) {
+				pointed[i] = *pointer.MetadataAddress
+			}
+		}
+	}
+	if len(pointed) == 0 {
+		return out, nil
+	}
+
+	indexes := make([]int, 0, len(pointed))
+	addresses = addresses[:0]
+	for i := range mints {
+		if address, ok := pointed[i]; ok {
+			indexes = append(indexes, i)
+			addresses = append(addresses, address)
+		}
+	}
+	res, err = rpcClient.GetMultipleAccountsWithOpts(ctx, addresses, &rpc.GetMultipleAccountsOpts{Encoding: solana.EncodingBase64})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Value) != len(addresses) {
+		return nil, fmt.Errorf("expected %d accounts, got %d", len(addresses), len(res.Value))
+	}
+	for j, i := range indexes {
+		account := res.Value[j]
+		if account == nil {
+			continue
+		}
+		if out[i], err = decodePointedMetadata(account); err != nil {
+			return nil, fmt.Errorf("unable to decode the metadata of %s at %s: %w", mints[i], addresses[j], err)
+		}
+		// The metadata names its mint, so that another mint can't point to it.
+		if out[i] != nil && !out[i].Mint.Equals(mints[i]) {
+			out[i] = nil
+		}
+	}
+	return out, nil
+}
+
+// decodePointedMetadata decodes the account a metadata pointer points to:
+// a Metaplex metadata account, or the account of a program implementing the token-metadata interface.
+func decodePointedMetadata(account *rpc.Account) (*Metadata, error) {
+	if account.Owner.Equals(ProgramID) {
+		return DecodeMetadata(account.Data.GetBinary())
+	}
+	tokenMetadata, err := DecodeTokenMetadataAccount(account.Data.GetBinary())
+	if err != nil {
+		return nil, err
+	}
+	metadata := MetadataFromTokenMetadata(tokenMetadata)
+	metadata.Source = account.Owner
+	return metadata, nil
+}
@@ -0,0 +1,417 @@
+package tokenmetadata
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/programs/token2022"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// The Metaplex Token Metadata program attaches data (name, symbol, URI, creators...)
+// to a mint, in an account at a PDA of the mint.
+var ProgramID solana.PublicKey = solana.TokenMetadataProgramID
+
+func SetProgramID(pubkey solana.PublicKey) {
+	ProgramID = pubkey
+}
+
+const ProgramName = "TokenMetadata"
+
+// KEY_METADATA_V1 is the first byte of a metadata account.
+const KEY_METADATA_V1 uint8 = 4
+
+// The maximum lengths of the metadata strings; the program pads them with zeros.
+const (
+	MAX_NAME_LENGTH   = 32
+	MAX_SYMBOL_LENGTH = 10
+	MAX_URI_LENGTH    = 200
+)
+
+// TokenStandard tells what kind of token the mint is.
+type TokenStandard uint8
+
+const (
+	TokenStandardNonFungible TokenStandard = iota
+	TokenStandardFungibleAsset
+	TokenStandardFungible
+	TokenStandardNonFungibleEdition
+	TokenStandardProgrammableNonFungible
+	TokenStandardProgrammableNonFungibleEdition
+)
+
+// Creator of the token, sharing the royalties.
+type Creator struct {
+	Address  solana.PublicKey
+	Verified bool
+	// Share of the royalties, in percent.
+	Share uint8
+}
+
+// Collection the token belongs to.
+type Collection struct {
+	Verified bool
+	Key      solana.PublicKey
+}
+
+// Uses of the token.
+type Uses struct {
+	UseMethod uint8
+	Remaining uint64
+	Total     uint64
+}
+
+// Metadata of a mint, from the Metaplex metadata account or the Token-2022 metadata extension.
+type Metadata struct {
+	// The authority that can update the metadata; nil if it can't be updated.
+	UpdateAuthority *solana.PublicKey
+
+	// The mint the metadata is for.
+	Mint solana.PublicKey
+
+	// The name, symbol and URI of the token, without padding.
+	Name   string
+	Symbol string
+	URI    string
+
+	// Royalties, in basis points.
+	SellerFeeBasisPoints uint16
+
+	Creators []Creator
+
+	PrimarySaleHappened bool
+
+	// Whether the metadata can change.
+	IsMutable bool
+
+	EditionNonce  *uint8
+	TokenStandard *TokenStandard
+	Collection    *Collection
+	Uses          *Uses
+
+	// Key-value pairs of the Token-2022 metadata extension.
+	AdditionalMetadata [][2]string
+
+	// Program the metadata comes from: ProgramID, solana.Token2022ProgramID for the extension,
+	// or the owner of the account the metadata pointer of the mint points to.
+	Source solana.PublicKey
+}
+
+// FindMetadataAddress derives the address of the metadata account of the mint.
+func FindMetadataAddress(mint solana.PublicKey) (solana.PublicKey, uint8, error) {
+	return solana.FindProgramAddress([][]byte{
+		[]byte("metadata"),
+		ProgramID[:],
+		mint[:],
+	}, ProgramID)
+}
+
+// DecodeMetadata decodes the data of a Metaplex metadata account.
+// The trailing optional fields are not set on old accounts; decoding stops where the data ends.
+func DecodeMetadata(data []byte) (*Metadata, error) {
+	var metadata Metadata
+	if err := metadata.UnmarshalWithDecoder(bin.NewBinDecoder(data)); err != nil {
+		return nil, err
+	}
+	return &metadata, nil
+}
+
+// TokenMetadataDiscriminator starts the TLV entry holding the token-metadata interface state
+// in an account of a program implementing the interface: the first 8 bytes of
+// sha256("spl_token_metadata_interface:token_metadata").
+var TokenMetadataDiscriminator = [8]byte{112, 132, 90, 90, 11, 88, 157, 87}
+
+// DecodeTokenMetadataAccount decodes the token-metadata interface state from the data of
+// an account a Token-2022 metadata pointer points to, which holds TLV entries
+// (an 8-byte discriminator, a u32 length, then the value).
+func DecodeTokenMetadataAccount(data []byte) (*token2022.TokenMetadata, error) {
+	for len(data) >= 12 {
+		length := int(binary.LittleEndian.Uint32(data[8:12]))
+		if len(data)-12 < length {
+			return nil, fmt.Errorf("TLV entry is truncated: %d bytes, expected %d", len(data)-12, length)
+		}
+		if [8]byte(data[:8]) == TokenMetadataDiscriminator {
+			var metadata token2022.TokenMetadata
+			if err := metadata.UnmarshalWithDecoder(bin.NewBinDecoder(data[12 : 12+length])); err != nil {
+				return nil, err
+			}
+			return &metadata, nil
+		}
+		data = data[12+length:]
+	}
+	return nil, errors.New("no token metadata entry found")
+}
+
+// MetadataFromTokenMetadata converts the Token-2022 metadata extension to a Metadata.
+func MetadataFromTokenMetadata(tokenMetadata *token2022.TokenMetadata) *Metadata {
+	return &Metadata{
+		UpdateAuthority:    tokenMetadata.UpdateAuthority,
+		Mint:               tokenMetadata.Mint,
+		Name:               tokenMetadata.Name,
+		Symbol:             tokenMetadata.Symbol,
+		URI:                tokenMetadata.URI,
+		IsMutable:          tokenMetadata.UpdateAuthority != nil,
+		AdditionalMetadata: tokenMetadata.AdditionalMetadata,
+		Source:             solana.Token2022ProgramID,
+	}
+}
+
+func (metadata *Metadata) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	key, err := dec.ReadUint8()
+	if err != nil {
+		return fmt.Errorf("failed to decode Key: %w", err)
+	}
+	if key != KEY_METADATA_V1 {
+		return fmt.Errorf("not a metadata account: key %d, expected %d", key, KEY_METADATA_V1)
+	}
+	metadata.Source = ProgramID
+	metadata.UpdateAuthority = new(solana.PublicKey)
+	if _, err = dec.Read(metadata.UpdateAuthority[:]); err != nil {
+		return fmt.Errorf("failed to decode UpdateAuthority: %w", err)
+	}
+	if _, err = dec.Read(metadata.Mint[:]); err != nil {
+		return fmt.Errorf("failed to decode Mint: %w", err)
+	}
+	if metadata.Name, err = readPaddedString(dec); err != nil {
+		return fmt.Errorf("failed to decode Name: %w", err)
+	}
+	if metadata.Symbol, err = readPaddedString(dec); err != nil {
+		return fmt.Errorf("failed to decode Symbol: %w", err)
+	}
+	if metadata.URI, err = readPaddedString(dec); err != nil {
+		return fmt.Errorf("failed to decode URI: %w", err)
+	}
+	if metadata.SellerFeeBasisPoints, err = dec.ReadUint16(bin.LE); err != nil {
+		return fmt.Errorf("failed to decode SellerFeeBasisPoints: %w", err)
+	}
+	if metadata.Creators, err = readCreators(dec); err != nil {
+		return fmt.Errorf("failed to decode Creators: %w", err)
+	}
+	if metadata.PrimarySaleHappened, err = dec.ReadBool(); err != nil {
+		return fmt.Errorf("failed to decode PrimarySaleHappened: %w", err)
+	}
+	if metadata.IsMutable, err = dec.ReadBool(); err != nil {
+		return fmt.Errorf("failed to decode IsMutable: %w", err)
+	}
+
+	// Fields added in later versions of the program:
+	if dec.Remaining() == 0 {
+		return nil
+	}
+	if ok, err := readOption(dec); err != nil {
+		return fmt.Errorf("failed to decode EditionNonce: %w", err)
+	} else if ok {
+		metadata.EditionNonce = new(uint8)
+		if *metadata.EditionNonce, err = dec.ReadUint8(); err != nil {
+			return fmt.Errorf("failed to decode EditionNonce: %w", err)
+		}
+	}
+	if dec.Remaining() == 0 {
+		return nil
+	}
+	if ok, err := readOption(dec); err != nil {
+		return fmt.Errorf("failed to decode TokenStandard: %w", err)
+	} else if ok {
+		standard, err := dec.ReadUint8()
+		if err != nil {
+			return fmt.Errorf("failed to decode TokenStandard: %w", err)
+		}
+		metadata.TokenStandard = (*TokenStandard)(&standard)
+	}
+	if dec.Remaining() == 0 {
+		return nil
+	}
+	if ok, err := readOption(dec); err != nil {
+		return fmt.Errorf("failed to decode Collection: %w", err)
+	} else if ok {
+		metadata.Collection = new(Collection)
+		if metadata.Collection.Verified, err = dec.ReadBool(); err != nil {
+			return fmt.Errorf("failed to decode Collection: %w", err)
+		}
+		if _, err = dec.Read(metadata.Collection.Key[:]); err != nil {
+			return fmt.Errorf("failed to decode Collection: %w", err)
+		}
+	}
+	if dec.Remaining() == 0 {
+		return nil
+	}
+	if ok, err := readOption(dec); err != nil {
+		return fmt.Errorf("failed to decode Uses: %w", err)
+	} else if ok {
+		metadata.Uses = new(Uses)
+		if metadata.Uses.UseMethod, err = dec.ReadUint8(); err != nil {
+			return fmt.Errorf("failed to decode Uses: %w", err)
+		}
+		if metadata.Uses.Remaining, err = dec.ReadUint64(bin.LE); err != nil {
+			return fmt.Errorf("failed to decode Uses: %w", err)
+		}
+		if metadata.Uses.Total, err = dec.ReadUint64(bin.LE); err != nil {
+			return fmt.Errorf("failed to decode Uses: %w", err)
+		}
+	}
+	// The collection details and programmable config that may follow are not decoded.
+	return nil
+}
+
+// MarshalWithEncoder encodes the metadata in the layout of the Metaplex metadata account,
+// without padding the strings.
+func (metadata Metadata) MarshalWithEncoder(enc *bin.Encoder) (err error) {
+	if metadata.UpdateAuthority == nil {
+		return errors.New("UpdateAuthority is required by the metadata account")
+	}
+	if err = enc.WriteUint8(KEY_METADATA_V1); err != nil {
+		return err
+	}
+	if err = enc.WriteBytes(metadata.UpdateAuthority[:], false); err != nil {
+		return err
+	}
+	if err = enc.WriteBytes(metadata.Mint[:], false); err != nil {
+		return err
+	}
+	for _, s := range []string{metadata.Name, metadata.Symbol, metadata.URI} {
+		if err = writeString(enc, s); err != nil {
+			return err
+		}
+	}
+	if err = enc.WriteUint16(metadata.SellerFeeBasisPoints, bin.LE); err != nil {
+		return err
+	}
+	if err = enc.WriteBool(metadata.Creators != nil); err != nil {
+		return err
+	}
+	if metadata.Creators != nil {
+		if err = enc.WriteUint32(uint32(len(metadata.Creators)), bin.LE); err != nil {
+			return err
+		}
+		for _, creator := range metadata.Creators {
+			if err = enc.WriteBytes(creator.Address[:], false); err != nil {
+				return err
+			}
+			if err = enc.WriteBool(creator.Verified); err != nil {
+				return err
+			}
+			if err = enc.WriteUint8(creator.Share); err != nil {
+				return err
+			}
+		}
+	}
+	if err = enc.WriteBool(metadata.PrimarySaleHappened); err != nil {
+		return err
+	}
+	if err = enc.WriteBool(metadata.IsMutable); err != nil {
+		return err
+	}
+	if err = enc.WriteBool(metadata.EditionNonce != nil); err != nil {
+		return err
+	}
+	if metadata.EditionNonce != nil {
+		if err = enc.WriteUint8(*metadata.EditionNonce); err != nil {
+			return err
+		}
+	}
+	if err = enc.WriteBool(metadata.TokenStandard != nil); err != nil {
+		return err
+	}
+	if metadata.TokenStandard != nil {
+		if err = enc.WriteUint8(uint8(*metadata.TokenStandard)); err != nil {
+			return err
+		}
+	}
+	if err = enc.WriteBool(metadata.Collection != nil); err != nil {
+		return err
+	}
+	if metadata.Collection != nil {
+		if err = enc.WriteBool(metadata.Collection.Verified); err != nil {
+			return err
+		}
+		if err = enc.WriteBytes(metadata.Collection.Key[:], false); err != nil {
+			return err
+		}
+	}
+	if err = enc.WriteBool(metadata.Uses != nil); err != nil {
+		return err
+	}
+	if metadata.Uses != nil {
+		if err = enc.WriteUint8(metadata.Uses.UseMethod); err != nil {
+			return err
+		}
+		if err = enc.WriteUint64(metadata.Uses.Remaining, bin.LE); err != nil {
+			return err
+		}
+		if err = enc.WriteUint64(metadata.Uses.Total, bin.LE); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readPaddedString reads a borsh string and strips the zeros the program pads it with.
+func readPaddedString(dec *bin.Decoder) (string, error) {
+	length, err := dec.ReadUint32(bin.LE)
+	if err != nil {
+		return "", err
+	}
+	if int(length) > dec.Remaining() {
+		return "", fmt.Errorf("string of %d bytes doesn't fit in %d bytes", length, dec.Remaining())
+	}
+	data, err := dec.ReadNBytes(int(length))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\x00"), nil
+}
+
+// writeString writes a borsh string: a u32 length, then the bytes.
+func writeString(enc *bin.Encoder, s string) error {
+	if err := enc.WriteUint32(uint32(len(s)), bin.LE); err != nil {
+		return err
+	}
+	return enc.WriteBytes([]byte(s), false)
+}
+
+func readCreators(dec *bin.Decoder) ([]Creator, error) {
+	ok, err := readOption(dec)
+	if err != nil || !ok {
+		return nil, err
+	}
+	count, err := dec.ReadUint32(bin.LE)
+	if err != nil {
+		return nil, err
+	}
+	// 34 bytes per creator.
+	if int(count) > dec.Remaining()/34 {
+		return nil, fmt.Errorf("%d creators don't fit in %d bytes", count, dec.Remaining())
+	}
+	creators := make([]Creator, count)
+	for i := range creators {
+		if _, err = dec.Read(creators[i].Address[:]); err != nil {
+			return nil, err
+		}
+		if creators[i].Verified, err = dec.ReadBool(); err != nil {
+			return nil, err
+		}
+		if creators[i].Share, err = dec.ReadUint8(); err != nil {
+			return nil, err
+		}
+	}
+	return creators, nil
+}
+
+// readOption reads the tag of a borsh Option.
+func readOption(dec *bin.Decoder) (bool, error) {
+	tag, err := dec.ReadUint8()
+	if err != nil {
+		return false, err
+	}
+	switch tag {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid option tag %d", tag)
+	}
+}
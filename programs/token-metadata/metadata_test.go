@@ -0,0 +1,184 @@
+package tokenmetadata
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/programs/token"
+	"github.com/scatkit/pumpdexer/programs/token2022"
+	"github.com/scatkit/pumpdexer/rpc"
+	"github.com/scatkit/pumpdexer/solana"
+	"github.com/scatkit/pumpdexer/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func encode(t *testing.T, v bin.EncoderDecoder) []byte {
+	buf := new(bytes.Buffer)
+	require.NoError(t, v.MarshalWithEncoder(bin.NewBinEncoder(buf)))
+	return buf.Bytes()
+}
+
+// encodeToken2022Mint lays out an initialized Token-2022 mint with the metadata extension.
+func encodeToken2022Mint(t *testing.T, metadata token2022.TokenMetadata) []byte {
+	data := make([]byte, token.ACCOUNT_SIZE, token.ACCOUNT_SIZE+1)
+	data[45] = 1 // IsInitialized
+	data = append(data, byte(token2022.AccountTypeMint))
+	extension := encode(t, &metadata)
+	data = binary.LittleEndian.AppendUint16(data, uint16(token2022.ExtensionTokenMetadata))
+	data = binary.LittleEndian.AppendUint16(data, uint16(len(extension)))
+	return append(data, extension...)
+}
+
+func TestDecodeMetadata(t *testing.T) {
+	authority := solana.NewWallet().PublicKey()
+	nonce := uint8(254)
+	standard := TokenStandardFungible
+	metadata := Metadata{
+		UpdateAuthority:      &authority,
+		Mint:                 solana.NewWallet().PublicKey(),
+		Name:                 "Pump",
+		Symbol:               "PUMP",
+		URI:                  "https://example.com/pump.json",
+		SellerFeeBasisPoints: 500,
+		Creators:             []Creator{{Address: authority, Verified: true, Share: 100}},
+		IsMutable:            true,
+		EditionNonce:         &nonce,
+		TokenStandard:        &standard,
+		Collection:           &Collection{Key: solana.NewWallet().PublicKey()},
+		Source:               ProgramID,
+	}
+	data := encode(t, &metadata)
+
+	got, err := DecodeMetadata(data)
+	require.NoError(t, err)
+	require.Equal(t, &metadata, got)
+
+	// Old accounts end after IsMutable.
+	legacy := metadata
+	legacy.EditionNonce, legacy.TokenStandard, legacy.Collection = nil, nil, nil
+	legacyData := encode(t, &legacy)
+	got, err = DecodeMetadata(legacyData[:len(legacyData)-4])
+	require.NoError(t, err)
+	require.Equal(t, &legacy, got)
+
+	_, err = DecodeMetadata(append([]byte{1}, data[1:]...))
+	require.ErrorContains(t, err, "not a metadata account")
+	_, err = DecodeMetadata(data[:100])
+	require.Error(t, err)
+}
+
+func TestDecodeMetadata_Padding(t *testing.T) {
+	authority := solana.NewWallet().PublicKey()
+	buf := new(bytes.Buffer)
+	enc := bin.NewBinEncoder(buf)
+	require.NoError(t, enc.WriteUint8(KEY_METADATA_V1))
+	require.NoError(t, enc.WriteBytes(authority[:], false))
+	require.NoError(t, enc.WriteBytes(authority[:], false))
+	require.NoError(t, writeString(enc, "Pump"+string(make([]byte, MAX_NAME_LENGTH-4))))
+	require.NoError(t, writeString(enc, "PUMP"+string(make([]byte, MAX_SYMBOL_LENGTH-4))))
+	require.NoError(t, writeString(enc, string(make([]byte, MAX_URI_LENGTH))))
+	require.NoError(t, enc.WriteBytes([]byte{0, 0, 0, 0, 1}, false)) // fee, no creators, sale, mutable
+
+	got, err := DecodeMetadata(buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, "Pump", got.Name)
+	require.Equal(t, "PUMP", got.Symbol)
+	require.Equal(t, "", got.URI)
+	require.True(t, got.IsMutable)
+}
+
+func TestGetMetadatas(t *testing.T) {
+	srv := testutil.NewServer()
+	defer srv.Close()
+	client := rpc.New(srv.URL)
+
+	authority := solana.NewWallet().PublicKey()
+	metaplexMint := solana.NewWallet().PublicKey()
+	token2022Mint := solana.NewWallet().PublicKey()
+	bareMint := solana.NewWallet().PublicKey()
+
+	metadata := Metadata{UpdateAuthority: &authority, Mint: metaplexMint, Name: "Metaplex", Symbol: "MPL", Source: ProgramID}
+	address, bump, err := FindMetadataAddress(metaplexMint)
+	require.NoError(t, err)
+	expected, err := solana.CreateProgramAddress([][]byte{[]byte("metadata"), ProgramID[:], metaplexMint[:], {bump}}, ProgramID)
+	require.NoError(t, err)
+	require.Equal(t, expected, address)
+	srv.SetAccount(metaplexMint, &testutil.Account{Owner: solana.TokenProgramID, Data: make([]byte, token.MINT_SIZE)})
+	srv.SetAccount(address, &testutil.Account{Owner: ProgramID, Data: encode(t, &metadata)})
+
+	srv.SetAccount(token2022Mint, &testutil.Account{Owner: solana.Token2022ProgramID, Data: encodeToken2022Mint(t, token2022.TokenMetadata{
+		Mint:               token2022Mint,
+		Name:               "Extension",
+		Symbol:             "EXT",
+		URI:                "https://example.com/ext.json",
+		AdditionalMetadata: [][2]string{{"strategy", "42"}},
+	})})
+	srv.SetAccount(bareMint, &testutil.Account{Owner: solana.TokenProgramID, Data: make([]byte, token.MINT_SIZE)})
+
+	metadatas, err := GetMetadatas(context.Background(), client, metaplexMint, token2022Mint, bareMint)
+	require.NoError(t, err)
+	require.Len(t, metadatas, 3)
+	require.Equal(t, &metadata, metadatas[0])
+
+	require.Equal(t, &Metadata{
+		Mint:               token2022Mint,
+		Name:               "Extension",
+		Symbol:             "EXT",
+		URI:                "https://example.com/ext.json",
+		AdditionalMetadata: [][2]string{{"strategy", "42"}},
+		Source:             solana.Token2022ProgramID,
+	}, metadatas[1], "without update authority, the metadata is immutable")
+	require.Nil(t, metadatas[2])
+
+	got, err := GetMetadata(context.Background(), client, token2022Mint)
+	require.NoError(t, err)
+	require.Equal(t, "Extension", got.Name)
+
+	_, err = GetMetadata(context.Background(), client, bareMint)
+	require.ErrorContains(t, err, "no metadata found")
+}
+
+func TestGetMetadatas_MetadataPointer(t *testing.T) {
+	srv := testutil.NewServer()
+	defer srv.Close()
+	client := rpc.New(srv.URL)
+
+	mint := solana.NewWallet().PublicKey()
+	spoofingMint := solana.NewWallet().PublicKey()
+	metadataAddress := solana.NewWallet().PublicKey()
+	metadataProgram := solana.NewWallet().PublicKey()
+
+	// A mint with a pointer but no metadata extension.
+	pointerMint := func(address solana.PublicKey) []byte {
+		data := make([]byte, token.ACCOUNT_SIZE, token.ACCOUNT_SIZE+1)
+		data[45] = 1 // IsInitialized
+		data = append(data, byte(token2022.AccountTypeMint))
+		extension := encode(t, &token2022.MetadataPointer{MetadataAddress: &address})
+		data = binary.LittleEndian.AppendUint16(data, uint16(token2022.ExtensionMetadataPointer))
+		data = binary.LittleEndian.AppendUint16(data, uint16(len(extension)))
+		return append(data, extension...)
+	}
+	srv.SetAccount(mint, &testutil.Account{Owner: solana.Token2022ProgramID, Data: pointerMint(metadataAddress)})
+	srv.SetAccount(spoofingMint, &testutil.Account{Owner: solana.Token2022ProgramID, Data: pointerMint(metadataAddress)})
+
+	// The account of the metadata program: a TLV entry after another one.
+	value := encode(t, &token2022.TokenMetadata{Mint: mint, Name: "Pointed", Symbol: "PTR"})
+	data := []byte{1, 2, 3, 4, 5, 6, 7, 8, 2, 0, 0, 0, 9, 9}
+	data = append(data, TokenMetadataDiscriminator[:]...)
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(value)))
+	data = append(data, value...)
+	srv.SetAccount(metadataAddress, &testutil.Account{Owner: metadataProgram, Data: data})
+
+	metadatas, err := GetMetadatas(context.Background(), client, mint, spoofingMint)
+	require.NoError(t, err)
+	require.Equal(t, &Metadata{Mint: mint, Name: "Pointed", Symbol: "PTR", Source: metadataProgram}, metadatas[0])
+	require.Nil(t, metadatas[1], "the metadata is for another mint")
+
+	_, err = DecodeTokenMetadataAccount(data[:len(data)-1])
+	require.ErrorContains(t, err, "truncated")
+	_, err = DecodeTokenMetadataAccount(data[:14])
+	require.ErrorContains(t, err, "no token metadata entry")
+}
@@ -0,0 +1,185 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	solana "github.com/scatkit/pumpdexer/solana"
+)
+
+// Approves a delegate.  A delegate is given the authority over tokens on
+// behalf of the source account's owner.
+type Approve struct {
+	// The amount of tokens the delegate is approved for.
+	Amount *uint64
+
+	// [0] = [WRITE] source
+	// ··········· The source account.
+	//
+	// [1] = [] delegate
+	// ··········· The delegate.
+	//
+	// [2] = [] owner
+	// ··········· The source account owner.
+	//
+	// [3...] = [SIGNER] signers
+	// ··········· M signer accounts.
+	Accounts solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+	Signers  solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+func (obj *Approve) SetAccounts(accounts []*solana.AccountMeta) error {
+	obj.Accounts, obj.Signers = solana.AccountMetaSlice(accounts).SplitFrom(3)
+	return nil
+}
+
+func (slice Approve) GetAccounts() (accounts []*solana.AccountMeta) {
+	accounts = append(accounts, slice.Accounts...)
+	accounts = append(accounts, slice.Signers...)
+	return
+}
+
+// NewApproveInstructionBuilder creates a new `Approve` instruction builder.
+func NewApproveInstructionBuilder() *Approve {
+	nd := &Approve{
+		Accounts: make(solana.AccountMetaSlice, 3),
+		Signers:  make(solana.AccountMetaSlice, 0),
+	}
+	return nd
+}
+
+// SetAmount sets the "amount" parameter.
+// The amount of tokens the delegate is approved for.
+func (inst *Approve) SetAmount(amount uint64) *Approve {
+	inst.Amount = &amount
+	return inst
+}
+
+// SetSourceAccount sets the "source" account.
+// The source account.
+func (inst *Approve) SetSourceAccount(source solana.PublicKey) *Approve {
+	inst.Accounts[0] = solana.Meta(source).WRITE()
+	return inst
+}
+
+// GetSourceAccount gets the "source" account.
+// The source account.
+func (inst *Approve) GetSourceAccount() *solana.AccountMeta {
+	return inst.Accounts[0]
+}
+
+// SetDelegateAccount sets the "delegate" account.
+// The delegate.
+func (inst *Approve) SetDelegateAccount(delegate solana.PublicKey) *Approve {
+	inst.Accounts[1] = solana.Meta(delegate)
+	return inst
+}
+
+// GetDelegateAccount gets the "delegate" account.
+// The delegate.
+func (inst *Approve) GetDelegateAccount() *solana.AccountMeta {
+	return inst.Accounts[1]
+}
+
+// SetOwnerAccount sets the "owner" account.
+// The source account owner.
+func (inst *Approve) SetOwnerAccount(owner solana.PublicKey, multisigSigners ...solana.PublicKey) *Approve {
+	inst.Accounts[2] = solana.Meta(owner)
+	if len(multisigSigners) == 0 {
+		inst.Accounts[2].SIGNER()
+	}
+	for _, signer := range multisigSigners {
+		inst.Signers = append(inst.Signers, solana.Meta(signer).SIGNER())
+	}
+	return inst
+}
+
+// GetOwnerAccount gets the "owner" account.
+// The source account owner.
+func (inst *Approve) GetOwnerAccount() *solana.AccountMeta {
+	return inst.Accounts[2]
+}
+
+func (inst Approve) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint8(Instruction_Approve),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst Approve) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *Approve) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Amount == nil {
+			return errors.New("Amount parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.Accounts[0] == nil {
+			return errors.New("accounts.Source is not set")
+		}
+		if inst.Accounts[1] == nil {
+			return errors.New("accounts.Delegate is not set")
+		}
+		if inst.Accounts[2] == nil {
+			return errors.New("accounts.Owner is not set")
+		}
+		if !inst.Accounts[2].IsSigner && len(inst.Signers) == 0 {
+			return errors.New("accounts.Signers is not set")
+		}
+		if len(inst.Signers) > MAX_SIGNERS {
+			return fmt.Errorf("too many signers; got %v, but max is 11", len(inst.Signers))
+		}
+	}
+	return nil
+}
+
+func (obj Approve) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	// Serialize `Amount` param:
+	err = encoder.WriteUint64(*obj.Amount, bin.LE)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (obj *Approve) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	// Deserialize `Amount`:
+	var amount uint64
+	amount, err = decoder.ReadUint64(bin.LE)
+	if err != nil {
+		return err
+	}
+	obj.Amount = &amount
+	return nil
+}
+
+// NewApproveInstruction declares a new Approve instruction with the provided parameters and accounts.
+func NewApproveInstruction(
+	// Parameters:
+	amount uint64,
+	// Accounts:
+	source solana.PublicKey,
+	delegate solana.PublicKey,
+	owner solana.PublicKey,
+	multisigSigners []solana.PublicKey,
+) *Approve {
+	return NewApproveInstructionBuilder().
+		SetAmount(amount).
+		SetSourceAccount(source).
+		SetDelegateAccount(delegate).
+		SetOwnerAccount(owner, multisigSigners...)
+}
@@ -0,0 +1,237 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	solana "github.com/scatkit/pumpdexer/solana"
+)
+
+// Approves a delegate.  A delegate is given the authority over tokens on
+// behalf of the source account's owner.
+//
+// This instruction differs from Approve in that the token mint and
+// decimals value is checked by the caller.  This may be useful when
+// creating transactions offline or within a hardware wallet.
+type ApproveChecked struct {
+	// The amount of tokens the delegate is approved for.
+	Amount *uint64
+
+	// Expected number of base 10 digits to the right of the decimal place.
+	Decimals *uint8
+
+	// [0] = [WRITE] source
+	// ··········· The source account.
+	//
+	// [1] = [] mint
+	// ··········· The token mint.
+	//
+	// [2] = [] delegate
+	// ··········· The delegate.
+	//
+	// [3] = [] owner
+	// ··········· The source account owner.
+	//
+	// [4...] = [SIGNER] signers
+	// ··········· M signer accounts.
+	Accounts solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+	Signers  solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+func (obj *ApproveChecked) SetAccounts(accounts []*solana.AccountMeta) error {
+	obj.Accounts, obj.Signers = solana.AccountMetaSlice(accounts).SplitFrom(4)
+	return nil
+}
+
+func (slice ApproveChecked) GetAccounts() (accounts []*solana.AccountMeta) {
+	accounts = append(accounts, slice.Accounts...)
+	accounts = append(accounts, slice.Signers...)
+	return
+}
+
+// NewApproveCheckedInstructionBuilder creates a new `ApproveChecked` instruction builder.
+func NewApproveCheckedInstructionBuilder() *ApproveChecked {
+	nd := &ApproveChecked{
+		Accounts: make(solana.AccountMetaSlice, 4),
+		Signers:  make(solana.AccountMetaSlice, 0),
+	}
+	return nd
+}
+
+// SetAmount sets the "amount" parameter.
+// The amount of tokens the delegate is approved for.
+func (inst *ApproveChecked) SetAmount(amount uint64) *ApproveChecked {
+	inst.Amount = &amount
+	return inst
+}
+
+// SetDecimals sets the "decimals" parameter.
+// Expected number of base 10 digits to the right of the decimal place.
+func (inst *ApproveChecked) SetDecimals(decimals uint8) *ApproveChecked {
+	inst.Decimals = &decimals
+	return inst
+}
+
+// SetSourceAccount sets the "source" account.
+// The source account.
+func (inst *ApproveChecked) SetSourceAccount(source solana.PublicKey) *ApproveChecked {
+	inst.Accounts[0] = solana.Meta(source).WRITE()
+	return inst
+}
+
+// GetSourceAccount gets the "source" account.
+// The source account.
+func (inst *ApproveChecked) GetSourceAccount() *solana.AccountMeta {
+	return inst.Accounts[0]
+}
+
+// SetMintAccount sets the "mint" account.
+// The token mint.
+func (inst *ApproveChecked) SetMintAccount(mint solana.PublicKey) *ApproveChecked {
+	inst.Accounts[1] = solana.Meta(mint)
+	return inst
+}
+
+// GetMintAccount gets the "mint" account.
+// The token mint.
+func (inst *ApproveChecked) GetMintAccount() *solana.AccountMeta {
+	return inst.Accounts[1]
+}
+
+// SetDelegateAccount sets the "delegate" account.
+// The delegate.
+func (inst *ApproveChecked) SetDelegateAccount(delegate solana.PublicKey) *ApproveChecked {
+	inst.Accounts[2] = solana.Meta(delegate)
+	return inst
+}
+
+// GetDelegateAccount gets the "delegate" account.
+// The delegate.
+func (inst *ApproveChecked) GetDelegateAccount() *solana.AccountMeta {
+	return inst.Accounts[2]
+}
+
+// SetOwnerAccount sets the "owner" account.
+// The source account owner.
+func (inst *ApproveChecked) SetOwnerAccount(owner solana.PublicKey, multisigSigners ...solana.PublicKey) *ApproveChecked {
+	inst.Accounts[3] = solana.Meta(owner)
+	if len(multisigSigners) == 0 {
+		inst.Accounts[3].SIGNER()
+	}
+	for _, signer := range multisigSigners {
+		inst.Signers = append(inst.Signers, solana.Meta(signer).SIGNER())
+	}
+	return inst
+}
+
+// GetOwnerAccount gets the "owner" account.
+// The source account owner.
+func (inst *ApproveChecked) GetOwnerAccount() *solana.AccountMeta {
+	return inst.Accounts[3]
+}
+
+func (inst ApproveChecked) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint8(Instruction_ApproveChecked),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst ApproveChecked) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *ApproveChecked) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Amount == nil {
+			return errors.New("Amount parameter is not set")
+		}
+		if inst.Decimals == nil {
+			return errors.New("Decimals parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.Accounts[0] == nil {
+			return errors.New("accounts.Source is not set")
+		}
+		if inst.Accounts[1] == nil {
+			return errors.New("accounts.Mint is not set")
+		}
+		if inst.Accounts[2] == nil {
+			return errors.New("accounts.Delegate is not set")
+		}
+		if inst.Accounts[3] == nil {
+			return errors.New("accounts.Owner is not set")
+		}
+		if !inst.Accounts[3].IsSigner && len(inst.Signers) == 0 {
+			return errors.New("accounts.Signers is not set")
+		}
+		if len(inst.Signers) > MAX_SIGNERS {
+			return fmt.Errorf("too many signers; got %v, but max is 11", len(inst.Signers))
+		}
+	}
+	return nil
+}
+
+func (obj ApproveChecked) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	// Serialize `Amount` param:
+	err = encoder.WriteUint64(*obj.Amount, bin.LE)
+	if err != nil {
+		return err
+	}
+	// Serialize `Decimals` param:
+	err = encoder.WriteUint8(*obj.Decimals)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (obj *ApproveChecked) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	// Deserialize `Amount`:
+	var amount uint64
+	amount, err = decoder.ReadUint64(bin.LE)
+	if err != nil {
+		return err
+	}
+	obj.Amount = &amount
+	// Deserialize `Decimals`:
+	var decimals uint8
+	decimals, err = decoder.ReadUint8()
+	if err != nil {
+		return err
+	}
+	obj.Decimals = &decimals
+	return nil
+}
+
+// NewApproveCheckedInstruction declares a new ApproveChecked instruction with the provided parameters and accounts.
+func NewApproveCheckedInstruction(
+	// Parameters:
+	amount uint64,
+	decimals uint8,
+	// Accounts:
+	source solana.PublicKey,
+	mint solana.PublicKey,
+	delegate solana.PublicKey,
+	owner solana.PublicKey,
+	multisigSigners []solana.PublicKey,
+) *ApproveChecked {
+	return NewApproveCheckedInstructionBuilder().
+		SetAmount(amount).
+		SetDecimals(decimals).
+		SetSourceAccount(source).
+		SetMintAccount(mint).
+		SetDelegateAccount(delegate).
+		SetOwnerAccount(owner, multisigSigners...)
+}
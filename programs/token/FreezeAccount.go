@@ -0,0 +1,152 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	solana "github.com/scatkit/pumpdexer/solana"
+)
+
+// Freeze an Initialized account using the Mint's freeze_authority (if set).
+type FreezeAccount struct {
+	// [0] = [WRITE] account
+	// ··········· The account to freeze.
+	//
+	// [1] = [] mint
+	// ··········· The token mint.
+	//
+	// [2] = [] authority
+	// ··········· The mint freeze authority.
+	//
+	// [3...] = [SIGNER] signers
+	// ··········· M signer accounts.
+	Accounts solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+	Signers  solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+func (obj *FreezeAccount) SetAccounts(accounts []*solana.AccountMeta) error {
+	obj.Accounts, obj.Signers = solana.AccountMetaSlice(accounts).SplitFrom(3)
+	return nil
+}
+
+func (slice FreezeAccount) GetAccounts() (accounts []*solana.AccountMeta) {
+	accounts = append(accounts, slice.Accounts...)
+	accounts = append(accounts, slice.Signers...)
+	return
+}
+
+// NewFreezeAccountInstructionBuilder creates a new `FreezeAccount` instruction builder.
+func NewFreezeAccountInstructionBuilder() *FreezeAccount {
+	nd := &FreezeAccount{
+		Accounts: make(solana.AccountMetaSlice, 3),
+		Signers:  make(solana.AccountMetaSlice, 0),
+	}
+	return nd
+}
+
+// SetAccount sets the "account" account.
+// The account to freeze.
+func (inst *FreezeAccount) SetAccount(account solana.PublicKey) *FreezeAccount {
+	inst.Accounts[0] = solana.Meta(account).WRITE()
+	return inst
+}
+
+// GetAccount gets the "account" account.
+// The account to freeze.
+func (inst *FreezeAccount) GetAccount() *solana.AccountMeta {
+	return inst.Accounts[0]
+}
+
+// SetMintAccount sets the "mint" account.
+// The token mint.
+func (inst *FreezeAccount) SetMintAccount(mint solana.PublicKey) *FreezeAccount {
+	inst.Accounts[1] = solana.Meta(mint)
+	return inst
+}
+
+// GetMintAccount gets the "mint" account.
+// The token mint.
+func (inst *FreezeAccount) GetMintAccount() *solana.AccountMeta {
+	return inst.Accounts[1]
+}
+
+// SetAuthorityAccount sets the "authority" account.
+// The mint freeze authority.
+func (inst *FreezeAccount) SetAuthorityAccount(authority solana.PublicKey, multisigSigners ...solana.PublicKey) *FreezeAccount {
+	inst.Accounts[2] = solana.Meta(authority)
+	if len(multisigSigners) == 0 {
+		inst.Accounts[2].SIGNER()
+	}
+	for _, signer := range multisigSigners {
+		inst.Signers = append(inst.Signers, solana.Meta(signer).SIGNER())
+	}
+	return inst
+}
+
+// GetAuthorityAccount gets the "authority" account.
+// The mint freeze authority.
+func (inst *FreezeAccount) GetAuthorityAccount() *solana.AccountMeta {
+	return inst.Accounts[2]
+}
+
+func (inst FreezeAccount) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint8(Instruction_FreezeAccount),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst FreezeAccount) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *FreezeAccount) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if inst.Accounts[0] == nil {
+			return errors.New("accounts.Account is not set")
+		}
+		if inst.Accounts[1] == nil {
+			return errors.New("accounts.Mint is not set")
+		}
+		if inst.Accounts[2] == nil {
+			return errors.New("accounts.Authority is not set")
+		}
+		if !inst.Accounts[2].IsSigner && len(inst.Signers) == 0 {
+			return errors.New("accounts.Signers is not set")
+		}
+		if len(inst.Signers) > MAX_SIGNERS {
+			return fmt.Errorf("too many signers; got %v, but max is 11", len(inst.Signers))
+		}
+	}
+	return nil
+}
+
+func (obj FreezeAccount) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	return nil
+}
+
+func (obj *FreezeAccount) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	return nil
+}
+
+// NewFreezeAccountInstruction declares a new FreezeAccount instruction with the provided parameters and accounts.
+func NewFreezeAccountInstruction(
+	// Accounts:
+	account solana.PublicKey,
+	mint solana.PublicKey,
+	authority solana.PublicKey,
+	multisigSigners []solana.PublicKey,
+) *FreezeAccount {
+	return NewFreezeAccountInstructionBuilder().
+		SetAccount(account).
+		SetMintAccount(mint).
+		SetAuthorityAccount(authority, multisigSigners...)
+}
@@ -0,0 +1,34 @@
+package token
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	fuzz "github.com/gagliardetto/gofuzz"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecode_FreezeAccount(t *testing.T) {
+	fu := fuzz.New().NilChance(0)
+	for i := 0; i < 1; i++ {
+		t.Run("FreezeAccount"+strconv.Itoa(i), func(t *testing.T) {
+			{
+				params := new(FreezeAccount)
+				fu.Fuzz(params)
+				params.Accounts = nil
+				params.Signers = nil
+				buf := new(bytes.Buffer)
+				err := encodeT(*params, buf)
+				require.NoError(t, err)
+				//
+				got := new(FreezeAccount)
+				err = decodeT(got, buf.Bytes())
+				got.Accounts = nil
+				got.Signers = nil
+				require.NoError(t, err)
+				require.Equal(t, params, got)
+			}
+		})
+	}
+}
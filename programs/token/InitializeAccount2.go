@@ -0,0 +1,157 @@
+package token
+
+import (
+	"errors"
+
+	bin "github.com/gagliardetto/binary"
+	solana "github.com/scatkit/pumpdexer/solana"
+)
+
+// Like InitializeAccount, but the owner pubkey is passed via instruction data
+// rather than the accounts list. This variant may be preferable when using
+// Cross Program Invocation from an instruction that does not need the owner's
+// `AccountInfo` otherwise.
+type InitializeAccount2 struct {
+	// The new account's owner/multisignature.
+	Owner *solana.PublicKey
+
+	// [0] = [WRITE] account
+	// ··········· The account to initialize.
+	//
+	// [1] = [] mint
+	// ··········· The mint this account will be associated with.
+	//
+	// [2] = [] $(SysVarRentPubkey)
+	// ··········· Rent sysvar.
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewInitializeAccount2InstructionBuilder creates a new `InitializeAccount2` instruction builder.
+func NewInitializeAccount2InstructionBuilder() *InitializeAccount2 {
+	nd := &InitializeAccount2{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 3),
+	}
+	nd.AccountMetaSlice[2] = solana.Meta(solana.SysVarRentPubkey)
+	return nd
+}
+
+// SetOwner sets the "owner" parameter.
+// The new account's owner/multisignature.
+func (inst *InitializeAccount2) SetOwner(owner solana.PublicKey) *InitializeAccount2 {
+	inst.Owner = &owner
+	return inst
+}
+
+// SetAccount sets the "account" account.
+// The account to initialize.
+func (inst *InitializeAccount2) SetAccount(account solana.PublicKey) *InitializeAccount2 {
+	inst.AccountMetaSlice[0] = solana.Meta(account).WRITE()
+	return inst
+}
+
+// GetAccount gets the "account" account.
+// The account to initialize.
+func (inst *InitializeAccount2) GetAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// SetMintAccount sets the "mint" account.
+// The mint this account will be associated with.
+func (inst *InitializeAccount2) SetMintAccount(mint solana.PublicKey) *InitializeAccount2 {
+	inst.AccountMetaSlice[1] = solana.Meta(mint)
+	return inst
+}
+
+// GetMintAccount gets the "mint" account.
+// The mint this account will be associated with.
+func (inst *InitializeAccount2) GetMintAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// SetSysVarRentPubkeyAccount sets the "$(SysVarRentPubkey)" account.
+// Rent sysvar.
+func (inst *InitializeAccount2) SetSysVarRentPubkeyAccount(SysVarRentPubkey solana.PublicKey) *InitializeAccount2 {
+	inst.AccountMetaSlice[2] = solana.Meta(SysVarRentPubkey)
+	return inst
+}
+
+// GetSysVarRentPubkeyAccount gets the "$(SysVarRentPubkey)" account.
+// Rent sysvar.
+func (inst *InitializeAccount2) GetSysVarRentPubkeyAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+func (inst InitializeAccount2) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint8(Instruction_InitializeAccount2),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst InitializeAccount2) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *InitializeAccount2) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Owner == nil {
+			return errors.New("Owner parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Account is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return errors.New("accounts.Mint is not set")
+		}
+		if inst.AccountMetaSlice[2] == nil {
+			return errors.New("accounts.SysVarRentPubkey is not set")
+		}
+	}
+	return nil
+}
+
+func (obj InitializeAccount2) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	// Serialize `Owner` param:
+	err = encoder.WriteBytes(obj.Owner[:], false)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (obj *InitializeAccount2) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	// Deserialize `Owner`:
+	obj.Owner = new(solana.PublicKey)
+	err = decoder.Decode(obj.Owner)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewInitializeAccount2Instruction declares a new InitializeAccount2 instruction with the provided parameters and accounts.
+func NewInitializeAccount2Instruction(
+	// Parameters:
+	owner solana.PublicKey,
+	// Accounts:
+	account solana.PublicKey,
+	mint solana.PublicKey,
+	SysVarRentPubkey solana.PublicKey,
+) *InitializeAccount2 {
+	return NewInitializeAccount2InstructionBuilder().
+		SetOwner(owner).
+		SetAccount(account).
+		SetMintAccount(mint).
+		SetSysVarRentPubkeyAccount(SysVarRentPubkey)
+}
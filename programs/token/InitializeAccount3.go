@@ -0,0 +1,132 @@
+package token
+
+import (
+	"errors"
+
+	bin "github.com/gagliardetto/binary"
+	solana "github.com/scatkit/pumpdexer/solana"
+)
+
+// Like InitializeAccount2, but does not require the Rent sysvar to be provided.
+type InitializeAccount3 struct {
+	// The new account's owner/multisignature.
+	Owner *solana.PublicKey
+
+	// [0] = [WRITE] account
+	// ··········· The account to initialize.
+	//
+	// [1] = [] mint
+	// ··········· The mint this account will be associated with.
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewInitializeAccount3InstructionBuilder creates a new `InitializeAccount3` instruction builder.
+func NewInitializeAccount3InstructionBuilder() *InitializeAccount3 {
+	nd := &InitializeAccount3{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 2),
+	}
+	return nd
+}
+
+// SetOwner sets the "owner" parameter.
+// The new account's owner/multisignature.
+func (inst *InitializeAccount3) SetOwner(owner solana.PublicKey) *InitializeAccount3 {
+	inst.Owner = &owner
+	return inst
+}
+
+// SetAccount sets the "account" account.
+// The account to initialize.
+func (inst *InitializeAccount3) SetAccount(account solana.PublicKey) *InitializeAccount3 {
+	inst.AccountMetaSlice[0] = solana.Meta(account).WRITE()
+	return inst
+}
+
+// GetAccount gets the "account" account.
+// The account to initialize.
+func (inst *InitializeAccount3) GetAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// SetMintAccount sets the "mint" account.
+// The mint this account will be associated with.
+func (inst *InitializeAccount3) SetMintAccount(mint solana.PublicKey) *InitializeAccount3 {
+	inst.AccountMetaSlice[1] = solana.Meta(mint)
+	return inst
+}
+
+// GetMintAccount gets the "mint" account.
+// The mint this account will be associated with.
+func (inst *InitializeAccount3) GetMintAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+func (inst InitializeAccount3) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint8(Instruction_InitializeAccount3),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst InitializeAccount3) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *InitializeAccount3) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Owner == nil {
+			return errors.New("Owner parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Account is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return errors.New("accounts.Mint is not set")
+		}
+	}
+	return nil
+}
+
+func (obj InitializeAccount3) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	// Serialize `Owner` param:
+	err = encoder.WriteBytes(obj.Owner[:], false)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (obj *InitializeAccount3) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	// Deserialize `Owner`:
+	obj.Owner = new(solana.PublicKey)
+	err = decoder.Decode(obj.Owner)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewInitializeAccount3Instruction declares a new InitializeAccount3 instruction with the provided parameters and accounts.
+func NewInitializeAccount3Instruction(
+	// Parameters:
+	owner solana.PublicKey,
+	// Accounts:
+	account solana.PublicKey,
+	mint solana.PublicKey,
+) *InitializeAccount3 {
+	return NewInitializeAccount3InstructionBuilder().
+		SetOwner(owner).
+		SetAccount(account).
+		SetMintAccount(mint)
+}
@@ -0,0 +1,210 @@
+package token
+
+import (
+	"errors"
+
+	bin "github.com/gagliardetto/binary"
+	solana "github.com/scatkit/pumpdexer/solana"
+)
+
+// Initializes a new mint and optionally deposits all the newly minted
+// tokens in an account.
+//
+// The `InitializeMint` instruction requires no signers and MUST be
+// included within the same Transaction as the system program's
+// `CreateAccount` instruction that creates the account being initialized.
+// Otherwise another party can acquire ownership of the uninitialized
+// account.
+type InitializeMint struct {
+	// Number of base 10 digits to the right of the decimal place.
+	Decimals *uint8
+
+	// The authority/multisignature to mint tokens.
+	MintAuthority *solana.PublicKey
+
+	// The freeze authority/multisignature of the mint.
+	FreezeAuthority *solana.PublicKey
+
+	// [0] = [WRITE] mint
+	// ··········· The mint to initialize.
+	//
+	// [1] = [] $(SysVarRentPubkey)
+	// ··········· Rent sysvar.
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewInitializeMintInstructionBuilder creates a new `InitializeMint` instruction builder.
+func NewInitializeMintInstructionBuilder() *InitializeMint {
+	nd := &InitializeMint{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 2),
+	}
+	nd.AccountMetaSlice[1] = solana.Meta(solana.SysVarRentPubkey)
+	return nd
+}
+
+// SetDecimals sets the "decimals" parameter.
+// Number of base 10 digits to the right of the decimal place.
+func (inst *InitializeMint) SetDecimals(decimals uint8) *InitializeMint {
+	inst.Decimals = &decimals
+	return inst
+}
+
+// SetMintAuthority sets the "mintAuthority" parameter.
+// The authority/multisignature to mint tokens.
+func (inst *InitializeMint) SetMintAuthority(mintAuthority solana.PublicKey) *InitializeMint {
+	inst.MintAuthority = &mintAuthority
+	return inst
+}
+
+// SetFreezeAuthority sets the "freezeAuthority" parameter.
+// The freeze authority/multisignature of the mint.
+func (inst *InitializeMint) SetFreezeAuthority(freezeAuthority solana.PublicKey) *InitializeMint {
+	inst.FreezeAuthority = &freezeAuthority
+	return inst
+}
+
+// SetMintAccount sets the "mint" account.
+// The mint to initialize.
+func (inst *InitializeMint) SetMintAccount(mint solana.PublicKey) *InitializeMint {
+	inst.AccountMetaSlice[0] = solana.Meta(mint).WRITE()
+	return inst
+}
+
+// GetMintAccount gets the "mint" account.
+// The mint to initialize.
+func (inst *InitializeMint) GetMintAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// SetSysVarRentPubkeyAccount sets the "$(SysVarRentPubkey)" account.
+// Rent sysvar.
+func (inst *InitializeMint) SetSysVarRentPubkeyAccount(SysVarRentPubkey solana.PublicKey) *InitializeMint {
+	inst.AccountMetaSlice[1] = solana.Meta(SysVarRentPubkey)
+	return inst
+}
+
+// GetSysVarRentPubkeyAccount gets the "$(SysVarRentPubkey)" account.
+// Rent sysvar.
+func (inst *InitializeMint) GetSysVarRentPubkeyAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+func (inst InitializeMint) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint8(Instruction_InitializeMint),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst InitializeMint) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *InitializeMint) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Decimals == nil {
+			return errors.New("Decimals parameter is not set")
+		}
+		if inst.MintAuthority == nil {
+			return errors.New("MintAuthority parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Mint is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return errors.New("accounts.SysVarRentPubkey is not set")
+		}
+	}
+	return nil
+}
+
+func (obj InitializeMint) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	// Serialize `Decimals` param:
+	err = encoder.WriteUint8(*obj.Decimals)
+	if err != nil {
+		return err
+	}
+	// Serialize `MintAuthority` param:
+	err = encoder.WriteBytes(obj.MintAuthority[:], false)
+	if err != nil {
+		return err
+	}
+	// Serialize `FreezeAuthority` param:
+	if obj.FreezeAuthority == nil {
+		err = encoder.WriteBool(false)
+		if err != nil {
+			return err
+		}
+	} else {
+		err = encoder.WriteBool(true)
+		if err != nil {
+			return err
+		}
+		err = encoder.WriteBytes(obj.FreezeAuthority[:], false)
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (obj *InitializeMint) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	// Deserialize `Decimals`:
+	var decimals uint8
+	decimals, err = decoder.ReadUint8()
+	if err != nil {
+		return err
+	}
+	obj.Decimals = &decimals
+	// Deserialize `MintAuthority`:
+	obj.MintAuthority = new(solana.PublicKey)
+	err = decoder.Decode(obj.MintAuthority)
+	if err != nil {
+		return err
+	}
+	// Deserialize `FreezeAuthority`:
+	ok, err := decoder.ReadBool()
+	if err != nil {
+		return err
+	}
+	if ok {
+		obj.FreezeAuthority = new(solana.PublicKey)
+		err = decoder.Decode(obj.FreezeAuthority)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewInitializeMintInstruction declares a new InitializeMint instruction with the provided parameters and accounts.
+func NewInitializeMintInstruction(
+	// Parameters:
+	decimals uint8,
+	mintAuthority solana.PublicKey,
+	freezeAuthority *solana.PublicKey,
+	// Accounts:
+	mint solana.PublicKey,
+	SysVarRentPubkey solana.PublicKey,
+) *InitializeMint {
+	inst := NewInitializeMintInstructionBuilder().
+		SetDecimals(decimals).
+		SetMintAuthority(mintAuthority).
+		SetMintAccount(mint).
+		SetSysVarRentPubkeyAccount(SysVarRentPubkey)
+	if freezeAuthority != nil {
+		inst.SetFreezeAuthority(*freezeAuthority)
+	}
+	return inst
+}
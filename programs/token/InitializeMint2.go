@@ -0,0 +1,181 @@
+package token
+
+import (
+	"errors"
+
+	bin "github.com/gagliardetto/binary"
+	solana "github.com/scatkit/pumpdexer/solana"
+)
+
+// Like InitializeMint, but does not require the Rent sysvar to be provided.
+type InitializeMint2 struct {
+	// Number of base 10 digits to the right of the decimal place.
+	Decimals *uint8
+
+	// The authority/multisignature to mint tokens.
+	MintAuthority *solana.PublicKey
+
+	// The freeze authority/multisignature of the mint.
+	FreezeAuthority *solana.PublicKey
+
+	// [0] = [WRITE] mint
+	// ··········· The mint to initialize.
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewInitializeMint2InstructionBuilder creates a new `InitializeMint2` instruction builder.
+func NewInitializeMint2InstructionBuilder() *InitializeMint2 {
+	nd := &InitializeMint2{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 1),
+	}
+	return nd
+}
+
+// SetDecimals sets the "decimals" parameter.
+// Number of base 10 digits to the right of the decimal place.
+func (inst *InitializeMint2) SetDecimals(decimals uint8) *InitializeMint2 {
+	inst.Decimals = &decimals
+	return inst
+}
+
+// SetMintAuthority sets the "mintAuthority" parameter.
+// The authority/multisignature to mint tokens.
+func (inst *InitializeMint2) SetMintAuthority(mintAuthority solana.PublicKey) *InitializeMint2 {
+	inst.MintAuthority = &mintAuthority
+	return inst
+}
+
+// SetFreezeAuthority sets the "freezeAuthority" parameter.
+// The freeze authority/multisignature of the mint.
+func (inst *InitializeMint2) SetFreezeAuthority(freezeAuthority solana.PublicKey) *InitializeMint2 {
+	inst.FreezeAuthority = &freezeAuthority
+	return inst
+}
+
+// SetMintAccount sets the "mint" account.
+// The mint to initialize.
+func (inst *InitializeMint2) SetMintAccount(mint solana.PublicKey) *InitializeMint2 {
+	inst.AccountMetaSlice[0] = solana.Meta(mint).WRITE()
+	return inst
+}
+
+// GetMintAccount gets the "mint" account.
+// The mint to initialize.
+func (inst *InitializeMint2) GetMintAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+func (inst InitializeMint2) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint8(Instruction_InitializeMint2),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst InitializeMint2) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *InitializeMint2) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Decimals == nil {
+			return errors.New("Decimals parameter is not set")
+		}
+		if inst.MintAuthority == nil {
+			return errors.New("MintAuthority parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Mint is not set")
+		}
+	}
+	return nil
+}
+
+func (obj InitializeMint2) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	// Serialize `Decimals` param:
+	err = encoder.WriteUint8(*obj.Decimals)
+	if err != nil {
+		return err
+	}
+	// Serialize `MintAuthority` param:
+	err = encoder.WriteBytes(obj.MintAuthority[:], false)
+	if err != nil {
+		return err
+	}
+	// Serialize `FreezeAuthority` param:
+	if obj.FreezeAuthority == nil {
+		err = encoder.WriteBool(false)
+		if err != nil {
+			return err
+		}
+	} else {
+		err = encoder.WriteBool(true)
+		if err != nil {
+			return err
+		}
+		err = encoder.WriteBytes(obj.FreezeAuthority[:], false)
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (obj *InitializeMint2) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	// Deserialize `Decimals`:
+	var decimals uint8
+	decimals, err = decoder.ReadUint8()
+	if err != nil {
+		return err
+	}
+	obj.Decimals = &decimals
+	// Deserialize `MintAuthority`:
+	obj.MintAuthority = new(solana.PublicKey)
+	err = decoder.Decode(obj.MintAuthority)
+	if err != nil {
+		return err
+	}
+	// Deserialize `FreezeAuthority`:
+	ok, err := decoder.ReadBool()
+	if err != nil {
+		return err
+	}
+	if ok {
+		obj.FreezeAuthority = new(solana.PublicKey)
+		err = decoder.Decode(obj.FreezeAuthority)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewInitializeMint2Instruction declares a new InitializeMint2 instruction with the provided parameters and accounts.
+func NewInitializeMint2Instruction(
+	// Parameters:
+	decimals uint8,
+	mintAuthority solana.PublicKey,
+	freezeAuthority *solana.PublicKey,
+	// Accounts:
+	mint solana.PublicKey,
+) *InitializeMint2 {
+	inst := NewInitializeMint2InstructionBuilder().
+		SetDecimals(decimals).
+		SetMintAuthority(mintAuthority).
+		SetMintAccount(mint)
+	if freezeAuthority != nil {
+		inst.SetFreezeAuthority(*freezeAuthority)
+	}
+	return inst
+}
@@ -0,0 +1,182 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	solana "github.com/scatkit/pumpdexer/solana"
+)
+
+// Initializes a multisignature account with N provided signers.
+//
+// Multisignature accounts can used in place of any single owner/delegate
+// accounts in any token instruction that require an owner/delegate to be
+// present.  The variant field represents the number of signers (M)
+// required to validate this multisignature account.
+//
+// The `InitializeMultisig` instruction requires no signers and MUST be
+// included within the same Transaction as the system program's
+// `CreateAccount` instruction that creates the account being initialized.
+// Otherwise another party can acquire ownership of the uninitialized
+// account.
+type InitializeMultisig struct {
+	// The number of signers (M) required to validate this multisignature account.
+	M *uint8
+
+	// [0] = [WRITE] account
+	// ··········· The multisignature account to initialize.
+	//
+	// [1] = [] $(SysVarRentPubkey)
+	// ··········· Rent sysvar.
+	//
+	// [2...] = [] signers
+	// ··········· The signer accounts, must equal to N where 1 <= N <= 11.
+	Accounts solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+	Signers  solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+func (obj *InitializeMultisig) SetAccounts(accounts []*solana.AccountMeta) error {
+	obj.Accounts, obj.Signers = solana.AccountMetaSlice(accounts).SplitFrom(2)
+	return nil
+}
+
+func (slice InitializeMultisig) GetAccounts() (accounts []*solana.AccountMeta) {
+	accounts = append(accounts, slice.Accounts...)
+	accounts = append(accounts, slice.Signers...)
+	return
+}
+
+// NewInitializeMultisigInstructionBuilder creates a new `InitializeMultisig` instruction builder.
+func NewInitializeMultisigInstructionBuilder() *InitializeMultisig {
+	nd := &InitializeMultisig{
+		Accounts: make(solana.AccountMetaSlice, 2),
+		Signers:  make(solana.AccountMetaSlice, 0),
+	}
+	nd.Accounts[1] = solana.Meta(solana.SysVarRentPubkey)
+	return nd
+}
+
+// SetM sets the "m" parameter.
+// The number of signers (M) required to validate this multisignature account.
+func (inst *InitializeMultisig) SetM(m uint8) *InitializeMultisig {
+	inst.M = &m
+	return inst
+}
+
+// SetAccount sets the "account" account.
+// The multisignature account to initialize.
+func (inst *InitializeMultisig) SetAccount(account solana.PublicKey) *InitializeMultisig {
+	inst.Accounts[0] = solana.Meta(account).WRITE()
+	return inst
+}
+
+// GetAccount gets the "account" account.
+// The multisignature account to initialize.
+func (inst *InitializeMultisig) GetAccount() *solana.AccountMeta {
+	return inst.Accounts[0]
+}
+
+// SetSysVarRentPubkeyAccount sets the "$(SysVarRentPubkey)" account.
+// Rent sysvar.
+func (inst *InitializeMultisig) SetSysVarRentPubkeyAccount(SysVarRentPubkey solana.PublicKey) *InitializeMultisig {
+	inst.Accounts[1] = solana.Meta(SysVarRentPubkey)
+	return inst
+}
+
+// GetSysVarRentPubkeyAccount gets the "$(SysVarRentPubkey)" account.
+// Rent sysvar.
+func (inst *InitializeMultisig) GetSysVarRentPubkeyAccount() *solana.AccountMeta {
+	return inst.Accounts[1]
+}
+
+// AddSigner adds a signer account of the multisignature.
+func (inst *InitializeMultisig) AddSigner(signer solana.PublicKey) *InitializeMultisig {
+	inst.Signers = append(inst.Signers, solana.Meta(signer))
+	return inst
+}
+
+func (inst InitializeMultisig) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint8(Instruction_InitializeMultisig),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst InitializeMultisig) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *InitializeMultisig) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.M == nil {
+			return errors.New("M parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.Accounts[0] == nil {
+			return errors.New("accounts.Account is not set")
+		}
+		if inst.Accounts[1] == nil {
+			return errors.New("accounts.SysVarRentPubkey is not set")
+		}
+		if len(inst.Signers) == 0 {
+			return errors.New("accounts.Signers is not set")
+		}
+		if len(inst.Signers) > MAX_SIGNERS {
+			return fmt.Errorf("too many signers; got %v, but max is 11", len(inst.Signers))
+		}
+		if inst.M != nil && int(*inst.M) > len(inst.Signers) {
+			return fmt.Errorf("M is %v, but only %v signers are set", *inst.M, len(inst.Signers))
+		}
+	}
+	return nil
+}
+
+func (obj InitializeMultisig) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	// Serialize `M` param:
+	err = encoder.WriteUint8(*obj.M)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (obj *InitializeMultisig) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	// Deserialize `M`:
+	var m uint8
+	m, err = decoder.ReadUint8()
+	if err != nil {
+		return err
+	}
+	obj.M = &m
+	return nil
+}
+
+// NewInitializeMultisigInstruction declares a new InitializeMultisig instruction with the provided parameters and accounts.
+func NewInitializeMultisigInstruction(
+	// Parameters:
+	m uint8,
+	// Accounts:
+	account solana.PublicKey,
+	SysVarRentPubkey solana.PublicKey,
+	signers []solana.PublicKey,
+) *InitializeMultisig {
+	inst := NewInitializeMultisigInstructionBuilder().
+		SetM(m).
+		SetAccount(account).
+		SetSysVarRentPubkeyAccount(SysVarRentPubkey)
+	for _, signer := range signers {
+		inst.AddSigner(signer)
+	}
+	return inst
+}
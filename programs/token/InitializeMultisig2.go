@@ -0,0 +1,149 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	solana "github.com/scatkit/pumpdexer/solana"
+)
+
+// Like InitializeMultisig, but does not require the Rent sysvar to be provided.
+type InitializeMultisig2 struct {
+	// The number of signers (M) required to validate this multisignature account.
+	M *uint8
+
+	// [0] = [WRITE] account
+	// ··········· The multisignature account to initialize.
+	//
+	// [1...] = [] signers
+	// ··········· The signer accounts, must equal to N where 1 <= N <= 11.
+	Accounts solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+	Signers  solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+func (obj *InitializeMultisig2) SetAccounts(accounts []*solana.AccountMeta) error {
+	obj.Accounts, obj.Signers = solana.AccountMetaSlice(accounts).SplitFrom(1)
+	return nil
+}
+
+func (slice InitializeMultisig2) GetAccounts() (accounts []*solana.AccountMeta) {
+	accounts = append(accounts, slice.Accounts...)
+	accounts = append(accounts, slice.Signers...)
+	return
+}
+
+// NewInitializeMultisig2InstructionBuilder creates a new `InitializeMultisig2` instruction builder.
+func NewInitializeMultisig2InstructionBuilder() *InitializeMultisig2 {
+	nd := &InitializeMultisig2{
+		Accounts: make(solana.AccountMetaSlice, 1),
+		Signers:  make(solana.AccountMetaSlice, 0),
+	}
+	return nd
+}
+
+// SetM sets the "m" parameter.
+// The number of signers (M) required to validate this multisignature account.
+func (inst *InitializeMultisig2) SetM(m uint8) *InitializeMultisig2 {
+	inst.M = &m
+	return inst
+}
+
+// SetAccount sets the "account" account.
+// The multisignature account to initialize.
+func (inst *InitializeMultisig2) SetAccount(account solana.PublicKey) *InitializeMultisig2 {
+	inst.Accounts[0] = solana.Meta(account).WRITE()
+	return inst
+}
+
+// GetAccount gets the "account" account.
+// The multisignature account to initialize.
+func (inst *InitializeMultisig2) GetAccount() *solana.AccountMeta {
+	return inst.Accounts[0]
+}
+
+// AddSigner adds a signer account of the multisignature.
+func (inst *InitializeMultisig2) AddSigner(signer solana.PublicKey) *InitializeMultisig2 {
+	inst.Signers = append(inst.Signers, solana.Meta(signer))
+	return inst
+}
+
+func (inst InitializeMultisig2) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint8(Instruction_InitializeMultisig2),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst InitializeMultisig2) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *InitializeMultisig2) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.M == nil {
+			return errors.New("M parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.Accounts[0] == nil {
+			return errors.New("accounts.Account is not set")
+		}
+		if len(inst.Signers) == 0 {
+			return errors.New("accounts.Signers is not set")
+		}
+		if len(inst.Signers) > MAX_SIGNERS {
+			return fmt.Errorf("too many signers; got %v, but max is 11", len(inst.Signers))
+		}
+		if inst.M != nil && int(*inst.M) > len(inst.Signers) {
+			return fmt.Errorf("M is %v, but only %v signers are set", *inst.M, len(inst.Signers))
+		}
+	}
+	return nil
+}
+
+func (obj InitializeMultisig2) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	// Serialize `M` param:
+	err = encoder.WriteUint8(*obj.M)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (obj *InitializeMultisig2) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	// Deserialize `M`:
+	var m uint8
+	m, err = decoder.ReadUint8()
+	if err != nil {
+		return err
+	}
+	obj.M = &m
+	return nil
+}
+
+// NewInitializeMultisig2Instruction declares a new InitializeMultisig2 instruction with the provided parameters and accounts.
+func NewInitializeMultisig2Instruction(
+	// Parameters:
+	m uint8,
+	// Accounts:
+	account solana.PublicKey,
+	signers []solana.PublicKey,
+) *InitializeMultisig2 {
+	inst := NewInitializeMultisig2InstructionBuilder().
+		SetM(m).
+		SetAccount(account)
+	for _, signer := range signers {
+		inst.AddSigner(signer)
+	}
+	return inst
+}
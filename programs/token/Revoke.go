@@ -0,0 +1,131 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	solana "github.com/scatkit/pumpdexer/solana"
+)
+
+// Revokes the delegate's authority.
+type Revoke struct {
+	// [0] = [WRITE] source
+	// ··········· The source account.
+	//
+	// [1] = [] owner
+	// ··········· The source account owner.
+	//
+	// [2...] = [SIGNER] signers
+	// ··········· M signer accounts.
+	Accounts solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+	Signers  solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+func (obj *Revoke) SetAccounts(accounts []*solana.AccountMeta) error {
+	obj.Accounts, obj.Signers = solana.AccountMetaSlice(accounts).SplitFrom(2)
+	return nil
+}
+
+func (slice Revoke) GetAccounts() (accounts []*solana.AccountMeta) {
+	accounts = append(accounts, slice.Accounts...)
+	accounts = append(accounts, slice.Signers...)
+	return
+}
+
+// NewRevokeInstructionBuilder creates a new `Revoke` instruction builder.
+func NewRevokeInstructionBuilder() *Revoke {
+	nd := &Revoke{
+		Accounts: make(solana.AccountMetaSlice, 2),
+		Signers:  make(solana.AccountMetaSlice, 0),
+	}
+	return nd
+}
+
+// SetSourceAccount sets the "source" account.
+// The source account.
+func (inst *Revoke) SetSourceAccount(source solana.PublicKey) *Revoke {
+	inst.Accounts[0] = solana.Meta(source).WRITE()
+	return inst
+}
+
+// GetSourceAccount gets the "source" account.
+// The source account.
+func (inst *Revoke) GetSourceAccount() *solana.AccountMeta {
+	return inst.Accounts[0]
+}
+
+// SetOwnerAccount sets the "owner" account.
+// The source account owner.
+func (inst *Revoke) SetOwnerAccount(owner solana.PublicKey, multisigSigners ...solana.PublicKey) *Revoke {
+	inst.Accounts[1] = solana.Meta(owner)
+	if len(multisigSigners) == 0 {
+		inst.Accounts[1].SIGNER()
+	}
+	for _, signer := range multisigSigners {
+		inst.Signers = append(inst.Signers, solana.Meta(signer).SIGNER())
+	}
+	return inst
+}
+
+// GetOwnerAccount gets the "owner" account.
+// The source account owner.
+func (inst *Revoke) GetOwnerAccount() *solana.AccountMeta {
+	return inst.Accounts[1]
+}
+
+func (inst Revoke) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint8(Instruction_Revoke),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst Revoke) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *Revoke) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if inst.Accounts[0] == nil {
+			return errors.New("accounts.Source is not set")
+		}
+		if inst.Accounts[1] == nil {
+			return errors.New("accounts.Owner is not set")
+		}
+		if !inst.Accounts[1].IsSigner && len(inst.Signers) == 0 {
+			return errors.New("accounts.Signers is not set")
+		}
+		if len(inst.Signers) > MAX_SIGNERS {
+			return fmt.Errorf("too many signers; got %v, but max is 11", len(inst.Signers))
+		}
+	}
+	return nil
+}
+
+func (obj Revoke) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	return nil
+}
+
+func (obj *Revoke) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	return nil
+}
+
+// NewRevokeInstruction declares a new Revoke instruction with the provided parameters and accounts.
+func NewRevokeInstruction(
+	// Accounts:
+	source solana.PublicKey,
+	owner solana.PublicKey,
+	multisigSigners []solana.PublicKey,
+) *Revoke {
+	return NewRevokeInstructionBuilder().
+		SetSourceAccount(source).
+		SetOwnerAccount(owner, multisigSigners...)
+}
@@ -0,0 +1,223 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	solana "github.com/scatkit/pumpdexer/solana"
+)
+
+// AuthorityType specifies the type of authority SetAuthority updates.
+type AuthorityType uint8
+
+const (
+	// Authority to mint new tokens
+	AuthorityMintTokens AuthorityType = iota
+
+	// Authority to freeze any account associated with the Mint
+	AuthorityFreezeAccount
+
+	// Owner of a given token account
+	AuthorityAccountOwner
+
+	// Authority to close a token account
+	AuthorityCloseAccount
+)
+
+// Sets a new authority of a mint or account.
+type SetAuthority struct {
+	// The type of authority to update.
+	AuthorityType *AuthorityType
+
+	// The new authority.
+	NewAuthority *solana.PublicKey
+
+	// [0] = [WRITE] subject
+	// ··········· The mint or account to change the authority of.
+	//
+	// [1] = [] authority
+	// ··········· The current authority of the mint or account.
+	//
+	// [2...] = [SIGNER] signers
+	// ··········· M signer accounts.
+	Accounts solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+	Signers  solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+func (obj *SetAuthority) SetAccounts(accounts []*solana.AccountMeta) error {
+	obj.Accounts, obj.Signers = solana.AccountMetaSlice(accounts).SplitFrom(2)
+	return nil
+}
+
+func (slice SetAuthority) GetAccounts() (accounts []*solana.AccountMeta) {
+	accounts = append(accounts, slice.Accounts...)
+	accounts = append(accounts, slice.Signers...)
+	return
+}
+
+// NewSetAuthorityInstructionBuilder creates a new `SetAuthority` instruction builder.
+func NewSetAuthorityInstructionBuilder() *SetAuthority {
+	nd := &SetAuthority{
+		Accounts: make(solana.AccountMetaSlice, 2),
+		Signers:  make(solana.AccountMetaSlice, 0),
+	}
+	return nd
+}
+
+// SetAuthorityType sets the "authorityType" parameter.
+// The type of authority to update.
+func (inst *SetAuthority) SetAuthorityType(authorityType AuthorityType) *SetAuthority {
+	inst.AuthorityType = &authorityType
+	return inst
+}
+
+// SetNewAuthority sets the "newAuthority" parameter.
+// The new authority.
+func (inst *SetAuthority) SetNewAuthority(newAuthority solana.PublicKey) *SetAuthority {
+	inst.NewAuthority = &newAuthority
+	return inst
+}
+
+// SetSubjectAccount sets the "subject" account.
+// The mint or account to change the authority of.
+func (inst *SetAuthority) SetSubjectAccount(subject solana.PublicKey) *SetAuthority {
+	inst.Accounts[0] = solana.Meta(subject).WRITE()
+	return inst
+}
+
+// GetSubjectAccount gets the "subject" account.
+// The mint or account to change the authority of.
+func (inst *SetAuthority) GetSubjectAccount() *solana.AccountMeta {
+	return inst.Accounts[0]
+}
+
+// SetAuthorityAccount sets the "authority" account.
+// The current authority of the mint or account.
+func (inst *SetAuthority) SetAuthorityAccount(authority solana.PublicKey, multisigSigners ...solana.PublicKey) *SetAuthority {
+	inst.Accounts[1] = solana.Meta(authority)
+	if len(multisigSigners) == 0 {
+		inst.Accounts[1].SIGNER()
+	}
+	for _, signer := range multisigSigners {
+		inst.Signers = append(inst.Signers, solana.Meta(signer).SIGNER())
+	}
+	return inst
+}
+
+// GetAuthorityAccount gets the "authority" account.
+// The current authority of the mint or account.
+func (inst *SetAuthority) GetAuthorityAccount() *solana.AccountMeta {
+	return inst.Accounts[1]
+}
+
+func (inst SetAuthority) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint8(Instruction_SetAuthority),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst SetAuthority) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *SetAuthority) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.AuthorityType == nil {
+			return errors.New("AuthorityType parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.Accounts[0] == nil {
+			return errors.New("accounts.Subject is not set")
+		}
+		if inst.Accounts[1] == nil {
+			return errors.New("accounts.Authority is not set")
+		}
+		if !inst.Accounts[1].IsSigner && len(inst.Signers) == 0 {
+			return errors.New("accounts.Signers is not set")
+		}
+		if len(inst.Signers) > MAX_SIGNERS {
+			return fmt.Errorf("too many signers; got %v, but max is 11", len(inst.Signers))
+		}
+	}
+	return nil
+}
+
+func (obj SetAuthority) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	// Serialize `AuthorityType` param:
+	err = encoder.WriteUint8(uint8(*obj.AuthorityType))
+	if err != nil {
+		return err
+	}
+	// Serialize `NewAuthority` param:
+	if obj.NewAuthority == nil {
+		err = encoder.WriteBool(false)
+		if err != nil {
+			return err
+		}
+	} else {
+		err = encoder.WriteBool(true)
+		if err != nil {
+			return err
+		}
+		err = encoder.WriteBytes(obj.NewAuthority[:], false)
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (obj *SetAuthority) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	// Deserialize `AuthorityType`:
+	var authorityType uint8
+	authorityType, err = decoder.ReadUint8()
+	if err != nil {
+		return err
+	}
+	obj.AuthorityType = (*AuthorityType)(&authorityType)
+	// Deserialize `NewAuthority`:
+	ok, err := decoder.ReadBool()
+	if err != nil {
+		return err
+	}
+	if ok {
+		obj.NewAuthority = new(solana.PublicKey)
+		err = decoder.Decode(obj.NewAuthority)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewSetAuthorityInstruction declares a new SetAuthority instruction with the provided parameters and accounts.
+func NewSetAuthorityInstruction(
+	// Parameters:
+	authorityType AuthorityType,
+	newAuthority *solana.PublicKey,
+	// Accounts:
+	subject solana.PublicKey,
+	authority solana.PublicKey,
+	multisigSigners []solana.PublicKey,
+) *SetAuthority {
+	inst := NewSetAuthorityInstructionBuilder().
+		SetAuthorityType(authorityType).
+		SetSubjectAccount(subject).
+		SetAuthorityAccount(authority, multisigSigners...)
+	if newAuthority != nil {
+		inst.SetNewAuthority(*newAuthority)
+	}
+	return inst
+}
@@ -0,0 +1,187 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	solana "github.com/scatkit/pumpdexer/solana"
+)
+
+// Transfers tokens from one account to another either directly or via a
+// delegate.  If this account is associated with the native mint then equal
+// amounts of SOL and Tokens will be transferred to the destination
+// account.
+type Transfer struct {
+	// The amount of tokens to transfer.
+	Amount *uint64
+
+	// [0] = [WRITE] source
+	// ··········· The source account.
+	//
+	// [1] = [WRITE] destination
+	// ··········· The destination account.
+	//
+	// [2] = [] owner
+	// ··········· The source account's owner/delegate.
+	//
+	// [3...] = [SIGNER] signers
+	// ··········· M signer accounts.
+	Accounts solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+	Signers  solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+func (obj *Transfer) SetAccounts(accounts []*solana.AccountMeta) error {
+	obj.Accounts, obj.Signers = solana.AccountMetaSlice(accounts).SplitFrom(3)
+	return nil
+}
+
+func (slice Transfer) GetAccounts() (accounts []*solana.AccountMeta) {
+	accounts = append(accounts, slice.Accounts...)
+	accounts = append(accounts, slice.Signers...)
+	return
+}
+
+// NewTransferInstructionBuilder creates a new `Transfer` instruction builder.
+func NewTransferInstructionBuilder() *Transfer {
+	nd := &Transfer{
+		Accounts: make(solana.AccountMetaSlice, 3),
+		Signers:  make(solana.AccountMetaSlice, 0),
+	}
+	return nd
+}
+
+// SetAmount sets the "amount" parameter.
+// The amount of tokens to transfer.
+func (inst *Transfer) SetAmount(amount uint64) *Transfer {
+	inst.Amount = &amount
+	return inst
+}
+
+// SetSourceAccount sets the "source" account.
+// The source account.
+func (inst *Transfer) SetSourceAccount(source solana.PublicKey) *Transfer {
+	inst.Accounts[0] = solana.Meta(source).WRITE()
+	return inst
+}
+
+// GetSourceAccount gets the "source" account.
+// The source account.
+func (inst *Transfer) GetSourceAccount() *solana.AccountMeta {
+	return inst.Accounts[0]
+}
+
+// SetDestinationAccount sets the "destination" account.
+// The destination account.
+func (inst *Transfer) SetDestinationAccount(destination solana.PublicKey) *Transfer {
+	inst.Accounts[1] = solana.Meta(destination).WRITE()
+	return inst
+}
+
+// GetDestinationAccount gets the "destination" account.
+// The destination account.
+func (inst *Transfer) GetDestinationAccount() *solana.AccountMeta {
+	return inst.Accounts[1]
+}
+
+// SetOwnerAccount sets the "owner" account.
+// The source account's owner/delegate.
+func (inst *Transfer) SetOwnerAccount(owner solana.PublicKey, multisigSigners ...solana.PublicKey) *Transfer {
+	inst.Accounts[2] = solana.Meta(owner)
+	if len(multisigSigners) == 0 {
+		inst.Accounts[2].SIGNER()
+	}
+	for _, signer := range multisigSigners {
+		inst.Signers = append(inst.Signers, solana.Meta(signer).SIGNER())
+	}
+	return inst
+}
+
+// GetOwnerAccount gets the "owner" account.
+// The source account's owner/delegate.
+func (inst *Transfer) GetOwnerAccount() *solana.AccountMeta {
+	return inst.Accounts[2]
+}
+
+func (inst Transfer) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint8(Instruction_Transfer),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst Transfer) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *Transfer) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Amount == nil {
+			return errors.New("Amount parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.Accounts[0] == nil {
+			return errors.New("accounts.Source is not set")
+		}
+		if inst.Accounts[1] == nil {
+			return errors.New("accounts.Destination is not set")
+		}
+		if inst.Accounts[2] == nil {
+			return errors.New("accounts.Owner is not set")
+		}
+		if !inst.Accounts[2].IsSigner && len(inst.Signers) == 0 {
+			return errors.New("accounts.Signers is not set")
+		}
+		if len(inst.Signers) > MAX_SIGNERS {
+			return fmt.Errorf("too many signers; got %v, but max is 11", len(inst.Signers))
+		}
+	}
+	return nil
+}
+
+func (obj Transfer) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	// Serialize `Amount` param:
+	err = encoder.WriteUint64(*obj.Amount, bin.LE)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (obj *Transfer) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	// Deserialize `Amount`:
+	var amount uint64
+	amount, err = decoder.ReadUint64(bin.LE)
+	if err != nil {
+		return err
+	}
+	obj.Amount = &amount
+	return nil
+}
+
+// NewTransferInstruction declares a new Transfer instruction with the provided parameters and accounts.
+func NewTransferInstruction(
+	// Parameters:
+	amount uint64,
+	// Accounts:
+	source solana.PublicKey,
+	destination solana.PublicKey,
+	owner solana.PublicKey,
+	multisigSigners []solana.PublicKey,
+) *Transfer {
+	return NewTransferInstructionBuilder().
+		SetAmount(amount).
+		SetSourceAccount(source).
+		SetDestinationAccount(destination).
+		SetOwnerAccount(owner, multisigSigners...)
+}
@@ -0,0 +1,355 @@
+package token
+
+import (
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	solana "github.com/scatkit/pumpdexer/solana"
+)
+
+// The serialized sizes of the token program states.
+const (
+	MINT_SIZE     = 82
+	ACCOUNT_SIZE  = 165
+	MULTISIG_SIZE = 355
+)
+
+// Token-2022 states longer than an account carry their type in the byte right after
+// the (padded) base state, followed by the extensions.
+const (
+	accountTypeMint    = 1
+	accountTypeAccount = 2
+)
+
+// checkStateSize tells whether data holds a state of the given size: either exactly that
+// size, or a Token-2022 state with extensions whose account type matches.
+func checkStateSize(data []byte, name string, size int, accountType byte) error {
+	if len(data) == size {
+		return nil
+	}
+	if len(data) <= ACCOUNT_SIZE || len(data) == MULTISIG_SIZE {
+		return fmt.Errorf("invalid %s data size: %d bytes, expected %d", name, len(data), size)
+	}
+	if got := data[ACCOUNT_SIZE]; got != accountType {
+		return fmt.Errorf("invalid account type %d, expected %d", got, accountType)
+	}
+	return nil
+}
+
+// Mint data.
+type Mint struct {
+	// Optional authority used to mint new tokens. The mint authority may only be provided during
+	// mint creation. If no mint authority is present then the mint has a fixed supply and no
+	// further tokens may be minted.
+	MintAuthority *solana.PublicKey
+
+	// Total supply of tokens.
+	Supply uint64
+
+	// Number of base 10 digits to the right of the decimal place.
+	Decimals uint8
+
+	// Is `true` if this structure has been initialized
+	IsInitialized bool
+
+	// Optional authority to freeze token accounts.
+	FreezeAuthority *solana.PublicKey
+}
+
+// DecodeMint decodes the given account bytes into a Mint.
+// The data must be exactly MINT_SIZE bytes, or a Token-2022 mint whose account type
+// says so; its extensions are ignored.
+func DecodeMint(data []byte) (*Mint, error) {
+	if err := checkStateSize(data, "mint", MINT_SIZE, accountTypeMint); err != nil {
+		return nil, err
+	}
+	var mint Mint
+	if err := mint.UnmarshalWithDecoder(bin.NewBinDecoder(data)); err != nil {
+		return nil, err
+	}
+	return &mint, nil
+}
+
+func (mint *Mint) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	if mint.MintAuthority, err = readCOptionPublicKey(dec); err != nil {
+		return fmt.Errorf("failed to decode MintAuthority: %w", err)
+	}
+	if mint.Supply, err = dec.ReadUint64(bin.LE); err != nil {
+		return fmt.Errorf("failed to decode Supply: %w", err)
+	}
+	if mint.Decimals, err = dec.ReadUint8(); err != nil {
+		return fmt.Errorf("failed to decode Decimals: %w", err)
+	}
+	if mint.IsInitialized, err = dec.ReadBool(); err != nil {
+		return fmt.Errorf("failed to decode IsInitialized: %w", err)
+	}
+	if mint.FreezeAuthority, err = readCOptionPublicKey(dec); err != nil {
+		return fmt.Errorf("failed to decode FreezeAuthority: %w", err)
+	}
+	return nil
+}
+
+func (mint Mint) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = writeCOptionPublicKey(encoder, mint.MintAuthority); err != nil {
+		return err
+	}
+	if err = encoder.WriteUint64(mint.Supply, bin.LE); err != nil {
+		return err
+	}
+	if err = encoder.WriteUint8(mint.Decimals); err != nil {
+		return err
+	}
+	if err = encoder.WriteBool(mint.IsInitialized); err != nil {
+		return err
+	}
+	return writeCOptionPublicKey(encoder, mint.FreezeAuthority)
+}
+
+// AccountState is the state of a token account.
+type AccountState uint8
+
+const (
+	// Account is not yet initialized
+	Uninitialized AccountState = iota
+
+	// Account is initialized; the account owner and/or delegate may perform permitted operations
+	// on this account
+	Initialized
+
+	// Account has been frozen by the mint freeze authority. Neither the account owner nor
+	// the delegate are able to perform operations on this account.
+	Frozen
+)
+
+func (state AccountState) String() string {
+	switch state {
+	case Uninitialized:
+		return "Uninitialized"
+	case Initialized:
+		return "Initialized"
+	case Frozen:
+		return "Frozen"
+	default:
+		return fmt.Sprintf("AccountState(%d)", uint8(state))
+	}
+}
+
+// Account data.
+type Account struct {
+	// The mint associated with this account
+	Mint solana.PublicKey
+
+	// The owner of this account.
+	Owner solana.PublicKey
+
+	// The amount of tokens this account holds.
+	Amount uint64
+
+	// If `delegate` is `Some` then `delegated_amount` represents
+	// the amount authorized by the delegate
+	Delegate *solana.PublicKey
+
+	// The account's state
+	State AccountState
+
+	// If is_native.is_some, this is a native token, and the value logs the rent-exempt reserve. An
+	// Account is required to be rent-exempt, so the value is used by the Processor to ensure that
+	// wrapped SOL accounts do not drop below this threshold.
+	IsNative *uint64
+
+	// The amount delegated
+	DelegatedAmount uint64
+
+	// Optional authority to close the account.
+	CloseAuthority *solana.PublicKey
+}
+
+// DecodeAccount decodes the given account bytes into an Account.
+// The data must be exactly ACCOUNT_SIZE bytes, or a Token-2022 account whose account type
+// says so; its extensions are ignored.
+func DecodeAccount(data []byte) (*Account, error) {
+	if err := checkStateSize(data, "token account", ACCOUNT_SIZE, accountTypeAccount); err != nil {
+		return nil, err
+	}
+	var account Account
+	if err := account.UnmarshalWithDecoder(bin.NewBinDecoder(data)); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (account *Account) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	if _, err = dec.Read(account.Mint[:]); err != nil {
+		return fmt.Errorf("failed to decode Mint: %w", err)
+	}
+	if _, err = dec.Read(account.Owner[:]); err != nil {
+		return fmt.Errorf("failed to decode Owner: %w", err)
+	}
+	if account.Amount, err = dec.ReadUint64(bin.LE); err != nil {
+		return fmt.Errorf("failed to decode Amount: %w", err)
+	}
+	if account.Delegate, err = readCOptionPublicKey(dec); err != nil {
+		return fmt.Errorf("failed to decode Delegate: %w", err)
+	}
+	state, err := dec.ReadUint8()
+	if err != nil {
+		return fmt.Errorf("failed to decode State: %w", err)
+	}
+	account.State = AccountState(state)
+	{
+		has, err := dec.ReadCOption()
+		if err != nil {
+			return fmt.Errorf("failed to decode IsNative option: %w", err)
+		}
+		reserve, err := dec.ReadUint64(bin.LE)
+		if err != nil {
+			return fmt.Errorf("failed to decode IsNative: %w", err)
+		}
+		if has {
+			account.IsNative = &reserve
+		}
+	}
+	if account.DelegatedAmount, err = dec.ReadUint64(bin.LE); err != nil {
+		return fmt.Errorf("failed to decode DelegatedAmount: %w", err)
+	}
+	if account.CloseAuthority, err = readCOptionPublicKey(dec); err != nil {
+		return fmt.Errorf("failed to decode CloseAuthority: %w", err)
+	}
+	return nil
+}
+
+func (account Account) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = encoder.WriteBytes(account.Mint[:], false); err != nil {
+		return err
+	}
+	if err = encoder.WriteBytes(account.Owner[:], false); err != nil {
+		return err
+	}
+	if err = encoder.WriteUint64(account.Amount, bin.LE); err != nil {
+		return err
+	}
+	if err = writeCOptionPublicKey(encoder, account.Delegate); err != nil {
+		return err
+	}
+	if err = encoder.WriteUint8(uint8(account.State)); err != nil {
+		return err
+	}
+	{
+		var reserve uint64
+		if account.IsNative != nil {
+			reserve = *account.IsNative
+		}
+		if err = encoder.WriteCOption(account.IsNative != nil); err != nil {
+			return err
+		}
+		if err = encoder.WriteUint64(reserve, bin.LE); err != nil {
+			return err
+		}
+	}
+	if err = encoder.WriteUint64(account.DelegatedAmount, bin.LE); err != nil {
+		return err
+	}
+	return writeCOptionPublicKey(encoder, account.CloseAuthority)
+}
+
+// IsFrozen returns true if the mint freeze authority froze the account.
+func (account *Account) IsFrozen() bool {
+	return account.State == Frozen
+}
+
+// Multisignature data.
+type Multisig struct {
+	// Number of signers required
+	M uint8
+
+	// Number of valid signers
+	N uint8
+
+	// Is `true` if this structure has been initialized
+	IsInitialized bool
+
+	// Signer public keys
+	Signers [MAX_SIGNERS]solana.PublicKey
+}
+
+// DecodeMultisig decodes the given account bytes into a Multisig.
+// The data must be exactly MULTISIG_SIZE bytes: multisigs have no extensions.
+func DecodeMultisig(data []byte) (*Multisig, error) {
+	if len(data) != MULTISIG_SIZE {
+		return nil, fmt.Errorf("invalid multisig data size: %d bytes, expected %d", len(data), MULTISIG_SIZE)
+	}
+	var multisig Multisig
+	if err := multisig.UnmarshalWithDecoder(bin.NewBinDecoder(data)); err != nil {
+		return nil, err
+	}
+	return &multisig, nil
+}
+
+func (multisig *Multisig) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	if multisig.M, err = dec.ReadUint8(); err != nil {
+		return fmt.Errorf("failed to decode M: %w", err)
+	}
+	if multisig.N, err = dec.ReadUint8(); err != nil {
+		return fmt.Errorf("failed to decode N: %w", err)
+	}
+	if multisig.IsInitialized, err = dec.ReadBool(); err != nil {
+		return fmt.Errorf("failed to decode IsInitialized: %w", err)
+	}
+	for i := range multisig.Signers {
+		if _, err = dec.Read(multisig.Signers[i][:]); err != nil {
+			return fmt.Errorf("failed to decode Signers[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (multisig Multisig) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = encoder.WriteUint8(multisig.M); err != nil {
+		return err
+	}
+	if err = encoder.WriteUint8(multisig.N); err != nil {
+		return err
+	}
+	if err = encoder.WriteBool(multisig.IsInitialized); err != nil {
+		return err
+	}
+	for _, signer := range multisig.Signers {
+		if err = encoder.WriteBytes(signer[:], false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidSigners returns the N signers of the multisig.
+func (multisig *Multisig) ValidSigners() []solana.PublicKey {
+	n := min(int(multisig.N), MAX_SIGNERS)
+	return append([]solana.PublicKey(nil), multisig.Signers[:n]...)
+}
+
+// A COption<Pubkey> always takes 36 bytes: a u32 tag then the key (zeroed when None).
+func readCOptionPublicKey(dec *bin.Decoder) (*solana.PublicKey, error) {
+	has, err := dec.ReadCOption()
+	if err != nil {
+		return nil, err
+	}
+	var key solana.PublicKey
+	if _, err := dec.Read(key[:]); err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, nil
+	}
+	return &key, nil
+}
+
+func writeCOptionPublicKey(encoder *bin.Encoder, key *solana.PublicKey) error {
+	if err := encoder.WriteCOption(key != nil); err != nil {
+		return err
+	}
+	var value solana.PublicKey
+	if key != nil {
+		value = *key
+	}
+	return encoder.WriteBytes(value[:], false)
+}
@@ -0,0 +1,169 @@
+package token
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/rpc"
+	"github.com/scatkit/pumpdexer/solana"
+	"github.com/scatkit/pumpdexer/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeState(t *testing.T, state interface{}) []byte {
+	buf := new(bytes.Buffer)
+	require.NoError(t, encodeT(state, buf))
+	return buf.Bytes()
+}
+
+func TestDecodeMint(t *testing.T) {
+	authority := solana.NewWallet().PublicKey()
+
+	// renounced mint authority, freeze authority still set
+	data := make([]byte, MINT_SIZE)
+	binary.LittleEndian.PutUint64(data[36:], 1_000_000_000)
+	data[44] = 6
+	data[45] = 1
+	binary.LittleEndian.PutUint32(data[46:], 1)
+	copy(data[50:], authority[:])
+
+	mint, err := DecodeMint(data)
+	require.NoError(t, err)
+	require.Nil(t, mint.MintAuthority)
+	require.Equal(t, uint64(1_000_000_000), mint.Supply)
+	require.Equal(t, uint8(6), mint.Decimals)
+	require.True(t, mint.IsInitialized)
+	require.Equal(t, &authority, mint.FreezeAuthority)
+	require.Equal(t, data, encodeState(t, *mint))
+
+	// Token-2022 mints carry their account type and extensions after the padded base layout
+	data2022 := append(append([]byte{}, data...), make([]byte, 100)...)
+	data2022[ACCOUNT_SIZE] = accountTypeMint
+	mint2022, err := DecodeMint(data2022)
+	require.NoError(t, err)
+	require.Equal(t, mint, mint2022)
+
+	data2022[ACCOUNT_SIZE] = accountTypeAccount
+	_, err = DecodeMint(data2022)
+	require.ErrorContains(t, err, "invalid account type")
+
+	_, err = DecodeMint(data[:MINT_SIZE-1])
+	require.Error(t, err)
+	_, err = DecodeMint(append(append([]byte{}, data...), 0))
+	require.ErrorContains(t, err, "invalid mint data size")
+	_, err = DecodeMint(make([]byte, ACCOUNT_SIZE))
+	require.ErrorContains(t, err, "invalid mint data size")
+
+	binary.LittleEndian.PutUint32(data[0:], 2)
+	_, err = DecodeMint(data)
+	require.Error(t, err, "invalid COption tag")
+}
+
+func TestDecodeAccount(t *testing.T) {
+	reserve := uint64(2039280)
+	delegate := solana.NewWallet().PublicKey()
+	account := Account{
+		Mint:            solana.SolMint,
+		Owner:           solana.NewWallet().PublicKey(),
+		Amount:          42,
+		Delegate:        &delegate,
+		State:           Frozen,
+		IsNative:        &reserve,
+		DelegatedAmount: 7,
+	}
+	data := encodeState(t, account)
+	require.Len(t, data, ACCOUNT_SIZE)
+	require.Equal(t, byte(Frozen), data[108])
+
+	got, err := DecodeAccount(data)
+	require.NoError(t, err)
+	require.Equal(t, &account, got)
+	require.True(t, got.IsFrozen())
+	require.Nil(t, got.CloseAuthority)
+
+	_, err = DecodeAccount(append(append([]byte{}, data...), 0))
+	require.ErrorContains(t, err, "invalid account type 0")
+	_, err = DecodeAccount(append(append([]byte{}, data...), accountTypeAccount))
+	require.NoError(t, err)
+	_, err = DecodeAccount(make([]byte, MULTISIG_SIZE))
+	require.ErrorContains(t, err, "invalid token account data size")
+	_, err = DecodeAccount(data[:MINT_SIZE])
+	require.ErrorContains(t, err, "invalid token account data size")
+}
+
+func TestDecodeMultisig(t *testing.T) {
+	multisig := Multisig{M: 2, N: 3, IsInitialized: true}
+	for i := 0; i < 3; i++ {
+		multisig.Signers[i] = solana.NewWallet().PublicKey()
+	}
+	data := encodeState(t, multisig)
+	require.Len(t, data, MULTISIG_SIZE)
+
+	got, err := DecodeMultisig(data)
+	require.NoError(t, err)
+	require.Equal(t, &multisig, got)
+	require.Equal(t, multisig.Signers[:3], got.ValidSigners())
+
+	_, err = DecodeMultisig(append(append([]byte{}, data...), accountTypeAccount))
+	require.ErrorContains(t, err, "invalid multisig data size")
+	_, err = DecodeMultisig(data[:MULTISIG_SIZE-1])
+	require.ErrorContains(t, err, "invalid multisig data size")
+
+	require.NoError(t, decodeT(new(Multisig), data))
+	require.Error(t, new(Multisig).UnmarshalWithDecoder(bin.NewBinDecoder(data[:10])))
+}
+
+func TestFetchHelpers(t *testing.T) {
+	srv := testutil.NewServer()
+	defer srv.Close()
+	client := rpc.New(srv.URL)
+	ctx := context.Background()
+
+	authority := solana.NewWallet().PublicKey()
+	mintAddress := solana.NewWallet().PublicKey()
+	mint2022Address := solana.NewWallet().PublicKey()
+	accountAddress := solana.NewWallet().PublicKey()
+	walletAddress := solana.NewWallet().PublicKey()
+	missing := solana.NewWallet().PublicKey()
+
+	srv.SetAccount(mintAddress, &testutil.Account{
+		Owner: solana.TokenProgramID,
+		Data:  encodeState(t, Mint{MintAuthority: &authority, Supply: 10, Decimals: 9, IsInitialized: true}),
+	})
+	srv.SetAccount(mint2022Address, &testutil.Account{
+		Owner: solana.Token2022ProgramID,
+		Data:  encodeState(t, Mint{Decimals: 6, IsInitialized: true}),
+	})
+	srv.SetAccount(accountAddress, &testutil.Account{
+		Owner: solana.TokenProgramID,
+		Data:  encodeState(t, Account{Mint: mintAddress, Owner: walletAddress, Amount: 5, State: Initialized}),
+	})
+	srv.SetAccount(walletAddress, &testutil.Account{Owner: solana.SystemProgramID, Lamports: 1})
+
+	mint, err := GetMint(ctx, client, mintAddress)
+	require.NoError(t, err)
+	require.Equal(t, &authority, mint.MintAuthority)
+	require.Nil(t, mint.FreezeAuthority)
+
+	account, err := GetAccount(ctx, client, accountAddress)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), account.Amount)
+	require.Equal(t, walletAddress, account.Owner)
+
+	_, err = GetMint(ctx, client, walletAddress)
+	require.ErrorContains(t, err, "not by a token program")
+
+	mints, err := GetMints(ctx, client, mintAddress, missing, mint2022Address)
+	require.NoError(t, err)
+	require.Len(t, mints, 3)
+	require.Equal(t, uint8(9), mints[0].Decimals)
+	require.Nil(t, mints[1])
+	require.Equal(t, uint8(6), mints[2].Decimals)
+
+	accounts, err := GetAccounts(ctx, client, accountAddress)
+	require.NoError(t, err)
+	require.Equal(t, mintAddress, accounts[0].Mint)
+}
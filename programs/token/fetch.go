@@ -0,0 +1,119 @@
+package token
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/scatkit/pumpdexer/rpc"
+	solana "github.com/scatkit/pumpdexer/solana"
+)
+
+// GetMint fetches and decodes a mint of the Token (or Token-2022) program.
+func GetMint(ctx context.Context, rpcClient *rpc.Client, address solana.PublicKey) (*Mint, error) {
+	data, err := getTokenAccountData(ctx, rpcClient, address)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeMint(data)
+}
+
+// GetAccount fetches and decodes a token account of the Token (or Token-2022) program.
+func GetAccount(ctx context.Context, rpcClient *rpc.Client, address solana.PublicKey) (*Account, error) {
+	data, err := getTokenAccountData(ctx, rpcClient, address)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeAccount(data)
+}
+
+// GetMultisig fetches and decodes a multisig of the Token (or Token-2022) program.
+func GetMultisig(ctx context.Context, rpcClient *rpc.Client, address solana.PublicKey) (*Multisig, error) {
+	data, err := getTokenAccountData(ctx, rpcClient, address)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeMultisig(data)
+}
+
+// GetMints fetches several mints in a single call.
+// The result has one entry per address, nil when the account doesn't exist.
+func GetMints(ctx context.Context, rpcClient *rpc.Client, addresses ...solana.PublicKey) ([]*Mint, error) {
+	datas, err := GetMultipleAccountsData(ctx, rpcClient, addresses)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*Mint, len(addresses))
+	for i, data := range datas {
+		if data == nil {
+			continue
+		}
+		if out[i], err = DecodeMint(data); err != nil {
+			return nil, fmt.Errorf("unable to decode mint %s: %w", addresses[i], err)
+		}
+	}
+	return out, nil
+}
+
+// GetAccounts fetches several token accounts in a single call.
+// The result has one entry per address, nil when the account doesn't exist.
+func GetAccounts(ctx context.Context, rpcClient *rpc.Client, addresses ...solana.PublicKey) ([]*Account, error) {
+	datas, err := GetMultipleAccountsData(ctx, rpcClient, addresses)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*Account, len(addresses))
+	for i, data := range datas {
+		if data == nil {
+			continue
+		}
+		if out[i], err = DecodeAccount(data); err != nil {
+			return nil, fmt.Errorf("unable to decode token account %s: %w", addresses[i], err)
+		}
+	}
+	return out, nil
+}
+
+// IsTokenProgram tells whether an account owner is the Token or the Token-2022 program.
+func IsTokenProgram(owner solana.PublicKey) bool {
+	return owner.Equals(solana.TokenProgramID) || owner.Equals(solana.Token2022ProgramID) || owner.Equals(ProgramID)
+}
+
+func getTokenAccountData(ctx context.Context, rpcClient *rpc.Client, address solana.PublicKey) ([]byte, error) {
+	account, err := rpcClient.GetAccountInfo(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil || account.Value == nil {
+		return nil, fmt.Errorf("account %s not found", address)
+	}
+	if !IsTokenProgram(account.Value.Owner) {
+		return nil, fmt.Errorf("account %s is owned by %s, not by a token program", address, account.Value.Owner)
+	}
+	return account.GetBinary(), nil
+}
+
+// GetMultipleAccountsData fetches several accounts of a token program in a single call,
+// and returns the data of each account, nil for the missing ones.
+func GetMultipleAccountsData(ctx context.Context, rpcClient *rpc.Client, addresses []solana.PublicKey) ([][]byte, error) {
+	if len(addresses) == 0 {
+		return nil, nil
+	}
+	res, err := rpcClient.GetMultipleAccountsWithOpts(ctx, addresses, &rpc.GetMultipleAccountsOpts{Encoding: solana.EncodingBase64})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Value) != len(addresses) {
+		return nil, fmt.Errorf("expected %d accounts, got %d", len(addresses), len(res.Value))
+	}
+	out := make([][]byte, len(addresses))
+	for i, account := range res.Value {
+		if account == nil {
+			continue
+		}
+		if !IsTokenProgram(account.Owner) {
+			return nil, fmt.Errorf("account %s is owned by %s, not by a token program", addresses[i], account.Owner)
+		}
+		out[i] = account.Data.GetBinary()
+	}
+	return out, nil
+}
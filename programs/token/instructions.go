@@ -227,15 +227,74 @@ type Instruction struct {
 	bin.BaseVariant
 }
 
+// The variants are listed in the order of their instruction ID:
+// the position in the list is the type ID.
 var InstructionImplDef = bin.NewVariantDefinition(
 	bin.Uint8TypeIDEncoding,
 	[]bin.VariantType{
+		{
+			"InitializeMint", (*InitializeMint)(nil),
+		},
 		{
 			"InitializeAccount", (*InitializeAccount)(nil),
 		},
-    {
+		{
+			"InitializeMultisig", (*InitializeMultisig)(nil),
+		},
+		{
+			"Transfer", (*Transfer)(nil),
+		},
+		{
+			"Approve", (*Approve)(nil),
+		},
+		{
+			"Revoke", (*Revoke)(nil),
+		},
+		{
+			"SetAuthority", (*SetAuthority)(nil),
+		},
+		{
+			"MintTo", (*MintTo)(nil),
+		},
+		{
+			"Burn", (*Burn)(nil),
+		},
+		{
 			"CloseAccount", (*CloseAccount)(nil),
 		},
+		{
+			"FreezeAccount", (*FreezeAccount)(nil),
+		},
+		{
+			"ThawAccount", (*ThawAccount)(nil),
+		},
+		{
+			"TransferChecked", (*TransferChecked)(nil),
+		},
+		{
+			"ApproveChecked", (*ApproveChecked)(nil),
+		},
+		{
+			"MintToChecked", (*MintToChecked)(nil),
+		},
+		{
+			"BurnChecked", (*BurnChecked)(nil),
+		},
+		{
+			"InitializeAccount2", (*InitializeAccount2)(nil),
+		},
+		{
+			"SyncNative", (*SyncNative)(nil),
+		},
+		{
+			"InitializeAccount3", (*InitializeAccount3)(nil),
+		},
+		{
+			"InitializeMultisig2", (*InitializeMultisig2)(nil),
+		},
+		{
+			"InitializeMint2", (*InitializeMint2)(nil),
+		},
 	},
 )
 
@@ -0,0 +1,122 @@
+package token
+
+import (
+	"testing"
+
+	"github.com/scatkit/pumpdexer/solana"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_RoundTrip(t *testing.T) {
+	source := solana.NewWallet().PublicKey()
+	destination := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+	owner := solana.NewWallet().PublicKey()
+	cosigners := []solana.PublicKey{solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()}
+	freeze := solana.NewWallet().PublicKey()
+
+	tests := []struct {
+		name     string
+		builder  interface{ ValidateAndBuild() (*Instruction, error) }
+		wantData []byte
+	}{
+		{
+			name:     "Transfer",
+			builder:  NewTransferInstruction(1_000_000, source, destination, owner, nil),
+			wantData: []byte{Instruction_Transfer, 0x40, 0x42, 0x0f, 0, 0, 0, 0, 0},
+		},
+		{
+			name:     "TransferChecked multisig",
+			builder:  NewTransferCheckedInstruction(5, 6, source, mint, destination, owner, cosigners),
+			wantData: []byte{Instruction_TransferChecked, 5, 0, 0, 0, 0, 0, 0, 0, 6},
+		},
+		{
+			name:     "InitializeMint2 without freeze authority",
+			builder:  NewInitializeMint2Instruction(9, owner, nil, mint),
+			wantData: append(append([]byte{Instruction_InitializeMint2, 9}, owner[:]...), 0),
+		},
+		{
+			name:     "InitializeMint with freeze authority",
+			builder:  NewInitializeMintInstruction(6, owner, &freeze, mint, solana.SysVarRentPubkey),
+			wantData: append(append(append([]byte{Instruction_InitializeMint, 6}, owner[:]...), 1), freeze[:]...),
+		},
+		{
+			name:     "SetAuthority revoking",
+			builder:  NewSetAuthorityInstruction(AuthorityFreezeAccount, nil, mint, owner, nil),
+			wantData: []byte{Instruction_SetAuthority, byte(AuthorityFreezeAccount), 0},
+		},
+		{
+			name:     "InitializeMultisig2",
+			builder:  NewInitializeMultisig2Instruction(2, source, cosigners),
+			wantData: []byte{Instruction_InitializeMultisig2, 2},
+		},
+		{name: "InitializeAccount3", builder: NewInitializeAccount3Instruction(owner, source, mint)},
+		{name: "InitializeAccount2", builder: NewInitializeAccount2Instruction(owner, source, mint, solana.SysVarRentPubkey)},
+		{name: "InitializeMultisig", builder: NewInitializeMultisigInstruction(1, source, solana.SysVarRentPubkey, cosigners)},
+		{name: "Approve", builder: NewApproveInstruction(7, source, destination, owner, nil)},
+		{name: "ApproveChecked", builder: NewApproveCheckedInstruction(7, 2, source, mint, destination, owner, nil)},
+		{name: "Revoke", builder: NewRevokeInstruction(source, owner, cosigners)},
+		{name: "MintTo", builder: NewMintToInstruction(1, mint, destination, owner, nil)},
+		{name: "MintToChecked", builder: NewMintToCheckedInstruction(1, 9, mint, destination, owner, nil)},
+		{name: "Burn", builder: NewBurnInstruction(3, source, mint, owner, nil)},
+		{name: "BurnChecked", builder: NewBurnCheckedInstruction(3, 9, source, mint, owner, nil)},
+		{name: "FreezeAccount", builder: NewFreezeAccountInstruction(source, mint, owner, nil)},
+		{name: "ThawAccount", builder: NewThawAccountInstruction(source, mint, owner, nil)},
+		{name: "CloseAccount", builder: NewCloseAccountInstruction(source, destination, owner, nil)},
+		{name: "SyncNative", builder: NewSyncNativeInstruction(source)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			inst, err := test.builder.ValidateAndBuild()
+			require.NoError(t, err)
+
+			data, err := inst.Data()
+			require.NoError(t, err)
+			if test.wantData != nil {
+				require.Equal(t, test.wantData, data)
+			}
+
+			decoded, err := solana.DecodeInstruction(ProgramID, inst.Accounts(), data)
+			require.NoError(t, err)
+			got := decoded.(*Instruction)
+			require.Equal(t, inst.TypeID, got.TypeID)
+			require.Equal(t, InstructionIDToName(inst.TypeID.Uint8()), InstructionIDToName(got.TypeID.Uint8()))
+			require.Equal(t, inst.Accounts(), got.Accounts())
+
+			reencoded, err := got.Data()
+			require.NoError(t, err)
+			require.Equal(t, data, reencoded)
+		})
+	}
+}
+
+func TestTransfer_Validate(t *testing.T) {
+	source := solana.NewWallet().PublicKey()
+	destination := solana.NewWallet().PublicKey()
+
+	_, err := NewTransferInstructionBuilder().
+		SetSourceAccount(source).
+		SetDestinationAccount(destination).
+		ValidateAndBuild()
+	require.EqualError(t, err, "Amount parameter is not set")
+
+	_, err = NewTransferInstructionBuilder().
+		SetAmount(1).
+		SetSourceAccount(source).
+		SetDestinationAccount(destination).
+		ValidateAndBuild()
+	require.EqualError(t, err, "accounts.Owner is not set")
+
+	signers := make([]solana.PublicKey, MAX_SIGNERS+1)
+	_, err = NewTransferInstruction(1, source, destination, solana.NewWallet().PublicKey(), signers).ValidateAndBuild()
+	require.Error(t, err)
+
+	inst, err := NewTransferInstruction(1, source, destination, solana.NewWallet().PublicKey(), signers[:2]).ValidateAndBuild()
+	require.NoError(t, err)
+	accounts := inst.Accounts()
+	require.Len(t, accounts, 5)
+	require.False(t, accounts[2].IsSigner, "a multisig owner doesn't sign itself")
+	require.True(t, accounts[3].IsSigner)
+	require.True(t, accounts[4].IsSigner)
+}
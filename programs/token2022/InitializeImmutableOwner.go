@@ -0,0 +1,81 @@
+package token2022
+
+import (
+	"errors"
+
+	bin "github.com/gagliardetto/binary"
+	solana "github.com/scatkit/pumpdexer/solana"
+)
+
+// Initialize the Immutable Owner extension for the given token account.
+//
+// Fails if the account has already been initialized, so must be called
+// before `InitializeAccount`.
+type InitializeImmutableOwner struct {
+	// [0] = [WRITE] account
+	// ··········· The token account to initialize.
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewInitializeImmutableOwnerInstructionBuilder creates a new `InitializeImmutableOwner` instruction builder.
+func NewInitializeImmutableOwnerInstructionBuilder() *InitializeImmutableOwner {
+	nd := &InitializeImmutableOwner{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 1),
+	}
+	return nd
+}
+
+// SetAccount sets the "account" account.
+// The token account to initialize.
+func (inst *InitializeImmutableOwner) SetAccount(account solana.PublicKey) *InitializeImmutableOwner {
+	inst.AccountMetaSlice[0] = solana.Meta(account).WRITE()
+	return inst
+}
+
+// GetAccount gets the "account" account.
+// The token account to initialize.
+func (inst *InitializeImmutableOwner) GetAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+func (inst InitializeImmutableOwner) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint8(Instruction_InitializeImmutableOwner),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst InitializeImmutableOwner) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *InitializeImmutableOwner) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Account is not set")
+		}
+	}
+	return nil
+}
+
+func (obj InitializeImmutableOwner) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	return nil
+}
+func (obj *InitializeImmutableOwner) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	return nil
+}
+
+// NewInitializeImmutableOwnerInstruction declares a new InitializeImmutableOwner instruction with the provided parameters and accounts.
+func NewInitializeImmutableOwnerInstruction(
+	// Accounts:
+	account solana.PublicKey) *InitializeImmutableOwner {
+	return NewInitializeImmutableOwnerInstructionBuilder().
+		SetAccount(account)
+}
@@ -0,0 +1,102 @@
+package token2022
+
+import (
+	"errors"
+
+	bin "github.com/gagliardetto/binary"
+	solana "github.com/scatkit/pumpdexer/solana"
+)
+
+// Initialize the close account authority on a new mint.
+//
+// Fails if the mint has already been initialized, so must be called before
+// `InitializeMint`.
+type InitializeMintCloseAuthority struct {
+	// Authority that must sign the `CloseAccount` instruction on a mint.
+	CloseAuthority *solana.PublicKey
+
+	// [0] = [WRITE] mint
+	// ··········· The mint to initialize.
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewInitializeMintCloseAuthorityInstructionBuilder creates a new `InitializeMintCloseAuthority` instruction builder.
+func NewInitializeMintCloseAuthorityInstructionBuilder() *InitializeMintCloseAuthority {
+	nd := &InitializeMintCloseAuthority{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 1),
+	}
+	return nd
+}
+
+// SetCloseAuthority sets the "closeAuthority" parameter.
+// Authority that must sign the `CloseAccount` instruction on a mint.
+func (inst *InitializeMintCloseAuthority) SetCloseAuthority(closeAuthority solana.PublicKey) *InitializeMintCloseAuthority {
+	inst.CloseAuthority = &closeAuthority
+	return inst
+}
+
+// SetMintAccount sets the "mint" account.
+// The mint to initialize.
+func (inst *InitializeMintCloseAuthority) SetMintAccount(mint solana.PublicKey) *InitializeMintCloseAuthority {
+	inst.AccountMetaSlice[0] = solana.Meta(mint).WRITE()
+	return inst
+}
+
+// GetMintAccount gets the "mint" account.
+// The mint to initialize.
+func (inst *InitializeMintCloseAuthority) GetMintAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+func (inst InitializeMintCloseAuthority) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint8(Instruction_InitializeMintCloseAuthority),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst InitializeMintCloseAuthority) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *InitializeMintCloseAuthority) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Mint is not set")
+		}
+	}
+	return nil
+}
+
+func (obj InitializeMintCloseAuthority) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	// Serialize `CloseAuthority` param (optional):
+	return writeOptionalPublicKey(encoder, obj.CloseAuthority)
+}
+
+func (obj *InitializeMintCloseAuthority) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	// Deserialize `CloseAuthority` (optional):
+	obj.CloseAuthority, err = readOptionalPublicKey(decoder)
+	return err
+}
+
+// NewInitializeMintCloseAuthorityInstruction declares a new InitializeMintCloseAuthority instruction with the provided parameters and accounts.
+func NewInitializeMintCloseAuthorityInstruction(
+	// Parameters:
+	closeAuthority *solana.PublicKey,
+	// Accounts:
+	mint solana.PublicKey,
+) *InitializeMintCloseAuthority {
+	inst := NewInitializeMintCloseAuthorityInstructionBuilder().
+		SetMintAccount(mint)
+	if closeAuthority != nil {
+		inst.SetCloseAuthority(*closeAuthority)
+	}
+	return inst
+}
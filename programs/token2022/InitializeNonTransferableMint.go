@@ -0,0 +1,81 @@
+package token2022
+
+import (
+	"errors"
+
+	bin "github.com/gagliardetto/binary"
+	solana "github.com/scatkit/pumpdexer/solana"
+)
+
+// Initialize the non transferable extension for the given mint account.
+//
+// Fails if the account has already been initialized, so must be called
+// before `InitializeMint`.
+type InitializeNonTransferableMint struct {
+	// [0] = [WRITE] mint
+	// ··········· The mint account to initialize.
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewInitializeNonTransferableMintInstructionBuilder creates a new `InitializeNonTransferableMint` instruction builder.
+func NewInitializeNonTransferableMintInstructionBuilder() *InitializeNonTransferableMint {
+	nd := &InitializeNonTransferableMint{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 1),
+	}
+	return nd
+}
+
+// SetMintAccount sets the "mint" account.
+// The mint account to initialize.
+func (inst *InitializeNonTransferableMint) SetMintAccount(mint solana.PublicKey) *InitializeNonTransferableMint {
+	inst.AccountMetaSlice[0] = solana.Meta(mint).WRITE()
+	return inst
+}
+
+// GetMintAccount gets the "mint" account.
+// The mint account to initialize.
+func (inst *InitializeNonTransferableMint) GetMintAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+func (inst InitializeNonTransferableMint) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint8(Instruction_InitializeNonTransferableMint),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst InitializeNonTransferableMint) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *InitializeNonTransferableMint) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Mint is not set")
+		}
+	}
+	return nil
+}
+
+func (obj InitializeNonTransferableMint) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	return nil
+}
+func (obj *InitializeNonTransferableMint) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	return nil
+}
+
+// NewInitializeNonTransferableMintInstruction declares a new InitializeNonTransferableMint instruction with the provided parameters and accounts.
+func NewInitializeNonTransferableMintInstruction(
+	// Accounts:
+	mint solana.PublicKey) *InitializeNonTransferableMint {
+	return NewInitializeNonTransferableMintInstructionBuilder().
+		SetMintAccount(mint)
+}
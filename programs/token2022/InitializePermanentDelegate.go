@@ -0,0 +1,109 @@
+package token2022
+
+import (
+	"errors"
+
+	bin "github.com/gagliardetto/binary"
+	solana "github.com/scatkit/pumpdexer/solana"
+)
+
+// Initialize the permanent delegate on a new mint.
+//
+// Fails if the mint has already been initialized, so must be called before
+// `InitializeMint`.
+//
+// The permanent delegate can transfer or burn tokens from any account of the mint.
+type InitializePermanentDelegate struct {
+	// Authority that may sign for `Transfer`s and `Burn`s on any account.
+	Delegate *solana.PublicKey
+
+	// [0] = [WRITE] mint
+	// ··········· The mint to initialize.
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewInitializePermanentDelegateInstructionBuilder creates a new `InitializePermanentDelegate` instruction builder.
+func NewInitializePermanentDelegateInstructionBuilder() *InitializePermanentDelegate {
+	nd := &InitializePermanentDelegate{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 1),
+	}
+	return nd
+}
+
+// SetDelegate sets the "delegate" parameter.
+// Authority that may sign for `Transfer`s and `Burn`s on any account.
+func (inst *InitializePermanentDelegate) SetDelegate(delegate solana.PublicKey) *InitializePermanentDelegate {
+	inst.Delegate = &delegate
+	return inst
+}
+
+// SetMintAccount sets the "mint" account.
+// The mint to initialize.
+func (inst *InitializePermanentDelegate) SetMintAccount(mint solana.PublicKey) *InitializePermanentDelegate {
+	inst.AccountMetaSlice[0] = solana.Meta(mint).WRITE()
+	return inst
+}
+
+// GetMintAccount gets the "mint" account.
+// The mint to initialize.
+func (inst *InitializePermanentDelegate) GetMintAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+func (inst InitializePermanentDelegate) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: bin.TypeIDFromUint8(Instruction_InitializePermanentDelegate),
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst InitializePermanentDelegate) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *InitializePermanentDelegate) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Delegate == nil {
+			return errors.New("Delegate parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Mint is not set")
+		}
+	}
+	return nil
+}
+
+func (obj InitializePermanentDelegate) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	// Serialize `Delegate` param:
+	return encoder.WriteBytes(obj.Delegate[:], false)
+}
+
+func (obj *InitializePermanentDelegate) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	// Deserialize `Delegate`:
+	obj.Delegate = new(solana.PublicKey)
+	_, err = decoder.Read(obj.Delegate[:])
+	return err
+}
+
+// NewInitializePermanentDelegateInstruction declares a new InitializePermanentDelegate instruction with the provided parameters and accounts.
+func NewInitializePermanentDelegateInstruction(
+	// Parameters:
+	delegate solana.PublicKey,
+	// Accounts:
+	mint solana.PublicKey,
+) *InitializePermanentDelegate {
+	return NewInitializePermanentDelegateInstructionBuilder().
+		SetDelegate(delegate).
+		SetMintAccount(mint)
+}
@@ -0,0 +1,153 @@
+package token2022
+
+import (
+	"errors"
+
+	bin "github.com/gagliardetto/binary"
+	solana "github.com/scatkit/pumpdexer/solana"
+)
+
+// Instructions of the Metadata Pointer extension (prefixed by Instruction_MetadataPointerExtension).
+const (
+	// Initialize a new mint with a metadata pointer.
+	MetadataPointer_InitializeMetadataPointer uint8 = iota
+
+	// Update the metadata pointer address. Only supported for mints that include the `MetadataPointer` extension.
+	MetadataPointer_Update
+)
+
+// MetadataPointerExtension is an instruction of the Metadata Pointer extension.
+type MetadataPointerExtension struct {
+	extensionVariant
+}
+
+var MetadataPointerExtensionImplDef = bin.NewVariantDefinition(
+	bin.Uint8TypeIDEncoding,
+	[]bin.VariantType{
+		{"Initialize", (*InitializeMetadataPointer)(nil)},
+		{"Update", (*Unknown)(nil)},
+	},
+)
+
+func (ext *MetadataPointerExtension) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	return ext.unmarshal(decoder, MetadataPointerExtensionImplDef)
+}
+
+// Initialize a new mint with a metadata pointer.
+//
+// Fails if the mint has already been initialized, so must be called before
+// `InitializeMint`.
+type InitializeMetadataPointer struct {
+	// The public key for the account that can update the metadata address.
+	Authority *solana.PublicKey
+
+	// The account address that holds the metadata.
+	MetadataAddress *solana.PublicKey
+
+	// [0] = [WRITE] mint
+	// ··········· The mint to initialize.
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewInitializeMetadataPointerInstructionBuilder creates a new `InitializeMetadataPointer` instruction builder.
+func NewInitializeMetadataPointerInstructionBuilder() *InitializeMetadataPointer {
+	nd := &InitializeMetadataPointer{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 1),
+	}
+	return nd
+}
+
+// SetAuthority sets the "authority" parameter.
+// The public key for the account that can update the metadata address.
+func (inst *InitializeMetadataPointer) SetAuthority(authority solana.PublicKey) *InitializeMetadataPointer {
+	inst.Authority = &authority
+	return inst
+}
+
+// SetMetadataAddress sets the "metadataAddress" parameter.
+// The account address that holds the metadata.
+func (inst *InitializeMetadataPointer) SetMetadataAddress(metadataAddress solana.PublicKey) *InitializeMetadataPointer {
+	inst.MetadataAddress = &metadataAddress
+	return inst
+}
+
+// SetMintAccount sets the "mint" account.
+// The mint to initialize.
+func (inst *InitializeMetadataPointer) SetMintAccount(mint solana.PublicKey) *InitializeMetadataPointer {
+	inst.AccountMetaSlice[0] = solana.Meta(mint).WRITE()
+	return inst
+}
+
+// GetMintAccount gets the "mint" account.
+// The mint to initialize.
+func (inst *InitializeMetadataPointer) GetMintAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+func (inst InitializeMetadataPointer) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		TypeID: bin.TypeIDFromUint8(Instruction_MetadataPointerExtension),
+		Impl: MetadataPointerExtension{extensionVariant{bin.BaseVariant{
+			TypeID: bin.TypeIDFromUint8(MetadataPointer_InitializeMetadataPointer),
+			Impl:   inst,
+		}}},
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst InitializeMetadataPointer) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *InitializeMetadataPointer) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Mint is not set")
+		}
+	}
+	return nil
+}
+
+func (obj InitializeMetadataPointer) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	// Serialize `Authority` param (zero when not set):
+	if err = writeNonZeroPublicKey(encoder, obj.Authority); err != nil {
+		return err
+	}
+	// Serialize `MetadataAddress` param (zero when not set):
+	return writeNonZeroPublicKey(encoder, obj.MetadataAddress)
+}
+
+func (obj *InitializeMetadataPointer) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	// Deserialize `Authority`:
+	if obj.Authority, err = readNonZeroPublicKey(decoder); err != nil {
+		return err
+	}
+	// Deserialize `MetadataAddress`:
+	obj.MetadataAddress, err = readNonZeroPublicKey(decoder)
+	return err
+}
+
+// NewInitializeMetadataPointerInstruction declares a new InitializeMetadataPointer instruction with the provided parameters and accounts.
+func NewInitializeMetadataPointerInstruction(
+	// Parameters:
+	authority *solana.PublicKey,
+	metadataAddress *solana.PublicKey,
+	// Accounts:
+	mint solana.PublicKey,
+) *InitializeMetadataPointer {
+	inst := NewInitializeMetadataPointerInstructionBuilder().
+		SetMintAccount(mint)
+	if authority != nil {
+		inst.SetAuthority(*authority)
+	}
+	if metadataAddress != nil {
+		inst.SetMetadataAddress(*metadataAddress)
+	}
+	return inst
+}
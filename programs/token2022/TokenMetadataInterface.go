@@ -0,0 +1,765 @@
+package token2022
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	bin "github.com/gagliardetto/binary"
+	solana "github.com/scatkit/pumpdexer/solana"
+)
+
+// Token-2022 implements the token-metadata interface for the metadata stored in the mint
+// (`TokenMetadata` extension). Unlike the program's own instructions, the instructions of
+// the interface start with an 8-byte discriminator: the first 8 bytes of
+// sha256("spl_token_metadata_interface:<name>").
+var (
+	// Initialize the metadata of a mint.
+	TokenMetadata_Initialize = bin.TypeIDFromBytes([]byte{210, 225, 30, 162, 88, 184, 77, 141})
+
+	// Update a field of the metadata, adding it when it's a new key.
+	TokenMetadata_UpdateField = bin.TypeIDFromBytes([]byte{221, 233, 49, 45, 181, 202, 220, 200})
+
+	// Remove a key of the additional metadata.
+	TokenMetadata_RemoveKey = bin.TypeIDFromBytes([]byte{234, 18, 32, 56, 89, 141, 37, 181})
+
+	// Update or remove the update authority of the metadata.
+	TokenMetadata_UpdateAuthority = bin.TypeIDFromBytes([]byte{215, 228, 166, 228, 84, 100, 86, 123})
+
+	// Emit the metadata as return data.
+	TokenMetadata_Emit = bin.TypeIDFromBytes([]byte{250, 166, 180, 250, 13, 12, 184, 70})
+)
+
+// tokenMetadataInstructions maps the discriminators of the token-metadata interface to their instructions.
+var tokenMetadataInstructions = map[bin.TypeID]bin.VariantType{
+	TokenMetadata_Initialize:      {"InitializeTokenMetadata", (*InitializeTokenMetadata)(nil)},
+	TokenMetadata_UpdateField:     {"UpdateTokenMetadataField", (*UpdateTokenMetadataField)(nil)},
+	TokenMetadata_RemoveKey:       {"RemoveTokenMetadataKey", (*RemoveTokenMetadataKey)(nil)},
+	TokenMetadata_UpdateAuthority: {"UpdateTokenMetadataAuthority", (*UpdateTokenMetadataAuthority)(nil)},
+	TokenMetadata_Emit:            {"EmitTokenMetadata", (*EmitTokenMetadata)(nil)},
+}
+
+// TokenMetadataInstructionName returns the name of a token-metadata interface instruction
+// given its discriminator, or "" if it isn't one.
+func TokenMetadataInstructionName(discriminator bin.TypeID) string {
+	return tokenMetadataInstructions[discriminator].Name
+}
+
+// unmarshalTokenMetadataInstruction decodes the instruction if the data starts with the
+// discriminator of a token-metadata interface instruction, and tells whether it did.
+func (inst *Instruction) unmarshalTokenMetadataInstruction(decoder *bin.Decoder) (bool, error) {
+	discriminator, err := decoder.PeekDiscriminator()
+	if err != nil {
+		return false, nil
+	}
+	variant, ok := tokenMetadataInstructions[discriminator]
+	if !ok {
+		return false, nil
+	}
+	if _, err := decoder.ReadDiscriminator(); err != nil {
+		return true, err
+	}
+	impl := reflect.New(reflect.TypeOf(variant.Type).Elem()).Interface()
+	if err := decoder.Decode(impl); err != nil {
+		return true, fmt.Errorf("unable to decode %s: %w", variant.Name, err)
+	}
+	inst.TypeID = discriminator
+	inst.Impl = impl
+	return true, nil
+}
+
+func isTokenMetadataInstruction(typeID bin.TypeID) bool {
+	_, ok := tokenMetadataInstructions[typeID]
+	return ok
+}
+
+// Initialize the metadata of a mint, stored in the mint itself.
+//
+// The mint must have a metadata pointer to itself, and enough lamports
+// for the reallocation the metadata needs.
+type InitializeTokenMetadata struct {
+	// The longer name of the token.
+	Name string
+
+	// The shortened symbol for the token.
+	Symbol string
+
+	// The URI pointing to richer metadata.
+	URI string
+
+	// [0] = [WRITE] metadata
+	// ··········· The metadata account (the mint).
+	//
+	// [1] = [] updateAuthority
+	// ··········· The update authority of the metadata.
+	//
+	// [2] = [] mint
+	// ··········· The mint.
+	//
+	// [3] = [SIGNER] mintAuthority
+	// ··········· The mint authority.
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewInitializeTokenMetadataInstructionBuilder creates a new `InitializeTokenMetadata` instruction builder.
+func NewInitializeTokenMetadataInstructionBuilder() *InitializeTokenMetadata {
+	nd := &InitializeTokenMetadata{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 4),
+	}
+	return nd
+}
+
+// SetName sets the "name" parameter.
+func (inst *InitializeTokenMetadata) SetName(name string) *InitializeTokenMetadata {
+	inst.Name = name
+	return inst
+}
+
+// SetSymbol sets the "symbol" parameter.
+func (inst *InitializeTokenMetadata) SetSymbol(symbol string) *InitializeTokenMetadata {
+	inst.Symbol = symbol
+	return inst
+}
+
+// SetURI sets the "uri" parameter.
+func (inst *InitializeTokenMetadata) SetURI(uri string) *InitializeTokenMetadata {
+	inst.URI = uri
+	return inst
+}
+
+// SetMetadataAccount sets the "metadata" account.
+func (inst *InitializeTokenMetadata) SetMetadataAccount(metadata solana.PublicKey) *InitializeTokenMetadata {
+	inst.AccountMetaSlice[0] = solana.Meta(metadata).WRITE()
+	return inst
+}
+
+// GetMetadataAccount gets the "metadata" account.
+func (inst *InitializeTokenMetadata) GetMetadataAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// SetUpdateAuthorityAccount sets the "updateAuthority" account.
+func (inst *InitializeTokenMetadata) SetUpdateAuthorityAccount(updateAuthority solana.PublicKey) *InitializeTokenMetadata {
+	inst.AccountMetaSlice[1] = solana.Meta(updateAuthority)
+	return inst
+}
+
+// GetUpdateAuthorityAccount gets the "updateAuthority" account.
+func (inst *InitializeTokenMetadata) GetUpdateAuthorityAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+// SetMintAccount sets the "mint" account.
+func (inst *InitializeTokenMetadata) SetMintAccount(mint solana.PublicKey) *InitializeTokenMetadata {
+	inst.AccountMetaSlice[2] = solana.Meta(mint)
+	return inst
+}
+
+// GetMintAccount gets the "mint" account.
+func (inst *InitializeTokenMetadata) GetMintAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[2]
+}
+
+// SetMintAuthorityAccount sets the "mintAuthority" account.
+func (inst *InitializeTokenMetadata) SetMintAuthorityAccount(mintAuthority solana.PublicKey) *InitializeTokenMetadata {
+	inst.AccountMetaSlice[3] = solana.Meta(mintAuthority).SIGNER()
+	return inst
+}
+
+// GetMintAuthorityAccount gets the "mintAuthority" account.
+func (inst *InitializeTokenMetadata) GetMintAuthorityAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[3]
+}
+
+func (inst InitializeTokenMetadata) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: TokenMetadata_Initialize,
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst InitializeTokenMetadata) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *InitializeTokenMetadata) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Metadata is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return errors.New("accounts.UpdateAuthority is not set")
+		}
+		if inst.AccountMetaSlice[2] == nil {
+			return errors.New("accounts.Mint is not set")
+		}
+		if inst.AccountMetaSlice[3] == nil {
+			return errors.New("accounts.MintAuthority is not set")
+		}
+	}
+	return nil
+}
+
+func (obj InitializeTokenMetadata) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	for _, s := range []string{obj.Name, obj.Symbol, obj.URI} {
+		if err = writeBorshString(encoder, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (obj *InitializeTokenMetadata) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	if obj.Name, err = readBorshString(decoder); err != nil {
+		return err
+	}
+	if obj.Symbol, err = readBorshString(decoder); err != nil {
+		return err
+	}
+	obj.URI, err = readBorshString(decoder)
+	return err
+}
+
+// NewInitializeTokenMetadataInstruction declares a new InitializeTokenMetadata instruction with the provided parameters and accounts.
+func NewInitializeTokenMetadataInstruction(
+	// Parameters:
+	name string,
+	symbol string,
+	uri string,
+	// Accounts:
+	mint solana.PublicKey,
+	updateAuthority solana.PublicKey,
+	mintAuthority solana.PublicKey,
+) *InitializeTokenMetadata {
+	return NewInitializeTokenMetadataInstructionBuilder().
+		SetName(name).
+		SetSymbol(symbol).
+		SetURI(uri).
+		SetMetadataAccount(mint).
+		SetUpdateAuthorityAccount(updateAuthority).
+		SetMintAccount(mint).
+		SetMintAuthorityAccount(mintAuthority)
+}
+
+// MetadataField is a field of the token metadata: one of the base fields,
+// or a key of the additional metadata.
+type MetadataField uint8
+
+const (
+	MetadataFieldName MetadataField = iota
+	MetadataFieldSymbol
+	MetadataFieldURI
+	MetadataFieldKey
+)
+
+// Update a field of the metadata. Updating a key that doesn't exist adds it
+// to the additional metadata.
+type UpdateTokenMetadataField struct {
+	// The field to update.
+	Field MetadataField
+
+	// The key of the additional metadata, when Field is MetadataFieldKey.
+	Key string
+
+	// The value of the field.
+	Value string
+
+	// [0] = [WRITE] metadata
+	// ··········· The metadata account (the mint).
+	//
+	// [1] = [SIGNER] updateAuthority
+	// ··········· The update authority of the metadata.
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewUpdateTokenMetadataFieldInstructionBuilder creates a new `UpdateTokenMetadataField` instruction builder.
+func NewUpdateTokenMetadataFieldInstructionBuilder() *UpdateTokenMetadataField {
+	nd := &UpdateTokenMetadataField{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 2),
+	}
+	return nd
+}
+
+// SetField sets the "field" parameter to one of the base fields.
+func (inst *UpdateTokenMetadataField) SetField(field MetadataField) *UpdateTokenMetadataField {
+	inst.Field = field
+	return inst
+}
+
+// SetKey sets the "field" parameter to a key of the additional metadata.
+func (inst *UpdateTokenMetadataField) SetKey(key string) *UpdateTokenMetadataField {
+	inst.Field = MetadataFieldKey
+	inst.Key = key
+	return inst
+}
+
+// SetValue sets the "value" parameter.
+func (inst *UpdateTokenMetadataField) SetValue(value string) *UpdateTokenMetadataField {
+	inst.Value = value
+	return inst
+}
+
+// SetMetadataAccount sets the "metadata" account.
+func (inst *UpdateTokenMetadataField) SetMetadataAccount(metadata solana.PublicKey) *UpdateTokenMetadataField {
+	inst.AccountMetaSlice[0] = solana.Meta(metadata).WRITE()
+	return inst
+}
+
+// GetMetadataAccount gets the "metadata" account.
+func (inst *UpdateTokenMetadataField) GetMetadataAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// SetUpdateAuthorityAccount sets the "updateAuthority" account.
+func (inst *UpdateTokenMetadataField) SetUpdateAuthorityAccount(updateAuthority solana.PublicKey) *UpdateTokenMetadataField {
+	inst.AccountMetaSlice[1] = solana.Meta(updateAuthority).SIGNER()
+	return inst
+}
+
+// GetUpdateAuthorityAccount gets the "updateAuthority" account.
+func (inst *UpdateTokenMetadataField) GetUpdateAuthorityAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+func (inst UpdateTokenMetadataField) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: TokenMetadata_UpdateField,
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst UpdateTokenMetadataField) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *UpdateTokenMetadataField) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Field > MetadataFieldKey {
+			return fmt.Errorf("invalid Field %d", inst.Field)
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Metadata is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return errors.New("accounts.UpdateAuthority is not set")
+		}
+	}
+	return nil
+}
+
+func (obj UpdateTokenMetadataField) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = encoder.WriteUint8(uint8(obj.Field)); err != nil {
+		return err
+	}
+	if obj.Field == MetadataFieldKey {
+		if err = writeBorshString(encoder, obj.Key); err != nil {
+			return err
+		}
+	}
+	return writeBorshString(encoder, obj.Value)
+}
+
+func (obj *UpdateTokenMetadataField) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	field, err := decoder.ReadUint8()
+	if err != nil {
+		return err
+	}
+	if obj.Field = MetadataField(field); obj.Field > MetadataFieldKey {
+		return fmt.Errorf("invalid Field %d", field)
+	}
+	if obj.Field == MetadataFieldKey {
+		if obj.Key, err = readBorshString(decoder); err != nil {
+			return err
+		}
+	}
+	obj.Value, err = readBorshString(decoder)
+	return err
+}
+
+// NewUpdateTokenMetadataFieldInstruction declares a new UpdateTokenMetadataField instruction with the provided parameters and accounts.
+func NewUpdateTokenMetadataFieldInstruction(
+	// Parameters:
+	field MetadataField,
+	key string,
+	value string,
+	// Accounts:
+	metadata solana.PublicKey,
+	updateAuthority solana.PublicKey,
+) *UpdateTokenMetadataField {
+	inst := NewUpdateTokenMetadataFieldInstructionBuilder().
+		SetField(field).
+		SetValue(value).
+		SetMetadataAccount(metadata).
+		SetUpdateAuthorityAccount(updateAuthority)
+	if field == MetadataFieldKey {
+		inst.SetKey(key)
+	}
+	return inst
+}
+
+// Remove a key of the additional metadata.
+type RemoveTokenMetadataKey struct {
+	// Don't fail if the key doesn't exist.
+	Idempotent bool
+
+	// The key to remove.
+	Key string
+
+	// [0] = [WRITE] metadata
+	// ··········· The metadata account (the mint).
+	//
+	// [1] = [SIGNER] updateAuthority
+	// ··········· The update authority of the metadata.
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewRemoveTokenMetadataKeyInstructionBuilder creates a new `RemoveTokenMetadataKey` instruction builder.
+func NewRemoveTokenMetadataKeyInstructionBuilder() *RemoveTokenMetadataKey {
+	nd := &RemoveTokenMetadataKey{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 2),
+	}
+	return nd
+}
+
+// SetIdempotent sets the "idempotent" parameter.
+func (inst *RemoveTokenMetadataKey) SetIdempotent(idempotent bool) *RemoveTokenMetadataKey {
+	inst.Idempotent = idempotent
+	return inst
+}
+
+// SetKey sets the "key" parameter.
+func (inst *RemoveTokenMetadataKey) SetKey(key string) *RemoveTokenMetadataKey {
+	inst.Key = key
+	return inst
+}
+
+// SetMetadataAccount sets the "metadata" account.
+func (inst *RemoveTokenMetadataKey) SetMetadataAccount(metadata solana.PublicKey) *RemoveTokenMetadataKey {
+	inst.AccountMetaSlice[0] = solana.Meta(metadata).WRITE()
+	return inst
+}
+
+// GetMetadataAccount gets the "metadata" account.
+func (inst *RemoveTokenMetadataKey) GetMetadataAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// SetUpdateAuthorityAccount sets the "updateAuthority" account.
+func (inst *RemoveTokenMetadataKey) SetUpdateAuthorityAccount(updateAuthority solana.PublicKey) *RemoveTokenMetadataKey {
+	inst.AccountMetaSlice[1] = solana.Meta(updateAuthority).SIGNER()
+	return inst
+}
+
+// GetUpdateAuthorityAccount gets the "updateAuthority" account.
+func (inst *RemoveTokenMetadataKey) GetUpdateAuthorityAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+func (inst RemoveTokenMetadataKey) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: TokenMetadata_RemoveKey,
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst RemoveTokenMetadataKey) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *RemoveTokenMetadataKey) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Metadata is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return errors.New("accounts.UpdateAuthority is not set")
+		}
+	}
+	return nil
+}
+
+func (obj RemoveTokenMetadataKey) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = encoder.WriteBool(obj.Idempotent); err != nil {
+		return err
+	}
+	return writeBorshString(encoder, obj.Key)
+}
+
+func (obj *RemoveTokenMetadataKey) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	if obj.Idempotent, err = decoder.ReadBool(); err != nil {
+		return err
+	}
+	obj.Key, err = readBorshString(decoder)
+	return err
+}
+
+// NewRemoveTokenMetadataKeyInstruction declares a new RemoveTokenMetadataKey instruction with the provided parameters and accounts.
+func NewRemoveTokenMetadataKeyInstruction(
+	// Parameters:
+	idempotent bool,
+	key string,
+	// Accounts:
+	metadata solana.PublicKey,
+	updateAuthority solana.PublicKey,
+) *RemoveTokenMetadataKey {
+	return NewRemoveTokenMetadataKeyInstructionBuilder().
+		SetIdempotent(idempotent).
+		SetKey(key).
+		SetMetadataAccount(metadata).
+		SetUpdateAuthorityAccount(updateAuthority)
+}
+
+// Update the update authority of the metadata; without a new authority,
+// the metadata becomes immutable.
+type UpdateTokenMetadataAuthority struct {
+	// The new update authority (zero when not set).
+	NewAuthority *solana.PublicKey
+
+	// [0] = [WRITE] metadata
+	// ··········· The metadata account (the mint).
+	//
+	// [1] = [SIGNER] updateAuthority
+	// ··········· The current update authority of the metadata.
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewUpdateTokenMetadataAuthorityInstructionBuilder creates a new `UpdateTokenMetadataAuthority` instruction builder.
+func NewUpdateTokenMetadataAuthorityInstructionBuilder() *UpdateTokenMetadataAuthority {
+	nd := &UpdateTokenMetadataAuthority{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 2),
+	}
+	return nd
+}
+
+// SetNewAuthority sets the "newAuthority" parameter.
+func (inst *UpdateTokenMetadataAuthority) SetNewAuthority(newAuthority solana.PublicKey) *UpdateTokenMetadataAuthority {
+	inst.NewAuthority = &newAuthority
+	return inst
+}
+
+// SetMetadataAccount sets the "metadata" account.
+func (inst *UpdateTokenMetadataAuthority) SetMetadataAccount(metadata solana.PublicKey) *UpdateTokenMetadataAuthority {
+	inst.AccountMetaSlice[0] = solana.Meta(metadata).WRITE()
+	return inst
+}
+
+// GetMetadataAccount gets the "metadata" account.
+func (inst *UpdateTokenMetadataAuthority) GetMetadataAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+// SetUpdateAuthorityAccount sets the "updateAuthority" account.
+func (inst *UpdateTokenMetadataAuthority) SetUpdateAuthorityAccount(updateAuthority solana.PublicKey) *UpdateTokenMetadataAuthority {
+	inst.AccountMetaSlice[1] = solana.Meta(updateAuthority).SIGNER()
+	return inst
+}
+
+// GetUpdateAuthorityAccount gets the "updateAuthority" account.
+func (inst *UpdateTokenMetadataAuthority) GetUpdateAuthorityAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[1]
+}
+
+func (inst UpdateTokenMetadataAuthority) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: TokenMetadata_UpdateAuthority,
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst UpdateTokenMetadataAuthority) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *UpdateTokenMetadataAuthority) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Metadata is not set")
+		}
+		if inst.AccountMetaSlice[1] == nil {
+			return errors.New("accounts.UpdateAuthority is not set")
+		}
+	}
+	return nil
+}
+
+func (obj UpdateTokenMetadataAuthority) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	// Serialize `NewAuthority` param (zero when not set):
+	return writeNonZeroPublicKey(encoder, obj.NewAuthority)
+}
+
+func (obj *UpdateTokenMetadataAuthority) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	// Deserialize `NewAuthority`:
+	obj.NewAuthority, err = readNonZeroPublicKey(decoder)
+	return err
+}
+
+// NewUpdateTokenMetadataAuthorityInstruction declares a new UpdateTokenMetadataAuthority instruction with the provided parameters and accounts.
+func NewUpdateTokenMetadataAuthorityInstruction(
+	// Parameters:
+	newAuthority *solana.PublicKey,
+	// Accounts:
+	metadata solana.PublicKey,
+	updateAuthority solana.PublicKey,
+) *UpdateTokenMetadataAuthority {
+	inst := NewUpdateTokenMetadataAuthorityInstructionBuilder().
+		SetMetadataAccount(metadata).
+		SetUpdateAuthorityAccount(updateAuthority)
+	if newAuthority != nil {
+		inst.SetNewAuthority(*newAuthority)
+	}
+	return inst
+}
+
+// Emit the serialized metadata as return data, optionally sliced to [Start, End).
+type EmitTokenMetadata struct {
+	// Start of the slice of the serialized metadata (optional).
+	Start *uint64
+
+	// End of the slice of the serialized metadata, exclusive (optional).
+	End *uint64
+
+	// [0] = [] metadata
+	// ··········· The metadata account (the mint).
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewEmitTokenMetadataInstructionBuilder creates a new `EmitTokenMetadata` instruction builder.
+func NewEmitTokenMetadataInstructionBuilder() *EmitTokenMetadata {
+	nd := &EmitTokenMetadata{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 1),
+	}
+	return nd
+}
+
+// SetStart sets the "start" parameter.
+func (inst *EmitTokenMetadata) SetStart(start uint64) *EmitTokenMetadata {
+	inst.Start = &start
+	return inst
+}
+
+// SetEnd sets the "end" parameter.
+func (inst *EmitTokenMetadata) SetEnd(end uint64) *EmitTokenMetadata {
+	inst.End = &end
+	return inst
+}
+
+// SetMetadataAccount sets the "metadata" account.
+func (inst *EmitTokenMetadata) SetMetadataAccount(metadata solana.PublicKey) *EmitTokenMetadata {
+	inst.AccountMetaSlice[0] = solana.Meta(metadata)
+	return inst
+}
+
+// GetMetadataAccount gets the "metadata" account.
+func (inst *EmitTokenMetadata) GetMetadataAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+func (inst EmitTokenMetadata) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		Impl:   inst,
+		TypeID: TokenMetadata_Emit,
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst EmitTokenMetadata) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *EmitTokenMetadata) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Metadata is not set")
+		}
+	}
+	return nil
+}
+
+func (obj EmitTokenMetadata) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	for _, value := range []*uint64{obj.Start, obj.End} {
+		if err = encoder.WriteBool(value != nil); err != nil {
+			return err
+		}
+		if value != nil {
+			if err = encoder.WriteUint64(*value, bin.LE); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (obj *EmitTokenMetadata) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	for _, value := range []**uint64{&obj.Start, &obj.End} {
+		has, err := decoder.ReadBool()
+		if err != nil {
+			return err
+		}
+		*value = nil
+		if has {
+			v, err := decoder.ReadUint64(bin.LE)
+			if err != nil {
+				return err
+			}
+			*value = &v
+		}
+	}
+	return nil
+}
+
+// NewEmitTokenMetadataInstruction declares a new EmitTokenMetadata instruction with the provided parameters and accounts.
+func NewEmitTokenMetadataInstruction(
+	// Parameters:
+	start *uint64,
+	end *uint64,
+	// Accounts:
+	metadata solana.PublicKey,
+) *EmitTokenMetadata {
+	inst := NewEmitTokenMetadataInstructionBuilder().
+		SetMetadataAccount(metadata)
+	if start != nil {
+		inst.SetStart(*start)
+	}
+	if end != nil {
+		inst.SetEnd(*end)
+	}
+	return inst
+}
@@ -0,0 +1,462 @@
+package token2022
+
+import (
+	"errors"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/programs/token"
+	solana "github.com/scatkit/pumpdexer/solana"
+)
+
+// Instructions of the Transfer Fee extension (prefixed by Instruction_TransferFeeExtension).
+const (
+	// Initialize the transfer fee on a new mint.
+	TransferFee_InitializeTransferFeeConfig uint8 = iota
+
+	// Transfer, providing expected mint information and fees.
+	TransferFee_TransferCheckedWithFee
+
+	// Transfer all withheld tokens in the mint to an account.
+	TransferFee_WithdrawWithheldTokensFromMint
+
+	// Transfer all withheld tokens to an account.
+	TransferFee_WithdrawWithheldTokensFromAccounts
+
+	// Permissionless instruction to transfer all withheld tokens to the mint.
+	TransferFee_HarvestWithheldTokensToMint
+
+	// Set transfer fee. Only supported for mints that include the `TransferFeeConfig` extension.
+	TransferFee_SetTransferFee
+)
+
+// TransferFeeExtension is an instruction of the Transfer Fee extension.
+type TransferFeeExtension struct {
+	extensionVariant
+}
+
+var TransferFeeExtensionImplDef = bin.NewVariantDefinition(
+	bin.Uint8TypeIDEncoding,
+	[]bin.VariantType{
+		{"InitializeTransferFeeConfig", (*InitializeTransferFeeConfig)(nil)},
+		{"TransferCheckedWithFee", (*TransferCheckedWithFee)(nil)},
+		{"WithdrawWithheldTokensFromMint", (*Unknown)(nil)},
+		{"WithdrawWithheldTokensFromAccounts", (*Unknown)(nil)},
+		{"HarvestWithheldTokensToMint", (*Unknown)(nil)},
+		{"SetTransferFee", (*Unknown)(nil)},
+	},
+)
+
+func (ext *TransferFeeExtension) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	return ext.unmarshal(decoder, TransferFeeExtensionImplDef)
+}
+
+func newTransferFeeExtension(instructionID uint8, impl interface{}) *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		TypeID: bin.TypeIDFromUint8(Instruction_TransferFeeExtension),
+		Impl: TransferFeeExtension{extensionVariant{bin.BaseVariant{
+			TypeID: bin.TypeIDFromUint8(instructionID),
+			Impl:   impl,
+		}}},
+	}}
+}
+
+// Initialize the transfer fee on a new mint.
+//
+// Fails if the mint has already been initialized, so must be called before
+// `InitializeMint`.
+type InitializeTransferFeeConfig struct {
+	// Pubkey that may update the fees.
+	TransferFeeConfigAuthority *solana.PublicKey
+
+	// Withdraw instructions must be signed by this key.
+	WithdrawWithheldAuthority *solana.PublicKey
+
+	// Amount of transfer collected as fees, expressed as basis points of the transfer amount.
+	TransferFeeBasisPoints *uint16
+
+	// Maximum fee assessed on transfers.
+	MaximumFee *uint64
+
+	// [0] = [WRITE] mint
+	// ··········· The mint to initialize.
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewInitializeTransferFeeConfigInstructionBuilder creates a new `InitializeTransferFeeConfig` instruction builder.
+func NewInitializeTransferFeeConfigInstructionBuilder() *InitializeTransferFeeConfig {
+	nd := &InitializeTransferFeeConfig{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 1),
+	}
+	return nd
+}
+
+// SetTransferFeeConfigAuthority sets the "transferFeeConfigAuthority" parameter.
+// Pubkey that may update the fees.
+func (inst *InitializeTransferFeeConfig) SetTransferFeeConfigAuthority(authority solana.PublicKey) *InitializeTransferFeeConfig {
+	inst.TransferFeeConfigAuthority = &authority
+	return inst
+}
+
+// SetWithdrawWithheldAuthority sets the "withdrawWithheldAuthority" parameter.
+// Withdraw instructions must be signed by this key.
+func (inst *InitializeTransferFeeConfig) SetWithdrawWithheldAuthority(authority solana.PublicKey) *InitializeTransferFeeConfig {
+	inst.WithdrawWithheldAuthority = &authority
+	return inst
+}
+
+// SetTransferFeeBasisPoints sets the "transferFeeBasisPoints" parameter.
+// Amount of transfer collected as fees, expressed as basis points of the transfer amount.
+func (inst *InitializeTransferFeeConfig) SetTransferFeeBasisPoints(basisPoints uint16) *InitializeTransferFeeConfig {
+	inst.TransferFeeBasisPoints = &basisPoints
+	return inst
+}
+
+// SetMaximumFee sets the "maximumFee" parameter.
+// Maximum fee assessed on transfers.
+func (inst *InitializeTransferFeeConfig) SetMaximumFee(maximumFee uint64) *InitializeTransferFeeConfig {
+	inst.MaximumFee = &maximumFee
+	return inst
+}
+
+// SetMintAccount sets the "mint" account.
+// The mint to initialize.
+func (inst *InitializeTransferFeeConfig) SetMintAccount(mint solana.PublicKey) *InitializeTransferFeeConfig {
+	inst.AccountMetaSlice[0] = solana.Meta(mint).WRITE()
+	return inst
+}
+
+// GetMintAccount gets the "mint" account.
+// The mint to initialize.
+func (inst *InitializeTransferFeeConfig) GetMintAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+func (inst InitializeTransferFeeConfig) Build() *Instruction {
+	return newTransferFeeExtension(TransferFee_InitializeTransferFeeConfig, inst)
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst InitializeTransferFeeConfig) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *InitializeTransferFeeConfig) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.TransferFeeBasisPoints == nil {
+			return errors.New("TransferFeeBasisPoints parameter is not set")
+		}
+		if *inst.TransferFeeBasisPoints > MAX_FEE_BASIS_POINTS {
+			return fmt.Errorf("TransferFeeBasisPoints is %v, but max is %v", *inst.TransferFeeBasisPoints, MAX_FEE_BASIS_POINTS)
+		}
+		if inst.MaximumFee == nil {
+			return errors.New("MaximumFee parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Mint is not set")
+		}
+	}
+	return nil
+}
+
+func (obj InitializeTransferFeeConfig) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = writeOptionalPublicKey(encoder, obj.TransferFeeConfigAuthority); err != nil {
+		return err
+	}
+	if err = writeOptionalPublicKey(encoder, obj.WithdrawWithheldAuthority); err != nil {
+		return err
+	}
+	if err = encoder.WriteUint16(*obj.TransferFeeBasisPoints, bin.LE); err != nil {
+		return err
+	}
+	return encoder.WriteUint64(*obj.MaximumFee, bin.LE)
+}
+
+func (obj *InitializeTransferFeeConfig) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	if obj.TransferFeeConfigAuthority, err = readOptionalPublicKey(decoder); err != nil {
+		return err
+	}
+	if obj.WithdrawWithheldAuthority, err = readOptionalPublicKey(decoder); err != nil {
+		return err
+	}
+	basisPoints, err := decoder.ReadUint16(bin.LE)
+	if err != nil {
+		return err
+	}
+	obj.TransferFeeBasisPoints = &basisPoints
+	maximumFee, err := decoder.ReadUint64(bin.LE)
+	if err != nil {
+		return err
+	}
+	obj.MaximumFee = &maximumFee
+	return nil
+}
+
+// NewInitializeTransferFeeConfigInstruction declares a new InitializeTransferFeeConfig instruction with the provided parameters and accounts.
+func NewInitializeTransferFeeConfigInstruction(
+	// Parameters:
+	transferFeeConfigAuthority *solana.PublicKey,
+	withdrawWithheldAuthority *solana.PublicKey,
+	transferFeeBasisPoints uint16,
+	maximumFee uint64,
+	// Accounts:
+	mint solana.PublicKey,
+) *InitializeTransferFeeConfig {
+	inst := NewInitializeTransferFeeConfigInstructionBuilder().
+		SetTransferFeeBasisPoints(transferFeeBasisPoints).
+		SetMaximumFee(maximumFee).
+		SetMintAccount(mint)
+	if transferFeeConfigAuthority != nil {
+		inst.SetTransferFeeConfigAuthority(*transferFeeConfigAuthority)
+	}
+	if withdrawWithheldAuthority != nil {
+		inst.SetWithdrawWithheldAuthority(*withdrawWithheldAuthority)
+	}
+	return inst
+}
+
+// Transfer, providing expected mint information and fees.
+//
+// The fee must match the one the mint charges for the amount at the current epoch
+// (see TransferFeeConfig.CalculateEpochFee), otherwise the transfer fails.
+type TransferCheckedWithFee struct {
+	// The amount of tokens to transfer.
+	Amount *uint64
+
+	// Expected number of base 10 digits to the right of the decimal place.
+	Decimals *uint8
+
+	// Expected fee assessed on this transfer, calculated off-chain based on
+	// the transfer_fee_basis_points and maximum_fee of the mint. May be 0 for a mint without a configured transfer fee.
+	Fee *uint64
+
+	// [0] = [WRITE] source
+	// ··········· The source account.
+	//
+	// [1] = [] mint
+	// ··········· The token mint.
+	//
+	// [2] = [WRITE] destination
+	// ··········· The destination account.
+	//
+	// [3] = [] owner
+	// ··········· The source account's owner/delegate.
+	//
+	// [4...] = [SIGNER] signers
+	// ··········· M signer accounts.
+	Accounts solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+	Signers  solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+func (obj *TransferCheckedWithFee) SetAccounts(accounts []*solana.AccountMeta) error {
+	obj.Accounts, obj.Signers = solana.AccountMetaSlice(accounts).SplitFrom(4)
+	return nil
+}
+
+func (slice TransferCheckedWithFee) GetAccounts() (accounts []*solana.AccountMeta) {
+	accounts = append(accounts, slice.Accounts...)
+	accounts = append(accounts, slice.Signers...)
+	return
+}
+
+// NewTransferCheckedWithFeeInstructionBuilder creates a new `TransferCheckedWithFee` instruction builder.
+func NewTransferCheckedWithFeeInstructionBuilder() *TransferCheckedWithFee {
+	nd := &TransferCheckedWithFee{
+		Accounts: make(solana.AccountMetaSlice, 4),
+		Signers:  make(solana.AccountMetaSlice, 0),
+	}
+	return nd
+}
+
+// SetAmount sets the "amount" parameter.
+// The amount of tokens to transfer.
+func (inst *TransferCheckedWithFee) SetAmount(amount uint64) *TransferCheckedWithFee {
+	inst.Amount = &amount
+	return inst
+}
+
+// SetDecimals sets the "decimals" parameter.
+// Expected number of base 10 digits to the right of the decimal place.
+func (inst *TransferCheckedWithFee) SetDecimals(decimals uint8) *TransferCheckedWithFee {
+	inst.Decimals = &decimals
+	return inst
+}
+
+// SetFee sets the "fee" parameter.
+// Expected fee assessed on this transfer.
+func (inst *TransferCheckedWithFee) SetFee(fee uint64) *TransferCheckedWithFee {
+	inst.Fee = &fee
+	return inst
+}
+
+// SetSourceAccount sets the "source" account.
+// The source account.
+func (inst *TransferCheckedWithFee) SetSourceAccount(source solana.PublicKey) *TransferCheckedWithFee {
+	inst.Accounts[0] = solana.Meta(source).WRITE()
+	return inst
+}
+
+// GetSourceAccount gets the "source" account.
+// The source account.
+func (inst *TransferCheckedWithFee) GetSourceAccount() *solana.AccountMeta {
+	return inst.Accounts[0]
+}
+
+// SetMintAccount sets the "mint" account.
+// The token mint.
+func (inst *TransferCheckedWithFee) SetMintAccount(mint solana.PublicKey) *TransferCheckedWithFee {
+	inst.Accounts[1] = solana.Meta(mint)
+	return inst
+}
+
+// GetMintAccount gets the "mint" account.
+// The token mint.
+func (inst *TransferCheckedWithFee) GetMintAccount() *solana.AccountMeta {
+	return inst.Accounts[1]
+}
+
+// SetDestinationAccount sets the "destination" account.
+// The destination account.
+func (inst *TransferCheckedWithFee) SetDestinationAccount(destination solana.PublicKey) *TransferCheckedWithFee {
+	inst.Accounts[2] = solana.Meta(destination).WRITE()
+	return inst
+}
+
+// GetDestinationAccount gets the "destination" account.
+// The destination account.
+func (inst *TransferCheckedWithFee) GetDestinationAccount() *solana.AccountMeta {
+	return inst.Accounts[2]
+}
+
+// SetOwnerAccount sets the "owner" account.
+// The source account's owner/delegate.
+func (inst *TransferCheckedWithFee) SetOwnerAccount(owner solana.PublicKey, multisigSigners ...solana.PublicKey) *TransferCheckedWithFee {
+	inst.Accounts[3] = solana.Meta(owner)
+	if len(multisigSigners) == 0 {
+		inst.Accounts[3].SIGNER()
+	}
+	for _, signer := range multisigSigners {
+		inst.Signers = append(inst.Signers, solana.Meta(signer).SIGNER())
+	}
+	return inst
+}
+
+// GetOwnerAccount gets the "owner" account.
+// The source account's owner/delegate.
+func (inst *TransferCheckedWithFee) GetOwnerAccount() *solana.AccountMeta {
+	return inst.Accounts[3]
+}
+
+func (inst TransferCheckedWithFee) Build() *Instruction {
+	return newTransferFeeExtension(TransferFee_TransferCheckedWithFee, inst)
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst TransferCheckedWithFee) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *TransferCheckedWithFee) Validate() error {
+	// Check whether all (required) parameters are set:
+	{
+		if inst.Amount == nil {
+			return errors.New("Amount parameter is not set")
+		}
+		if inst.Decimals == nil {
+			return errors.New("Decimals parameter is not set")
+		}
+		if inst.Fee == nil {
+			return errors.New("Fee parameter is not set")
+		}
+	}
+
+	// Check whether all (required) accounts are set:
+	{
+		if inst.Accounts[0] == nil {
+			return errors.New("accounts.Source is not set")
+		}
+		if inst.Accounts[1] == nil {
+			return errors.New("accounts.Mint is not set")
+		}
+		if inst.Accounts[2] == nil {
+			return errors.New("accounts.Destination is not set")
+		}
+		if inst.Accounts[3] == nil {
+			return errors.New("accounts.Owner is not set")
+		}
+		if !inst.Accounts[3].IsSigner && len(inst.Signers) == 0 {
+			return errors.New("accounts.Signers is not set")
+		}
+		if len(inst.Signers) > token.MAX_SIGNERS {
+			return fmt.Errorf("too many signers; got %v, but max is 11", len(inst.Signers))
+		}
+	}
+	return nil
+}
+
+func (obj TransferCheckedWithFee) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = encoder.WriteUint64(*obj.Amount, bin.LE); err != nil {
+		return err
+	}
+	if err = encoder.WriteUint8(*obj.Decimals); err != nil {
+		return err
+	}
+	return encoder.WriteUint64(*obj.Fee, bin.LE)
+}
+
+func (obj *TransferCheckedWithFee) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	amount, err := decoder.ReadUint64(bin.LE)
+	if err != nil {
+		return err
+	}
+	obj.Amount = &amount
+	decimals, err := decoder.ReadUint8()
+	if err != nil {
+		return err
+	}
+	obj.Decimals = &decimals
+	fee, err := decoder.ReadUint64(bin.LE)
+	if err != nil {
+		return err
+	}
+	obj.Fee = &fee
+	return nil
+}
+
+// NewTransferCheckedWithFeeInstruction declares a new TransferCheckedWithFee instruction with the provided parameters and accounts.
+func NewTransferCheckedWithFeeInstruction(
+	// Parameters:
+	amount uint64,
+	decimals uint8,
+	fee uint64,
+	// Accounts:
+	source solana.PublicKey,
+	mint solana.PublicKey,
+	destination solana.PublicKey,
+	owner solana.PublicKey,
+	multisigSigners []solana.PublicKey,
+) *TransferCheckedWithFee {
+	return NewTransferCheckedWithFeeInstructionBuilder().
+		SetAmount(amount).
+		SetDecimals(decimals).
+		SetFee(fee).
+		SetSourceAccount(source).
+		SetMintAccount(mint).
+		SetDestinationAccount(destination).
+		SetOwnerAccount(owner, multisigSigners...)
+}
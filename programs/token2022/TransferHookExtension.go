@@ -0,0 +1,153 @@
+package token2022
+
+import (
+	"errors"
+
+	bin "github.com/gagliardetto/binary"
+	solana "github.com/scatkit/pumpdexer/solana"
+)
+
+// Instructions of the Transfer Hook extension (prefixed by Instruction_TransferHookExtension).
+const (
+	// Initialize a new mint with a transfer hook program.
+	TransferHook_InitializeTransferHook uint8 = iota
+
+	// Update the transfer hook program id. Only supported for mints that include the `TransferHook` extension.
+	TransferHook_Update
+)
+
+// TransferHookExtension is an instruction of the Transfer Hook extension.
+type TransferHookExtension struct {
+	extensionVariant
+}
+
+var TransferHookExtensionImplDef = bin.NewVariantDefinition(
+	bin.Uint8TypeIDEncoding,
+	[]bin.VariantType{
+		{"Initialize", (*InitializeTransferHook)(nil)},
+		{"Update", (*Unknown)(nil)},
+	},
+)
+
+func (ext *TransferHookExtension) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	return ext.unmarshal(decoder, TransferHookExtensionImplDef)
+}
+
+// Initialize a new mint with a transfer hook program.
+//
+// Fails if the mint has already been initialized, so must be called before
+// `InitializeMint`.
+type InitializeTransferHook struct {
+	// The public key for the account that can update the program id.
+	Authority *solana.PublicKey
+
+	// The program id that performs logic during transfers.
+	HookProgramID *solana.PublicKey
+
+	// [0] = [WRITE] mint
+	// ··········· The mint to initialize.
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+// NewInitializeTransferHookInstructionBuilder creates a new `InitializeTransferHook` instruction builder.
+func NewInitializeTransferHookInstructionBuilder() *InitializeTransferHook {
+	nd := &InitializeTransferHook{
+		AccountMetaSlice: make(solana.AccountMetaSlice, 1),
+	}
+	return nd
+}
+
+// SetAuthority sets the "authority" parameter.
+// The public key for the account that can update the program id.
+func (inst *InitializeTransferHook) SetAuthority(authority solana.PublicKey) *InitializeTransferHook {
+	inst.Authority = &authority
+	return inst
+}
+
+// SetHookProgramID sets the "hookProgramID" parameter.
+// The program id that performs logic during transfers.
+func (inst *InitializeTransferHook) SetHookProgramID(hookProgramID solana.PublicKey) *InitializeTransferHook {
+	inst.HookProgramID = &hookProgramID
+	return inst
+}
+
+// SetMintAccount sets the "mint" account.
+// The mint to initialize.
+func (inst *InitializeTransferHook) SetMintAccount(mint solana.PublicKey) *InitializeTransferHook {
+	inst.AccountMetaSlice[0] = solana.Meta(mint).WRITE()
+	return inst
+}
+
+// GetMintAccount gets the "mint" account.
+// The mint to initialize.
+func (inst *InitializeTransferHook) GetMintAccount() *solana.AccountMeta {
+	return inst.AccountMetaSlice[0]
+}
+
+func (inst InitializeTransferHook) Build() *Instruction {
+	return &Instruction{BaseVariant: bin.BaseVariant{
+		TypeID: bin.TypeIDFromUint8(Instruction_TransferHookExtension),
+		Impl: TransferHookExtension{extensionVariant{bin.BaseVariant{
+			TypeID: bin.TypeIDFromUint8(TransferHook_InitializeTransferHook),
+			Impl:   inst,
+		}}},
+	}}
+}
+
+// ValidateAndBuild validates the instruction parameters and accounts;
+// if there is a validation error, it returns the error.
+// Otherwise, it builds and returns the instruction.
+func (inst InitializeTransferHook) ValidateAndBuild() (*Instruction, error) {
+	if err := inst.Validate(); err != nil {
+		return nil, err
+	}
+	return inst.Build(), nil
+}
+
+func (inst *InitializeTransferHook) Validate() error {
+	// Check whether all (required) accounts are set:
+	{
+		if inst.AccountMetaSlice[0] == nil {
+			return errors.New("accounts.Mint is not set")
+		}
+	}
+	return nil
+}
+
+func (obj InitializeTransferHook) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	// Serialize `Authority` param (zero when not set):
+	if err = writeNonZeroPublicKey(encoder, obj.Authority); err != nil {
+		return err
+	}
+	// Serialize `HookProgramID` param (zero when not set):
+	return writeNonZeroPublicKey(encoder, obj.HookProgramID)
+}
+
+func (obj *InitializeTransferHook) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	// Deserialize `Authority`:
+	if obj.Authority, err = readNonZeroPublicKey(decoder); err != nil {
+		return err
+	}
+	// Deserialize `HookProgramID`:
+	obj.HookProgramID, err = readNonZeroPublicKey(decoder)
+	return err
+}
+
+// NewInitializeTransferHookInstruction declares a new InitializeTransferHook instruction with the provided parameters and accounts.
+func NewInitializeTransferHookInstruction(
+	// Parameters:
+	authority *solana.PublicKey,
+	hookProgramID *solana.PublicKey,
+	// Accounts:
+	mint solana.PublicKey,
+) *InitializeTransferHook {
+	inst := NewInitializeTransferHookInstructionBuilder().
+		SetMintAccount(mint)
+	if authority != nil {
+		inst.SetAuthority(*authority)
+	}
+	if hookProgramID != nil {
+		inst.SetHookProgramID(*hookProgramID)
+	}
+	return inst
+}
@@ -0,0 +1,445 @@
+package token2022
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/programs/token"
+	solana "github.com/scatkit/pumpdexer/solana"
+)
+
+// ExtensionType identifies an extension in the TLV data of a mint or account.
+type ExtensionType uint16
+
+const (
+	// Used as padding if the account size would otherwise be 355, same as a multisig.
+	ExtensionUninitialized ExtensionType = iota
+	// Includes transfer fee rate info and accompanying authorities to withdraw and set the fee.
+	ExtensionTransferFeeConfig
+	// Includes withheld transfer fees.
+	ExtensionTransferFeeAmount
+	// Includes an optional mint close authority.
+	ExtensionMintCloseAuthority
+	// Auditor configuration for confidential transfers.
+	ExtensionConfidentialTransferMint
+	// State for confidential transfers.
+	ExtensionConfidentialTransferAccount
+	// Specifies the default Account::state for new Accounts.
+	ExtensionDefaultAccountState
+	// Indicates that the Account owner authority cannot be changed.
+	ExtensionImmutableOwner
+	// Require inbound transfers to have memo.
+	ExtensionMemoTransfer
+	// Indicates that the tokens from this mint can't be transferred.
+	ExtensionNonTransferable
+	// Tokens accrue interest over time.
+	ExtensionInterestBearingConfig
+	// Locks privileged token operations from happening via CPI.
+	ExtensionCpiGuard
+	// Includes an optional permanent delegate.
+	ExtensionPermanentDelegate
+	// Indicates that the tokens in this account belong to a non-transferable mint.
+	ExtensionNonTransferableAccount
+	// Mint requires a CPI to a program implementing the "transfer hook" interface.
+	ExtensionTransferHook
+	// Indicates that the tokens in this account belong to a mint with a transfer hook.
+	ExtensionTransferHookAccount
+	// Includes encrypted withheld fees and the encryption public key that they are encrypted under.
+	ExtensionConfidentialTransferFeeConfig
+	// Includes confidential withheld transfer fees.
+	ExtensionConfidentialTransferFeeAmount
+	// Mint contains a pointer to another account (or the same account) that holds metadata.
+	ExtensionMetadataPointer
+	// Mint contains token-metadata.
+	ExtensionTokenMetadata
+	// Mint contains a pointer to another account (or the same account) that holds group configurations.
+	ExtensionGroupPointer
+	// Mint contains token group configurations.
+	ExtensionTokenGroup
+	// Mint contains a pointer to another account (or the same account) that holds group member configurations.
+	ExtensionGroupMemberPointer
+	// Mint contains token group member configurations.
+	ExtensionTokenGroupMember
+	// Mint allowing the minting and burning of confidential tokens.
+	ExtensionConfidentialMintBurn
+	// Tokens whose UI amount is scaled by a given amount.
+	ExtensionScaledUiAmount
+	// Tokens where minting / burning / transferring can be paused.
+	ExtensionPausable
+	// Indicates that the account belongs to a pausable mint.
+	ExtensionPausableAccount
+)
+
+func (t ExtensionType) String() string {
+	switch t {
+	case ExtensionUninitialized:
+		return "Uninitialized"
+	case ExtensionTransferFeeConfig:
+		return "TransferFeeConfig"
+	case ExtensionTransferFeeAmount:
+		return "TransferFeeAmount"
+	case ExtensionMintCloseAuthority:
+		return "MintCloseAuthority"
+	case ExtensionConfidentialTransferMint:
+		return "ConfidentialTransferMint"
+	case ExtensionConfidentialTransferAccount:
+		return "ConfidentialTransferAccount"
+	case ExtensionDefaultAccountState:
+		return "DefaultAccountState"
+	case ExtensionImmutableOwner:
+		return "ImmutableOwner"
+	case ExtensionMemoTransfer:
+		return "MemoTransfer"
+	case ExtensionNonTransferable:
+		return "NonTransferable"
+	case ExtensionInterestBearingConfig:
+		return "InterestBearingConfig"
+	case ExtensionCpiGuard:
+		return "CpiGuard"
+	case ExtensionPermanentDelegate:
+		return "PermanentDelegate"
+	case ExtensionNonTransferableAccount:
+		return "NonTransferableAccount"
+	case ExtensionTransferHook:
+		return "TransferHook"
+	case ExtensionTransferHookAccount:
+		return "TransferHookAccount"
+	case ExtensionConfidentialTransferFeeConfig:
+		return "ConfidentialTransferFeeConfig"
+	case ExtensionConfidentialTransferFeeAmount:
+		return "ConfidentialTransferFeeAmount"
+	case ExtensionMetadataPointer:
+		return "MetadataPointer"
+	case ExtensionTokenMetadata:
+		return "TokenMetadata"
+	case ExtensionGroupPointer:
+		return "GroupPointer"
+	case ExtensionTokenGroup:
+		return "TokenGroup"
+	case ExtensionGroupMemberPointer:
+		return "GroupMemberPointer"
+	case ExtensionTokenGroupMember:
+		return "TokenGroupMember"
+	case ExtensionConfidentialMintBurn:
+		return "ConfidentialMintBurn"
+	case ExtensionScaledUiAmount:
+		return "ScaledUiAmount"
+	case ExtensionPausable:
+		return "Pausable"
+	case ExtensionPausableAccount:
+		return "PausableAccount"
+	default:
+		return fmt.Sprintf("ExtensionType(%d)", uint16(t))
+	}
+}
+
+// AccountType is the byte right after the base state (padded to the size of an account)
+// telling whether the extended data is a mint or an account.
+type AccountType uint8
+
+const (
+	AccountTypeUninitialized AccountType = iota
+	AccountTypeMint
+	AccountTypeAccount
+)
+
+// Extension is a raw TLV entry: the type, then the value as stored on chain.
+type Extension struct {
+	Type ExtensionType
+	Data []byte
+}
+
+// parseExtensions returns the TLV entries of the given mint or account data.
+// Data without anything past the base state has no extensions.
+func parseExtensions(data []byte, accountType AccountType) ([]Extension, error) {
+	if len(data) <= token.ACCOUNT_SIZE {
+		return nil, nil
+	}
+	if got := AccountType(data[token.ACCOUNT_SIZE]); got != accountType {
+		return nil, fmt.Errorf("invalid account type %d, expected %d", got, accountType)
+	}
+	var extensions []Extension
+	tlv := data[token.ACCOUNT_SIZE+1:]
+	for len(tlv) >= 4 {
+		extensionType := ExtensionType(binary.LittleEndian.Uint16(tlv[0:2]))
+		length := int(binary.LittleEndian.Uint16(tlv[2:4]))
+		if extensionType == ExtensionUninitialized {
+			// The rest is padding.
+			break
+		}
+		if len(tlv) < 4+length {
+			return nil, fmt.Errorf("extension %s is truncated: %d bytes, expected %d", extensionType, len(tlv)-4, length)
+		}
+		extensions = append(extensions, Extension{Type: extensionType, Data: tlv[4 : 4+length]})
+		tlv = tlv[4+length:]
+	}
+	return extensions, nil
+}
+
+// TransferFee is a transfer fee schedule, effective from its epoch.
+type TransferFee struct {
+	// First epoch where the transfer fee takes effect.
+	Epoch uint64
+
+	// Maximum fee assessed on transfers, expressed as an amount of tokens.
+	MaximumFee uint64
+
+	// Amount of transfer collected as fees, expressed as basis points of the transfer amount,
+	// ie. increments of 0.01%.
+	TransferFeeBasisPoints uint16
+}
+
+func (fee *TransferFee) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	if fee.Epoch, err = dec.ReadUint64(bin.LE); err != nil {
+		return err
+	}
+	if fee.MaximumFee, err = dec.ReadUint64(bin.LE); err != nil {
+		return err
+	}
+	fee.TransferFeeBasisPoints, err = dec.ReadUint16(bin.LE)
+	return err
+}
+
+func (fee TransferFee) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = encoder.WriteUint64(fee.Epoch, bin.LE); err != nil {
+		return err
+	}
+	if err = encoder.WriteUint64(fee.MaximumFee, bin.LE); err != nil {
+		return err
+	}
+	return encoder.WriteUint16(fee.TransferFeeBasisPoints, bin.LE)
+}
+
+// TransferFeeConfig is the state of the `TransferFeeConfig` mint extension.
+type TransferFeeConfig struct {
+	// Optional authority to set the fee.
+	TransferFeeConfigAuthority *solana.PublicKey
+
+	// Withdraw from mint instructions must be signed by this key.
+	WithdrawWithheldAuthority *solana.PublicKey
+
+	// Withheld transfer fee tokens that have been moved to the mint for withdrawal.
+	WithheldAmount uint64
+
+	// Older transfer fee, used if the current epoch < NewerTransferFee.Epoch.
+	OlderTransferFee TransferFee
+
+	// Newer transfer fee, used if the current epoch >= NewerTransferFee.Epoch.
+	NewerTransferFee TransferFee
+}
+
+func (cfg *TransferFeeConfig) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	if cfg.TransferFeeConfigAuthority, err = readNonZeroPublicKey(dec); err != nil {
+		return fmt.Errorf("failed to decode TransferFeeConfigAuthority: %w", err)
+	}
+	if cfg.WithdrawWithheldAuthority, err = readNonZeroPublicKey(dec); err != nil {
+		return fmt.Errorf("failed to decode WithdrawWithheldAuthority: %w", err)
+	}
+	if cfg.WithheldAmount, err = dec.ReadUint64(bin.LE); err != nil {
+		return fmt.Errorf("failed to decode WithheldAmount: %w", err)
+	}
+	if err = cfg.OlderTransferFee.UnmarshalWithDecoder(dec); err != nil {
+		return fmt.Errorf("failed to decode OlderTransferFee: %w", err)
+	}
+	if err = cfg.NewerTransferFee.UnmarshalWithDecoder(dec); err != nil {
+		return fmt.Errorf("failed to decode NewerTransferFee: %w", err)
+	}
+	return nil
+}
+
+func (cfg TransferFeeConfig) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = writeNonZeroPublicKey(encoder, cfg.TransferFeeConfigAuthority); err != nil {
+		return err
+	}
+	if err = writeNonZeroPublicKey(encoder, cfg.WithdrawWithheldAuthority); err != nil {
+		return err
+	}
+	if err = encoder.WriteUint64(cfg.WithheldAmount, bin.LE); err != nil {
+		return err
+	}
+	if err = cfg.OlderTransferFee.MarshalWithEncoder(encoder); err != nil {
+		return err
+	}
+	return cfg.NewerTransferFee.MarshalWithEncoder(encoder)
+}
+
+// TransferHook is the state of the `TransferHook` mint extension.
+type TransferHook struct {
+	// Authority that can set the transfer hook program id.
+	Authority *solana.PublicKey
+
+	// Program that authorizes the transfer.
+	ProgramID *solana.PublicKey
+}
+
+func (hook *TransferHook) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	if hook.Authority, err = readNonZeroPublicKey(dec); err != nil {
+		return err
+	}
+	hook.ProgramID, err = readNonZeroPublicKey(dec)
+	return err
+}
+
+func (hook TransferHook) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = writeNonZeroPublicKey(encoder, hook.Authority); err != nil {
+		return err
+	}
+	return writeNonZeroPublicKey(encoder, hook.ProgramID)
+}
+
+// MetadataPointer is the state of the `MetadataPointer` mint extension.
+type MetadataPointer struct {
+	// Authority that can set the metadata address.
+	Authority *solana.PublicKey
+
+	// Account address that holds the metadata.
+	MetadataAddress *solana.PublicKey
+}
+
+func (pointer *MetadataPointer) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	if pointer.Authority, err = readNonZeroPublicKey(dec); err != nil {
+		return err
+	}
+	pointer.MetadataAddress, err = readNonZeroPublicKey(dec)
+	return err
+}
+
+func (pointer MetadataPointer) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = writeNonZeroPublicKey(encoder, pointer.Authority); err != nil {
+		return err
+	}
+	return writeNonZeroPublicKey(encoder, pointer.MetadataAddress)
+}
+
+// TokenMetadata is the state of the `TokenMetadata` mint extension,
+// as defined by the token-metadata interface.
+type TokenMetadata struct {
+	// The authority that can sign to update the metadata.
+	UpdateAuthority *solana.PublicKey
+
+	// The associated mint, used to counter spoofing to be sure that metadata
+	// belongs to a particular mint.
+	Mint solana.PublicKey
+
+	// The longer name of the token.
+	Name string
+
+	// The shortened symbol for the token.
+	Symbol string
+
+	// The URI pointing to richer metadata.
+	URI string
+
+	// Any additional metadata about the token as key-value pairs.
+	AdditionalMetadata [][2]string
+}
+
+func (metadata *TokenMetadata) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	if metadata.UpdateAuthority, err = readNonZeroPublicKey(dec); err != nil {
+		return fmt.Errorf("failed to decode UpdateAuthority: %w", err)
+	}
+	if _, err = dec.Read(metadata.Mint[:]); err != nil {
+		return fmt.Errorf("failed to decode Mint: %w", err)
+	}
+	if metadata.Name, err = readBorshString(dec); err != nil {
+		return fmt.Errorf("failed to decode Name: %w", err)
+	}
+	if metadata.Symbol, err = readBorshString(dec); err != nil {
+		return fmt.Errorf("failed to decode Symbol: %w", err)
+	}
+	if metadata.URI, err = readBorshString(dec); err != nil {
+		return fmt.Errorf("failed to decode URI: %w", err)
+	}
+	count, err := dec.ReadUint32(bin.LE)
+	if err != nil {
+		return fmt.Errorf("failed to decode AdditionalMetadata: %w", err)
+	}
+	if int(count) > dec.Remaining()/8 {
+		return fmt.Errorf("failed to decode AdditionalMetadata: %d entries don't fit in %d bytes", count, dec.Remaining())
+	}
+	metadata.AdditionalMetadata = nil
+	for i := uint32(0); i < count; i++ {
+		var pair [2]string
+		if pair[0], err = readBorshString(dec); err != nil {
+			return fmt.Errorf("failed to decode AdditionalMetadata key: %w", err)
+		}
+		if pair[1], err = readBorshString(dec); err != nil {
+			return fmt.Errorf("failed to decode AdditionalMetadata value: %w", err)
+		}
+		metadata.AdditionalMetadata = append(metadata.AdditionalMetadata, pair)
+	}
+	return nil
+}
+
+func (metadata TokenMetadata) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = writeNonZeroPublicKey(encoder, metadata.UpdateAuthority); err != nil {
+		return err
+	}
+	if err = encoder.WriteBytes(metadata.Mint[:], false); err != nil {
+		return err
+	}
+	for _, s := range []string{metadata.Name, metadata.Symbol, metadata.URI} {
+		if err = writeBorshString(encoder, s); err != nil {
+			return err
+		}
+	}
+	if err = encoder.WriteUint32(uint32(len(metadata.AdditionalMetadata)), bin.LE); err != nil {
+		return err
+	}
+	for _, pair := range metadata.AdditionalMetadata {
+		if err = writeBorshString(encoder, pair[0]); err != nil {
+			return err
+		}
+		if err = writeBorshString(encoder, pair[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Pausable is the state of the `Pausable` mint extension.
+type Pausable struct {
+	// Authority that can pause or resume activity on the mint.
+	Authority *solana.PublicKey
+
+	// Whether minting / transferring / burning tokens is paused.
+	Paused bool
+}
+
+func (pausable *Pausable) UnmarshalWithDecoder(dec *bin.Decoder) (err error) {
+	if pausable.Authority, err = readNonZeroPublicKey(dec); err != nil {
+		return err
+	}
+	pausable.Paused, err = dec.ReadBool()
+	return err
+}
+
+func (pausable Pausable) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	if err = writeNonZeroPublicKey(encoder, pausable.Authority); err != nil {
+		return err
+	}
+	return encoder.WriteBool(pausable.Paused)
+}
+
+func readBorshString(dec *bin.Decoder) (string, error) {
+	length, err := dec.ReadUint32(bin.LE)
+	if err != nil {
+		return "", err
+	}
+	if int(length) > dec.Remaining() {
+		return "", fmt.Errorf("string of %d bytes doesn't fit in %d bytes", length, dec.Remaining())
+	}
+	data, err := dec.ReadNBytes(int(length))
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func writeBorshString(encoder *bin.Encoder, s string) error {
+	if err := encoder.WriteUint32(uint32(len(s)), bin.LE); err != nil {
+		return err
+	}
+	return encoder.WriteBytes([]byte(s), false)
+}
@@ -0,0 +1,103 @@
+package token2022
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// MAX_FEE_BASIS_POINTS is the maximum transfer fee, in basis points: 100%.
+const MAX_FEE_BASIS_POINTS = 10_000
+
+const oneInBasisPoints = 10_000
+
+// Calculate returns the fee charged on a transfer of the given amount:
+// the amount times the basis points, rounded up, capped at MaximumFee.
+func (fee TransferFee) Calculate(preFeeAmount uint64) uint64 {
+	if fee.TransferFeeBasisPoints == 0 || preFeeAmount == 0 {
+		return 0
+	}
+	basisPoints := uint64(fee.TransferFeeBasisPoints)
+	if basisPoints > MAX_FEE_BASIS_POINTS {
+		basisPoints = MAX_FEE_BASIS_POINTS
+	}
+	// ceil(amount * bps / 10_000) on 128 bits; it can't be more than the amount.
+	hi, lo := bits.Mul64(preFeeAmount, basisPoints)
+	lo, carry := bits.Add64(lo, oneInBasisPoints-1, 0)
+	raw, _ := bits.Div64(hi+carry, lo, oneInBasisPoints)
+	if raw > fee.MaximumFee {
+		return fee.MaximumFee
+	}
+	return raw
+}
+
+// CalculateInverse returns the amount to transfer so that the destination receives
+// the given amount once the fee is withheld.
+// It fails when that amount doesn't fit in a u64, or can't be reached at a 100% fee.
+func (fee TransferFee) CalculateInverse(postFeeAmount uint64) (uint64, error) {
+	if fee.TransferFeeBasisPoints == 0 || postFeeAmount == 0 {
+		return postFeeAmount, nil
+	}
+	if fee.TransferFeeBasisPoints >= MAX_FEE_BASIS_POINTS {
+		// Everything is withheld up to the maximum fee.
+		return addFee(postFeeAmount, fee.MaximumFee)
+	}
+	// ceil(amount * 10_000 / (10_000 - bps)) on 128 bits.
+	denominator := uint64(oneInBasisPoints - fee.TransferFeeBasisPoints)
+	hi, lo := bits.Mul64(postFeeAmount, oneInBasisPoints)
+	lo, carry := bits.Add64(lo, denominator-1, 0)
+	hi += carry
+	if hi >= denominator {
+		// Way above the maximum fee anyway.
+		return addFee(postFeeAmount, fee.MaximumFee)
+	}
+	raw, _ := bits.Div64(hi, lo, denominator)
+	if raw-postFeeAmount >= fee.MaximumFee {
+		return addFee(postFeeAmount, fee.MaximumFee)
+	}
+	return raw, nil
+}
+
+func addFee(amount, fee uint64) (uint64, error) {
+	sum, carry := bits.Add64(amount, fee, 0)
+	if carry != 0 {
+		return 0, fmt.Errorf("amount %d plus fee %d overflows", amount, fee)
+	}
+	return sum, nil
+}
+
+// GetEpochFee returns the fee schedule in effect at the given epoch.
+func (cfg *TransferFeeConfig) GetEpochFee(epoch uint64) TransferFee {
+	if epoch >= cfg.NewerTransferFee.Epoch {
+		return cfg.NewerTransferFee
+	}
+	return cfg.OlderTransferFee
+}
+
+// CalculateEpochFee returns the fee charged at the given epoch on a transfer of the given amount.
+func (cfg *TransferFeeConfig) CalculateEpochFee(epoch uint64, preFeeAmount uint64) uint64 {
+	return cfg.GetEpochFee(epoch).Calculate(preFeeAmount)
+}
+
+// TransferFee returns the fee withheld at the given epoch on a transfer of the given amount
+// of this mint, zero if the mint has no transfer fee.
+func (mint *Mint) TransferFee(epoch uint64, amount uint64) uint64 {
+	if mint.TransferFeeConfig == nil {
+		return 0
+	}
+	return mint.TransferFeeConfig.CalculateEpochFee(epoch, amount)
+}
+
+// AmountAfterTransferFee returns what the destination receives at the given epoch
+// when the given amount of this mint is sent (eg. the output of a swap paid out of a vault).
+func (mint *Mint) AmountAfterTransferFee(epoch uint64, amount uint64) uint64 {
+	return amount - mint.TransferFee(epoch, amount)
+}
+
+// AmountBeforeTransferFee returns how much to send at the given epoch for the destination
+// to receive the given amount of this mint (eg. the input of a swap paid into a vault).
+func (mint *Mint) AmountBeforeTransferFee(epoch uint64, received uint64) (uint64, error) {
+	if mint.TransferFeeConfig == nil {
+		return received, nil
+	}
+	return mint.TransferFeeConfig.GetEpochFee(epoch).CalculateInverse(received)
+}
@@ -0,0 +1,109 @@
+package token2022
+
+import (
+	"math"
+	"testing"
+
+	"github.com/scatkit/pumpdexer/programs/token"
+	"github.com/scatkit/pumpdexer/solana"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferFee_Calculate(t *testing.T) {
+	fee := TransferFee{MaximumFee: 5_000, TransferFeeBasisPoints: 100}
+
+	require.Equal(t, uint64(0), fee.Calculate(0))
+	require.Equal(t, uint64(1), fee.Calculate(1), "rounded up")
+	require.Equal(t, uint64(10), fee.Calculate(1_000))
+	require.Equal(t, uint64(11), fee.Calculate(1_001))
+	require.Equal(t, uint64(5_000), fee.Calculate(math.MaxUint64), "capped")
+	require.Equal(t, uint64(0), TransferFee{MaximumFee: 5_000}.Calculate(1_000))
+
+	full := TransferFee{MaximumFee: math.MaxUint64, TransferFeeBasisPoints: MAX_FEE_BASIS_POINTS}
+	require.Equal(t, uint64(math.MaxUint64), full.Calculate(math.MaxUint64))
+}
+
+func TestTransferFee_CalculateInverse(t *testing.T) {
+	fees := []TransferFee{
+		{MaximumFee: 5_000, TransferFeeBasisPoints: 100},
+		{MaximumFee: math.MaxUint64, TransferFeeBasisPoints: 1},
+		{MaximumFee: math.MaxUint64, TransferFeeBasisPoints: 9_999},
+		{MaximumFee: 3, TransferFeeBasisPoints: MAX_FEE_BASIS_POINTS},
+	}
+	for _, fee := range fees {
+		for _, received := range []uint64{0, 1, 99, 1_000, 123_456_789, 1 << 40} {
+			sent, err := fee.CalculateInverse(received)
+			require.NoError(t, err)
+			require.Equal(t, received, sent-fee.Calculate(sent), "fee %+v, received %d", fee, received)
+		}
+	}
+
+	_, err := TransferFee{MaximumFee: 10, TransferFeeBasisPoints: 100}.CalculateInverse(math.MaxUint64)
+	require.Error(t, err)
+	_, err = TransferFee{MaximumFee: math.MaxUint64, TransferFeeBasisPoints: 5_000}.CalculateInverse(math.MaxUint64/2 + 1)
+	require.Error(t, err)
+}
+
+func TestMint_TransferFeeQuotes(t *testing.T) {
+	mint := &Mint{TransferFeeConfig: &TransferFeeConfig{
+		OlderTransferFee: TransferFee{Epoch: 0, MaximumFee: 1_000, TransferFeeBasisPoints: 100},
+		NewerTransferFee: TransferFee{Epoch: 500, MaximumFee: 1_000, TransferFeeBasisPoints: 300},
+	}}
+
+	require.Equal(t, uint64(9_900), mint.AmountAfterTransferFee(499, 10_000))
+	require.Equal(t, uint64(9_700), mint.AmountAfterTransferFee(500, 10_000))
+	require.Equal(t, uint64(100), mint.TransferFee(10, 10_000))
+
+	sent, err := mint.AmountBeforeTransferFee(500, 9_700)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10_000), sent)
+
+	plain := &Mint{Mint: token.Mint{MintAuthority: &solana.PublicKey{}}}
+	require.Equal(t, uint64(10_000), plain.AmountAfterTransferFee(500, 10_000))
+	sent, err = plain.AmountBeforeTransferFee(500, 10_000)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10_000), sent)
+}
+
+func TestMint_Risks(t *testing.T) {
+	authority := solana.NewWallet().PublicKey()
+
+	require.Empty(t, (&Mint{}).Risks())
+
+	frozen := token.Frozen
+	mint := &Mint{
+		Mint:              token.Mint{MintAuthority: &authority},
+		PermanentDelegate: &authority,
+		TransferHook:      &TransferHook{ProgramID: &authority},
+		TransferFeeConfig: &TransferFeeConfig{
+			TransferFeeConfigAuthority: &authority,
+			NewerTransferFee:           TransferFee{Epoch: 900, TransferFeeBasisPoints: 10},
+		},
+		DefaultAccountState: &frozen,
+		Pausable:            &Pausable{Paused: true},
+		Extensions: []Extension{
+			{Type: ExtensionMetadataPointer},
+			{Type: ExtensionConfidentialTransferMint},
+		},
+	}
+	require.Equal(t, []Risk{
+		RiskMintAuthority,
+		RiskPermanentDelegate,
+		RiskTransferHook,
+		RiskTransferFee,
+		RiskTransferFeeAuthority,
+		RiskDefaultAccountFrozen,
+		RiskPaused,
+		RiskUnknownExtension,
+	}, mint.Risks())
+	require.True(t, mint.HasRisk(RiskPermanentDelegate))
+	require.False(t, mint.HasRisk(RiskFreezeAuthority))
+
+	// Metadata alone is harmless, and so is a renounced, zero fee.
+	safe := &Mint{
+		TransferFeeConfig: &TransferFeeConfig{},
+		TransferHook:      &TransferHook{},
+		Extensions:        []Extension{{Type: ExtensionTransferFeeConfig}, {Type: ExtensionTokenMetadata}},
+	}
+	require.Empty(t, safe.Risks())
+}
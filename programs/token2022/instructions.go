@@ -0,0 +1,374 @@
+// The Token-2022 program (Token Extensions) on the Solana blockchain.
+// It is a superset of the Token program: the base instructions share their IDs,
+// layouts and accounts (build them with the `token` package and target them here with FromToken),
+// mints and accounts carry extensions after the base layout.
+// It also builds and decodes the token-metadata interface instructions the program implements
+// for the metadata stored in the mint (InitializeTokenMetadata, UpdateTokenMetadataField, ...).
+
+package token2022
+
+import (
+	"bytes"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/programs/token"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+var ProgramID solana.PublicKey = solana.Token2022ProgramID
+
+func SetProgramID(pubkey solana.PublicKey) {
+	ProgramID = pubkey
+	solana.RegisterInstructionDecoder(ProgramID, registryDecodeInstruction)
+}
+
+const ProgramName = "Token2022"
+
+func init() {
+	if !ProgramID.IsZero() {
+		solana.RegisterInstructionDecoder(ProgramID, registryDecodeInstruction)
+	}
+}
+
+// Instructions added by Token-2022, following the base Token instructions
+// (token.Instruction_InitializeMint ... token.Instruction_InitializeMint2).
+const (
+	// Gets the required size of an account for the given mint as a little-endian `u64`.
+	Instruction_GetAccountDataSize uint8 = iota + token.Instruction_InitializeMint2 + 1
+
+	// Initialize the Immutable Owner extension for the given token account.
+	Instruction_InitializeImmutableOwner
+
+	// Convert an Amount of tokens to a UiAmount `string`, using the given mint.
+	Instruction_AmountToUiAmount
+
+	// Convert a UiAmount of tokens to a little-endian `u64` raw Amount, using the given mint.
+	Instruction_UiAmountToAmount
+
+	// Initialize the close account authority on a new mint.
+	Instruction_InitializeMintCloseAuthority
+
+	// The common instruction prefix for Transfer Fee extension instructions.
+	Instruction_TransferFeeExtension
+
+	// The common instruction prefix for Confidential Transfer extension instructions.
+	Instruction_ConfidentialTransferExtension
+
+	// The common instruction prefix for Default Account State extension instructions.
+	Instruction_DefaultAccountStateExtension
+
+	// Check to see if a token account is large enough for a list of ExtensionTypes,
+	// and if not, use reallocation to increase the data size.
+	Instruction_Reallocate
+
+	// The common instruction prefix for Memo Transfer account extension instructions.
+	Instruction_MemoTransferExtension
+
+	// Creates the native mint.
+	Instruction_CreateNativeMint
+
+	// Initialize the non transferable extension for the given mint account.
+	Instruction_InitializeNonTransferableMint
+
+	// The common instruction prefix for Interest Bearing extension instructions.
+	Instruction_InterestBearingMintExtension
+
+	// The common instruction prefix for CPI Guard account extension instructions.
+	Instruction_CpiGuardExtension
+
+	// Initialize the permanent delegate on a new mint.
+	Instruction_InitializePermanentDelegate
+
+	// The common instruction prefix for transfer hook extension instructions.
+	Instruction_TransferHookExtension
+
+	// The common instruction prefix for the confidential transfer fee extension instructions.
+	Instruction_ConfidentialTransferFeeExtension
+
+	// Transfer all excess lamports (above rent exemption) out of an account.
+	Instruction_WithdrawExcessLamports
+
+	// The common instruction prefix for metadata pointer extension instructions.
+	Instruction_MetadataPointerExtension
+
+	// The common instruction prefix for group pointer extension instructions.
+	Instruction_GroupPointerExtension
+
+	// The common instruction prefix for group member pointer extension instructions.
+	Instruction_GroupMemberPointerExtension
+
+	// The common instruction prefix for confidential mint-burn extension instructions.
+	Instruction_ConfidentialMintBurnExtension
+
+	// The common instruction prefix for scaled UI amount extension instructions.
+	Instruction_ScaledUiAmountExtension
+
+	// The common instruction prefix for pausable extension instructions.
+	Instruction_PausableExtension
+)
+
+// InstructionIDToName returns the name of the instruction given its ID.
+func InstructionIDToName(id uint8) string {
+	switch id {
+	case Instruction_GetAccountDataSize:
+		return "GetAccountDataSize"
+	case Instruction_InitializeImmutableOwner:
+		return "InitializeImmutableOwner"
+	case Instruction_AmountToUiAmount:
+		return "AmountToUiAmount"
+	case Instruction_UiAmountToAmount:
+		return "UiAmountToAmount"
+	case Instruction_InitializeMintCloseAuthority:
+		return "InitializeMintCloseAuthority"
+	case Instruction_TransferFeeExtension:
+		return "TransferFeeExtension"
+	case Instruction_ConfidentialTransferExtension:
+		return "ConfidentialTransferExtension"
+	case Instruction_DefaultAccountStateExtension:
+		return "DefaultAccountStateExtension"
+	case Instruction_Reallocate:
+		return "Reallocate"
+	case Instruction_MemoTransferExtension:
+		return "MemoTransferExtension"
+	case Instruction_CreateNativeMint:
+		return "CreateNativeMint"
+	case Instruction_InitializeNonTransferableMint:
+		return "InitializeNonTransferableMint"
+	case Instruction_InterestBearingMintExtension:
+		return "InterestBearingMintExtension"
+	case Instruction_CpiGuardExtension:
+		return "CpiGuardExtension"
+	case Instruction_InitializePermanentDelegate:
+		return "InitializePermanentDelegate"
+	case Instruction_TransferHookExtension:
+		return "TransferHookExtension"
+	case Instruction_ConfidentialTransferFeeExtension:
+		return "ConfidentialTransferFeeExtension"
+	case Instruction_WithdrawExcessLamports:
+		return "WithdrawExcessLamports"
+	case Instruction_MetadataPointerExtension:
+		return "MetadataPointerExtension"
+	case Instruction_GroupPointerExtension:
+		return "GroupPointerExtension"
+	case Instruction_GroupMemberPointerExtension:
+		return "GroupMemberPointerExtension"
+	case Instruction_ConfidentialMintBurnExtension:
+		return "ConfidentialMintBurnExtension"
+	case Instruction_ScaledUiAmountExtension:
+		return "ScaledUiAmountExtension"
+	case Instruction_PausableExtension:
+		return "PausableExtension"
+	default:
+		return token.InstructionIDToName(id)
+	}
+}
+
+type Instruction struct {
+	bin.BaseVariant
+}
+
+// FromToken targets a base instruction built with the `token` package at the Token-2022 program.
+func FromToken(inst *token.Instruction) *Instruction {
+	return &Instruction{BaseVariant: inst.BaseVariant}
+}
+
+// The variants are listed in the order of their instruction ID:
+// the position in the list is the type ID.
+var InstructionImplDef = bin.NewVariantDefinition(
+	bin.Uint8TypeIDEncoding,
+	[]bin.VariantType{
+		{"InitializeMint", (*token.InitializeMint)(nil)},
+		{"InitializeAccount", (*token.InitializeAccount)(nil)},
+		{"InitializeMultisig", (*token.InitializeMultisig)(nil)},
+		{"Transfer", (*token.Transfer)(nil)},
+		{"Approve", (*token.Approve)(nil)},
+		{"Revoke", (*token.Revoke)(nil)},
+		{"SetAuthority", (*token.SetAuthority)(nil)},
+		{"MintTo", (*token.MintTo)(nil)},
+		{"Burn", (*token.Burn)(nil)},
+		{"CloseAccount", (*token.CloseAccount)(nil)},
+		{"FreezeAccount", (*token.FreezeAccount)(nil)},
+		{"ThawAccount", (*token.ThawAccount)(nil)},
+		{"TransferChecked", (*token.TransferChecked)(nil)},
+		{"ApproveChecked", (*token.ApproveChecked)(nil)},
+		{"MintToChecked", (*token.MintToChecked)(nil)},
+		{"BurnChecked", (*token.BurnChecked)(nil)},
+		{"InitializeAccount2", (*token.InitializeAccount2)(nil)},
+		{"SyncNative", (*token.SyncNative)(nil)},
+		{"InitializeAccount3", (*token.InitializeAccount3)(nil)},
+		{"InitializeMultisig2", (*token.InitializeMultisig2)(nil)},
+		{"InitializeMint2", (*token.InitializeMint2)(nil)},
+		{"GetAccountDataSize", (*Unknown)(nil)},
+		{"InitializeImmutableOwner", (*InitializeImmutableOwner)(nil)},
+		{"AmountToUiAmount", (*Unknown)(nil)},
+		{"UiAmountToAmount", (*Unknown)(nil)},
+		{"InitializeMintCloseAuthority", (*InitializeMintCloseAuthority)(nil)},
+		{"TransferFeeExtension", (*TransferFeeExtension)(nil)},
+		{"ConfidentialTransferExtension", (*Unknown)(nil)},
+		{"DefaultAccountStateExtension", (*Unknown)(nil)},
+		{"Reallocate", (*Unknown)(nil)},
+		{"MemoTransferExtension", (*Unknown)(nil)},
+		{"CreateNativeMint", (*Unknown)(nil)},
+		{"InitializeNonTransferableMint", (*InitializeNonTransferableMint)(nil)},
+		{"InterestBearingMintExtension", (*Unknown)(nil)},
+		{"CpiGuardExtension", (*Unknown)(nil)},
+		{"InitializePermanentDelegate", (*InitializePermanentDelegate)(nil)},
+		{"TransferHookExtension", (*TransferHookExtension)(nil)},
+		{"ConfidentialTransferFeeExtension", (*Unknown)(nil)},
+		{"WithdrawExcessLamports", (*Unknown)(nil)},
+		{"MetadataPointerExtension", (*MetadataPointerExtension)(nil)},
+		{"GroupPointerExtension", (*Unknown)(nil)},
+		{"GroupMemberPointerExtension", (*Unknown)(nil)},
+		{"ConfidentialMintBurnExtension", (*Unknown)(nil)},
+		{"ScaledUiAmountExtension", (*Unknown)(nil)},
+		{"PausableExtension", (*Unknown)(nil)},
+	},
+)
+
+func (inst *Instruction) ProgramID() solana.PublicKey {
+	return ProgramID
+}
+
+func (inst *Instruction) Accounts() (out []*solana.AccountMeta) {
+	return inst.Impl.(solana.AccountsGettable).GetAccounts()
+}
+
+func (inst *Instruction) Data() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := bin.NewBinEncoder(buf).Encode(inst); err != nil {
+		return nil, fmt.Errorf("unable to encode instruction: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (inst *Instruction) UnmarshalWithDecoder(decoder *bin.Decoder) error {
+	if ok, err := inst.unmarshalTokenMetadataInstruction(decoder); ok {
+		return err
+	}
+	return inst.BaseVariant.UnmarshalBinaryVariant(decoder, InstructionImplDef)
+}
+
+func (inst Instruction) MarshalWithEncoder(encoder *bin.Encoder) error {
+	if isTokenMetadataInstruction(inst.TypeID) {
+		if err := encoder.WriteBytes(inst.TypeID.Bytes(), false); err != nil {
+			return fmt.Errorf("unable to write token-metadata discriminator: %w", err)
+		}
+		return encoder.Encode(inst.Impl)
+	}
+	err := encoder.WriteUint8(inst.TypeID.Uint8())
+	if err != nil {
+		return fmt.Errorf("unable to write variant type: %w", err)
+	}
+	return encoder.Encode(inst.Impl)
+}
+
+func registryDecodeInstruction(accounts []*solana.AccountMeta, data []byte) (interface{}, error) {
+	inst, err := DecodeInstruction(accounts, data)
+	if err != nil {
+		return nil, err
+	}
+	return inst, nil
+}
+
+func DecodeInstruction(accounts []*solana.AccountMeta, data []byte) (*Instruction, error) {
+	inst := new(Instruction)
+	if err := bin.NewBinDecoder(data).Decode(inst); err != nil {
+		return nil, fmt.Errorf("unable to decode instruction: %w", err)
+	}
+	if v, ok := inst.Impl.(solana.AccountsSettable); ok {
+		err := v.SetAccounts(accounts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to set accounts for instruction: %w", err)
+		}
+	}
+	return inst, nil
+}
+
+// Unknown holds an instruction this package has no builder for: the data is kept as is.
+type Unknown struct {
+	Data []byte
+
+	solana.AccountMetaSlice `bin:"-" borsh_skip:"true"`
+}
+
+func (obj Unknown) MarshalWithEncoder(encoder *bin.Encoder) (err error) {
+	return encoder.WriteBytes(obj.Data, false)
+}
+
+func (obj *Unknown) UnmarshalWithDecoder(decoder *bin.Decoder) (err error) {
+	obj.Data, err = decoder.ReadNBytes(decoder.Remaining())
+	return err
+}
+
+// extensionVariant is the instruction of an extension family:
+// the byte after the instruction ID selects the instruction within the family.
+type extensionVariant struct {
+	bin.BaseVariant
+}
+
+func (ext *extensionVariant) unmarshal(decoder *bin.Decoder, def *bin.VariantDefinition) error {
+	return ext.BaseVariant.UnmarshalBinaryVariant(decoder, def)
+}
+
+func (ext extensionVariant) MarshalWithEncoder(encoder *bin.Encoder) error {
+	err := encoder.WriteUint8(ext.TypeID.Uint8())
+	if err != nil {
+		return fmt.Errorf("unable to write extension instruction type: %w", err)
+	}
+	return encoder.Encode(ext.Impl)
+}
+
+func (ext *extensionVariant) SetAccounts(accounts []*solana.AccountMeta) error {
+	if v, ok := ext.Impl.(solana.AccountsSettable); ok {
+		return v.SetAccounts(accounts)
+	}
+	return nil
+}
+
+func (ext extensionVariant) GetAccounts() []*solana.AccountMeta {
+	return ext.Impl.(solana.AccountsGettable).GetAccounts()
+}
+
+// writeOptionalPublicKey writes a `COption<Pubkey>` the way instruction data packs it: a 1-byte tag then the key when set.
+func writeOptionalPublicKey(encoder *bin.Encoder, key *solana.PublicKey) error {
+	if key == nil {
+		return encoder.WriteBool(false)
+	}
+	if err := encoder.WriteBool(true); err != nil {
+		return err
+	}
+	return encoder.WriteBytes(key[:], false)
+}
+
+func readOptionalPublicKey(decoder *bin.Decoder) (*solana.PublicKey, error) {
+	has, err := decoder.ReadBool()
+	if err != nil || !has {
+		return nil, err
+	}
+	var key solana.PublicKey
+	if _, err := decoder.Read(key[:]); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// writeNonZeroPublicKey writes an `OptionalNonZeroPubkey`: 32 bytes, all zeros meaning None.
+func writeNonZeroPublicKey(encoder *bin.Encoder, key *solana.PublicKey) error {
+	var value solana.PublicKey
+	if key != nil {
+		value = *key
+	}
+	return encoder.WriteBytes(value[:], false)
+}
+
+func readNonZeroPublicKey(decoder *bin.Decoder) (*solana.PublicKey, error) {
+	var key solana.PublicKey
+	if _, err := decoder.Read(key[:]); err != nil {
+		return nil, err
+	}
+	if key.IsZero() {
+		return nil, nil
+	}
+	return &key, nil
+}
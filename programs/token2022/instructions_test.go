@@ -0,0 +1,217 @@
+package token2022
+
+import (
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/programs/token"
+	"github.com/scatkit/pumpdexer/solana"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_RoundTrip(t *testing.T) {
+	source := solana.NewWallet().PublicKey()
+	destination := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+	owner := solana.NewWallet().PublicKey()
+	authority := solana.NewWallet().PublicKey()
+	cosigners := []solana.PublicKey{solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()}
+
+	transfer, err := token.NewTransferCheckedInstruction(5, 6, source, mint, destination, owner, nil).ValidateAndBuild()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		build    func() (*Instruction, error)
+		wantData []byte
+	}{
+		{
+			name:     "TransferChecked from the token package",
+			build:    func() (*Instruction, error) { return FromToken(transfer), nil },
+			wantData: []byte{token.Instruction_TransferChecked, 5, 0, 0, 0, 0, 0, 0, 0, 6},
+		},
+		{
+			name:     "InitializeMintCloseAuthority",
+			build:    NewInitializeMintCloseAuthorityInstruction(&authority, mint).ValidateAndBuild,
+			wantData: append([]byte{Instruction_InitializeMintCloseAuthority, 1}, authority[:]...),
+		},
+		{
+			name:     "InitializeMintCloseAuthority without authority",
+			build:    NewInitializeMintCloseAuthorityInstruction(nil, mint).ValidateAndBuild,
+			wantData: []byte{Instruction_InitializeMintCloseAuthority, 0},
+		},
+		{
+			name:     "InitializeImmutableOwner",
+			build:    NewInitializeImmutableOwnerInstruction(source).ValidateAndBuild,
+			wantData: []byte{Instruction_InitializeImmutableOwner},
+		},
+		{
+			name:     "InitializeNonTransferableMint",
+			build:    NewInitializeNonTransferableMintInstruction(mint).ValidateAndBuild,
+			wantData: []byte{Instruction_InitializeNonTransferableMint},
+		},
+		{
+			name:     "InitializePermanentDelegate",
+			build:    NewInitializePermanentDelegateInstruction(authority, mint).ValidateAndBuild,
+			wantData: append([]byte{Instruction_InitializePermanentDelegate}, authority[:]...),
+		},
+		{
+			name:  "InitializeTransferFeeConfig",
+			build: NewInitializeTransferFeeConfigInstruction(&authority, nil, 250, 1_000, mint).ValidateAndBuild,
+			wantData: append(append([]byte{Instruction_TransferFeeExtension, TransferFee_InitializeTransferFeeConfig, 1}, authority[:]...),
+				0, 0xfa, 0, 0xe8, 0x03, 0, 0, 0, 0, 0, 0),
+		},
+		{
+			name:     "TransferCheckedWithFee multisig",
+			build:    NewTransferCheckedWithFeeInstruction(100, 6, 3, source, mint, destination, owner, cosigners).ValidateAndBuild,
+			wantData: []byte{Instruction_TransferFeeExtension, TransferFee_TransferCheckedWithFee, 100, 0, 0, 0, 0, 0, 0, 0, 6, 3, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:  "InitializeTransferHook",
+			build: NewInitializeTransferHookInstruction(nil, &owner, mint).ValidateAndBuild,
+			wantData: append(append([]byte{Instruction_TransferHookExtension, TransferHook_InitializeTransferHook},
+				make([]byte, 32)...), owner[:]...),
+		},
+		{
+			name:  "InitializeMetadataPointer",
+			build: NewInitializeMetadataPointerInstruction(&authority, &mint, mint).ValidateAndBuild,
+			wantData: append(append([]byte{Instruction_MetadataPointerExtension, MetadataPointer_InitializeMetadataPointer},
+				authority[:]...), mint[:]...),
+		},
+	}
+
+	start := uint64(1)
+	tests = append(tests, []struct {
+		name     string
+		build    func() (*Instruction, error)
+		wantData []byte
+	}{
+		{
+			name:  "InitializeTokenMetadata",
+			build: NewInitializeTokenMetadataInstruction("Pump", "P", "u", mint, authority, owner).ValidateAndBuild,
+			wantData: []byte{210, 225, 30, 162, 88, 184, 77, 141,
+				4, 0, 0, 0, 'P', 'u', 'm', 'p', 1, 0, 0, 0, 'P', 1, 0, 0, 0, 'u'},
+		},
+		{
+			name:     "UpdateTokenMetadataField name",
+			build:    NewUpdateTokenMetadataFieldInstruction(MetadataFieldName, "", "x", mint, authority).ValidateAndBuild,
+			wantData: []byte{221, 233, 49, 45, 181, 202, 220, 200, 0, 1, 0, 0, 0, 'x'},
+		},
+		{
+			name:     "UpdateTokenMetadataField key",
+			build:    NewUpdateTokenMetadataFieldInstruction(MetadataFieldKey, "k", "v", mint, authority).ValidateAndBuild,
+			wantData: []byte{221, 233, 49, 45, 181, 202, 220, 200, 3, 1, 0, 0, 0, 'k', 1, 0, 0, 0, 'v'},
+		},
+		{
+			name:     "RemoveTokenMetadataKey",
+			build:    NewRemoveTokenMetadataKeyInstruction(true, "k", mint, authority).ValidateAndBuild,
+			wantData: []byte{234, 18, 32, 56, 89, 141, 37, 181, 1, 1, 0, 0, 0, 'k'},
+		},
+		{
+			name:     "UpdateTokenMetadataAuthority",
+			build:    NewUpdateTokenMetadataAuthorityInstruction(&owner, mint, authority).ValidateAndBuild,
+			wantData: append([]byte{215, 228, 166, 228, 84, 100, 86, 123}, owner[:]...),
+		},
+		{
+			name:     "UpdateTokenMetadataAuthority to none",
+			build:    NewUpdateTokenMetadataAuthorityInstruction(nil, mint, authority).ValidateAndBuild,
+			wantData: append([]byte{215, 228, 166, 228, 84, 100, 86, 123}, make([]byte, 32)...),
+		},
+		{
+			name:     "EmitTokenMetadata",
+			build:    NewEmitTokenMetadataInstruction(&start, nil, mint).ValidateAndBuild,
+			wantData: []byte{250, 166, 180, 250, 13, 12, 184, 70, 1, 1, 0, 0, 0, 0, 0, 0, 0, 0},
+		},
+	}...)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			inst, err := test.build()
+			require.NoError(t, err)
+			require.Equal(t, ProgramID, inst.ProgramID())
+
+			data, err := inst.Data()
+			require.NoError(t, err)
+			require.Equal(t, test.wantData, data)
+
+			decoded, err := solana.DecodeInstruction(ProgramID, inst.Accounts(), data)
+			require.NoError(t, err)
+			got := decoded.(*Instruction)
+			require.Equal(t, inst.TypeID, got.TypeID)
+			require.Equal(t, inst.Accounts(), got.Accounts())
+
+			reencoded, err := got.Data()
+			require.NoError(t, err)
+			require.Equal(t, data, reencoded)
+		})
+	}
+}
+
+func TestDecodeInstruction_Unknown(t *testing.T) {
+	accounts := []*solana.AccountMeta{solana.Meta(solana.NewWallet().PublicKey()).WRITE()}
+
+	// UpdateTransferHook, which has no builder.
+	data := append([]byte{Instruction_TransferHookExtension, TransferHook_Update}, make([]byte, 32)...)
+	inst, err := DecodeInstruction(accounts, data)
+	require.NoError(t, err)
+	require.Equal(t, "TransferHookExtension", InstructionIDToName(inst.TypeID.Uint8()))
+	require.Equal(t, accounts, inst.Accounts())
+	reencoded, err := inst.Data()
+	require.NoError(t, err)
+	require.Equal(t, data, reencoded)
+
+	// WithdrawExcessLamports
+	data = []byte{Instruction_WithdrawExcessLamports}
+	inst, err = DecodeInstruction(accounts, data)
+	require.NoError(t, err)
+	require.IsType(t, &Unknown{}, inst.Impl)
+	require.Equal(t, accounts, inst.Accounts())
+
+	require.Equal(t, "Transfer", InstructionIDToName(token.Instruction_Transfer))
+}
+
+func TestTokenMetadataInstructions(t *testing.T) {
+	mint := solana.NewWallet().PublicKey()
+	authority := solana.NewWallet().PublicKey()
+
+	inst, err := NewInitializeTokenMetadataInstruction("Pump", "PUMP", "https://example.com", mint, authority, authority).ValidateAndBuild()
+	require.NoError(t, err)
+	require.Equal(t, "InitializeTokenMetadata", TokenMetadataInstructionName(inst.TypeID))
+	require.Equal(t, []*solana.AccountMeta{
+		solana.Meta(mint).WRITE(),
+		solana.Meta(authority),
+		solana.Meta(mint),
+		solana.Meta(authority).SIGNER(),
+	}, inst.Accounts())
+
+	data, err := inst.Data()
+	require.NoError(t, err)
+	decoded, err := DecodeInstruction(inst.Accounts(), data)
+	require.NoError(t, err)
+	metadata := decoded.Impl.(*InitializeTokenMetadata)
+	require.Equal(t, "PUMP", metadata.Symbol)
+	require.Equal(t, "https://example.com", metadata.URI)
+
+	_, err = NewUpdateTokenMetadataFieldInstruction(MetadataFieldKey+1, "", "v", mint, authority).ValidateAndBuild()
+	require.EqualError(t, err, "invalid Field 4")
+	_, err = NewRemoveTokenMetadataKeyInstructionBuilder().SetKey("k").ValidateAndBuild()
+	require.EqualError(t, err, "accounts.Metadata is not set")
+
+	// A truncated instruction of the interface doesn't fall back to the program's instructions.
+	_, err = DecodeInstruction(inst.Accounts(), data[:10])
+	require.ErrorContains(t, err, "InitializeTokenMetadata")
+	require.Equal(t, "", TokenMetadataInstructionName(bin.TypeIDFromUint8(Instruction_Reallocate)))
+}
+
+func TestTransferFeeInstructions_Validate(t *testing.T) {
+	mint := solana.NewWallet().PublicKey()
+
+	_, err := NewInitializeTransferFeeConfigInstruction(nil, nil, MAX_FEE_BASIS_POINTS+1, 0, mint).ValidateAndBuild()
+	require.EqualError(t, err, "TransferFeeBasisPoints is 10001, but max is 10000")
+
+	_, err = NewTransferCheckedWithFeeInstructionBuilder().
+		SetAmount(1).
+		SetDecimals(6).
+		ValidateAndBuild()
+	require.EqualError(t, err, "Fee parameter is not set")
+}
@@ -0,0 +1,116 @@
+package token2022
+
+import "github.com/scatkit/pumpdexer/programs/token"
+
+// Risk is a property of a mint that lets someone hurt its holders,
+// or makes the tokens harder to trade than a plain SPL token.
+type Risk string
+
+const (
+	// The supply can be inflated by the mint authority.
+	RiskMintAuthority Risk = "MintAuthority"
+	// Token accounts can be frozen by the freeze authority.
+	RiskFreezeAuthority Risk = "FreezeAuthority"
+	// The permanent delegate can transfer or burn the tokens of any account.
+	RiskPermanentDelegate Risk = "PermanentDelegate"
+	// Every transfer calls into a program chosen by the mint, which can fail it at will.
+	RiskTransferHook Risk = "TransferHook"
+	// The tokens can't be transferred at all.
+	RiskNonTransferable Risk = "NonTransferable"
+	// A fee is withheld on transfers, now or from a scheduled epoch.
+	RiskTransferFee Risk = "TransferFee"
+	// The transfer fee can be raised by its authority.
+	RiskTransferFeeAuthority Risk = "TransferFeeAuthority"
+	// New token accounts start frozen.
+	RiskDefaultAccountFrozen Risk = "DefaultAccountFrozen"
+	// The mint can be closed (and re-created with other settings) once its supply is zero.
+	RiskMintCloseAuthority Risk = "MintCloseAuthority"
+	// Minting, burning and transfers can be paused by the pause authority.
+	RiskPausable Risk = "Pausable"
+	// Minting, burning and transfers are currently paused.
+	RiskPaused Risk = "Paused"
+	// The mint has an extension this package doesn't assess.
+	RiskUnknownExtension Risk = "UnknownExtension"
+)
+
+// Risks returns the risks of holding or trading this mint, in the order of the constants above.
+// A mint without any authority nor risky extension has none.
+func (mint *Mint) Risks() []Risk {
+	var risks []Risk
+	if mint.MintAuthority != nil {
+		risks = append(risks, RiskMintAuthority)
+	}
+	if mint.FreezeAuthority != nil {
+		risks = append(risks, RiskFreezeAuthority)
+	}
+	if mint.PermanentDelegate != nil {
+		risks = append(risks, RiskPermanentDelegate)
+	}
+	if hook := mint.TransferHook; hook != nil && (hook.ProgramID != nil || hook.Authority != nil) {
+		risks = append(risks, RiskTransferHook)
+	}
+	if mint.NonTransferable {
+		risks = append(risks, RiskNonTransferable)
+	}
+	if cfg := mint.TransferFeeConfig; cfg != nil {
+		if cfg.OlderTransferFee.TransferFeeBasisPoints != 0 || cfg.NewerTransferFee.TransferFeeBasisPoints != 0 {
+			risks = append(risks, RiskTransferFee)
+		}
+		if cfg.TransferFeeConfigAuthority != nil {
+			risks = append(risks, RiskTransferFeeAuthority)
+		}
+	}
+	if mint.DefaultAccountState != nil && *mint.DefaultAccountState == token.Frozen {
+		risks = append(risks, RiskDefaultAccountFrozen)
+	}
+	if mint.MintCloseAuthority != nil {
+		risks = append(risks, RiskMintCloseAuthority)
+	}
+	if pausable := mint.Pausable; pausable != nil {
+		if pausable.Authority != nil {
+			risks = append(risks, RiskPausable)
+		}
+		if pausable.Paused {
+			risks = append(risks, RiskPaused)
+		}
+	}
+	for _, extension := range mint.Extensions {
+		if !isAssessedExtension(extension.Type) {
+			risks = append(risks, RiskUnknownExtension)
+			break
+		}
+	}
+	return risks
+}
+
+// HasRisk tells whether the mint has the given risk.
+func (mint *Mint) HasRisk(risk Risk) bool {
+	for _, r := range mint.Risks() {
+		if r == risk {
+			return true
+		}
+	}
+	return false
+}
+
+// isAssessedExtension tells whether the extension is covered by Risks,
+// either as a risk of its own or because it is harmless to holders.
+func isAssessedExtension(extensionType ExtensionType) bool {
+	switch extensionType {
+	case ExtensionTransferFeeConfig,
+		ExtensionMintCloseAuthority,
+		ExtensionDefaultAccountState,
+		ExtensionNonTransferable,
+		ExtensionPermanentDelegate,
+		ExtensionTransferHook,
+		ExtensionPausable,
+		ExtensionMetadataPointer,
+		ExtensionTokenMetadata,
+		ExtensionGroupPointer,
+		ExtensionTokenGroup,
+		ExtensionGroupMemberPointer,
+		ExtensionTokenGroupMember:
+		return true
+	}
+	return false
+}
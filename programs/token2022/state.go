@@ -0,0 +1,210 @@
+package token2022
+
+import (
+	"context"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/programs/token"
+	"github.com/scatkit/pumpdexer/rpc"
+	solana "github.com/scatkit/pumpdexer/solana"
+)
+
+// Mint is a Token-2022 mint: the base mint followed by its extensions.
+// The extensions this package knows are decoded into their fields,
+// which are nil (or false) when the mint doesn't have them.
+type Mint struct {
+	token.Mint
+
+	// All the extensions of the mint, as stored on chain.
+	Extensions []Extension
+
+	TransferFeeConfig   *TransferFeeConfig
+	MintCloseAuthority  *solana.PublicKey
+	DefaultAccountState *token.AccountState
+	NonTransferable     bool
+	PermanentDelegate   *solana.PublicKey
+	TransferHook        *TransferHook
+	MetadataPointer     *MetadataPointer
+	TokenMetadata       *TokenMetadata
+	Pausable            *Pausable
+}
+
+// DecodeMint decodes the given account bytes into a Mint.
+// A mint of the Token program decodes to a Mint without extensions.
+func DecodeMint(data []byte) (*Mint, error) {
+	base, err := token.DecodeMint(data)
+	if err != nil {
+		return nil, err
+	}
+	mint := &Mint{Mint: *base}
+	if mint.Extensions, err = parseExtensions(data, AccountTypeMint); err != nil {
+		return nil, err
+	}
+	for _, extension := range mint.Extensions {
+		if err := mint.decodeExtension(extension); err != nil {
+			return nil, fmt.Errorf("failed to decode %s extension: %w", extension.Type, err)
+		}
+	}
+	return mint, nil
+}
+
+func (mint *Mint) decodeExtension(extension Extension) (err error) {
+	dec := bin.NewBinDecoder(extension.Data)
+	switch extension.Type {
+	case ExtensionTransferFeeConfig:
+		mint.TransferFeeConfig = new(TransferFeeConfig)
+		return mint.TransferFeeConfig.UnmarshalWithDecoder(dec)
+	case ExtensionMintCloseAuthority:
+		mint.MintCloseAuthority, err = readNonZeroPublicKey(dec)
+		return err
+	case ExtensionDefaultAccountState:
+		state, err := dec.ReadUint8()
+		if err != nil {
+			return err
+		}
+		mint.DefaultAccountState = (*token.AccountState)(&state)
+	case ExtensionNonTransferable:
+		mint.NonTransferable = true
+	case ExtensionPermanentDelegate:
+		mint.PermanentDelegate, err = readNonZeroPublicKey(dec)
+		return err
+	case ExtensionTransferHook:
+		mint.TransferHook = new(TransferHook)
+		return mint.TransferHook.UnmarshalWithDecoder(dec)
+	case ExtensionMetadataPointer:
+		mint.MetadataPointer = new(MetadataPointer)
+		return mint.MetadataPointer.UnmarshalWithDecoder(dec)
+	case ExtensionTokenMetadata:
+		mint.TokenMetadata = new(TokenMetadata)
+		return mint.TokenMetadata.UnmarshalWithDecoder(dec)
+	case ExtensionPausable:
+		mint.Pausable = new(Pausable)
+		return mint.Pausable.UnmarshalWithDecoder(dec)
+	}
+	return nil
+}
+
+// HasExtension tells whether the mint has an extension of the given type.
+func (mint *Mint) HasExtension(extensionType ExtensionType) bool {
+	return hasExtension(mint.Extensions, extensionType)
+}
+
+// Account is a Token-2022 token account: the base account followed by its extensions.
+type Account struct {
+	token.Account
+
+	// All the extensions of the account, as stored on chain.
+	Extensions []Extension
+
+	// Transfer fees withheld on the account (`TransferFeeAmount` extension).
+	WithheldAmount uint64
+
+	// The owner of the account can't be changed.
+	ImmutableOwner bool
+
+	// The account belongs to a non-transferable mint.
+	NonTransferable bool
+}
+
+// DecodeAccount decodes the given account bytes into an Account.
+// A token account of the Token program decodes to an Account without extensions.
+func DecodeAccount(data []byte) (*Account, error) {
+	base, err := token.DecodeAccount(data)
+	if err != nil {
+		return nil, err
+	}
+	account := &Account{Account: *base}
+	if account.Extensions, err = parseExtensions(data, AccountTypeAccount); err != nil {
+		return nil, err
+	}
+	for _, extension := range account.Extensions {
+		switch extension.Type {
+		case ExtensionTransferFeeAmount:
+			if account.WithheldAmount, err = bin.NewBinDecoder(extension.Data).ReadUint64(bin.LE); err != nil {
+				return nil, fmt.Errorf("failed to decode %s extension: %w", extension.Type, err)
+			}
+		case ExtensionImmutableOwner:
+			account.ImmutableOwner = true
+		case ExtensionNonTransferableAccount:
+			account.NonTransferable = true
+		}
+	}
+	return account, nil
+}
+
+// HasExtension tells whether the account has an extension of the given type.
+func (account *Account) HasExtension(extensionType ExtensionType) bool {
+	return hasExtension(account.Extensions, extensionType)
+}
+
+func hasExtension(extensions []Extension, extensionType ExtensionType) bool {
+	for _, extension := range extensions {
+		if extension.Type == extensionType {
+			return true
+		}
+	}
+	return false
+}
+
+// GetMint fetches and decodes a mint of the Token-2022 (or Token) program.
+func GetMint(ctx context.Context, rpcClient *rpc.Client, address solana.PublicKey) (*Mint, error) {
+	mints, err := GetMints(ctx, rpcClient, address)
+	if err != nil {
+		return nil, err
+	}
+	if mints[0] == nil {
+		return nil, fmt.Errorf("account %s not found", address)
+	}
+	return mints[0], nil
+}
+
+// GetAccount fetches and decodes a token account of the Token-2022 (or Token) program.
+func GetAccount(ctx context.Context, rpcClient *rpc.Client, address solana.PublicKey) (*Account, error) {
+	accounts, err := GetAccounts(ctx, rpcClient, address)
+	if err != nil {
+		return nil, err
+	}
+	if accounts[0] == nil {
+		return nil, fmt.Errorf("account %s not found", address)
+	}
+	return accounts[0], nil
+}
+
+// GetMints fetches several mints in a single call.
+// The result has one entry per address, nil when the account doesn't exist.
+func GetMints(ctx context.Context, rpcClient *rpc.Client, addresses ...solana.PublicKey) ([]*Mint, error) {
+	datas, err := token.GetMultipleAccountsData(ctx, rpcClient, addresses)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*Mint, len(addresses))
+	for i, data := range datas {
+		if data == nil {
+			continue
+		}
+		if out[i], err = DecodeMint(data); err != nil {
+			return nil, fmt.Errorf("unable to decode mint %s: %w", addresses[i], err)
+		}
+	}
+	return out, nil
+}
+
+// GetAccounts fetches several token accounts in a single call.
+// The result has one entry per address, nil when the account doesn't exist.
+func GetAccounts(ctx context.Context, rpcClient *rpc.Client, addresses ...solana.PublicKey) ([]*Account, error) {
+	datas, err := token.GetMultipleAccountsData(ctx, rpcClient, addresses)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*Account, len(addresses))
+	for i, data := range datas {
+		if data == nil {
+			continue
+		}
+		if out[i], err = DecodeAccount(data); err != nil {
+			return nil, fmt.Errorf("unable to decode token account %s: %w", addresses[i], err)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,161 @@
+package token2022
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/scatkit/pumpdexer/programs/token"
+	"github.com/scatkit/pumpdexer/rpc"
+	"github.com/scatkit/pumpdexer/solana"
+	"github.com/scatkit/pumpdexer/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeState(t *testing.T, state interface{}) []byte {
+	buf := new(bytes.Buffer)
+	require.NoError(t, bin.NewBinEncoder(buf).Encode(state))
+	return buf.Bytes()
+}
+
+// encodeExtensions appends the account type and the TLV entries to the given base state,
+// padding it to the size of an account first.
+func encodeExtensions(base []byte, accountType AccountType, extensions ...Extension) []byte {
+	out := make([]byte, token.ACCOUNT_SIZE, token.ACCOUNT_SIZE+1)
+	copy(out, base)
+	out = append(out, byte(accountType))
+	for _, extension := range extensions {
+		out = binary.LittleEndian.AppendUint16(out, uint16(extension.Type))
+		out = binary.LittleEndian.AppendUint16(out, uint16(len(extension.Data)))
+		out = append(out, extension.Data...)
+	}
+	return out
+}
+
+func TestDecodeMint(t *testing.T) {
+	authority := solana.NewWallet().PublicKey()
+	delegate := solana.NewWallet().PublicKey()
+	hookProgram := solana.NewWallet().PublicKey()
+	mintAddress := solana.NewWallet().PublicKey()
+
+	feeConfig := TransferFeeConfig{
+		TransferFeeConfigAuthority: &authority,
+		WithheldAmount:             12,
+		OlderTransferFee:           TransferFee{Epoch: 100, MaximumFee: 5_000, TransferFeeBasisPoints: 50},
+		NewerTransferFee:           TransferFee{Epoch: 600, MaximumFee: 5_000, TransferFeeBasisPoints: 100},
+	}
+	metadata := TokenMetadata{
+		UpdateAuthority:    &authority,
+		Mint:               mintAddress,
+		Name:               "Pump",
+		Symbol:             "PUMP",
+		URI:                "https://example.com/pump.json",
+		AdditionalMetadata: [][2]string{{"twitter", "@pump"}},
+	}
+	base := encodeState(t, token.Mint{MintAuthority: &authority, Supply: 1_000, Decimals: 6, IsInitialized: true})
+	feeConfigData := encodeState(t, feeConfig)
+	require.Len(t, feeConfigData, 108)
+
+	data := encodeExtensions(base, AccountTypeMint,
+		Extension{Type: ExtensionTransferFeeConfig, Data: feeConfigData},
+		Extension{Type: ExtensionNonTransferable, Data: []byte{}},
+		Extension{Type: ExtensionPermanentDelegate, Data: delegate[:]},
+		Extension{Type: ExtensionTransferHook, Data: encodeState(t, TransferHook{ProgramID: &hookProgram})},
+		Extension{Type: ExtensionMetadataPointer, Data: encodeState(t, MetadataPointer{Authority: &authority, MetadataAddress: &mintAddress})},
+		Extension{Type: ExtensionDefaultAccountState, Data: []byte{byte(token.Frozen)}},
+		Extension{Type: ExtensionTokenMetadata, Data: encodeState(t, metadata)},
+	)
+
+	mint, err := DecodeMint(data)
+	require.NoError(t, err)
+	require.Equal(t, uint8(6), mint.Decimals)
+	require.Equal(t, &authority, mint.MintAuthority)
+	require.Len(t, mint.Extensions, 7)
+	require.Equal(t, &feeConfig, mint.TransferFeeConfig)
+	require.True(t, mint.NonTransferable)
+	require.Equal(t, &delegate, mint.PermanentDelegate)
+	require.Equal(t, &TransferHook{ProgramID: &hookProgram}, mint.TransferHook)
+	require.Equal(t, &mintAddress, mint.MetadataPointer.MetadataAddress)
+	require.Equal(t, token.Frozen, *mint.DefaultAccountState)
+	require.Equal(t, &metadata, mint.TokenMetadata)
+	require.Nil(t, mint.MintCloseAuthority)
+	require.True(t, mint.HasExtension(ExtensionPermanentDelegate))
+	require.False(t, mint.HasExtension(ExtensionPausable))
+
+	// Trailing zeros are padding, not extensions.
+	padded, err := DecodeMint(append(append([]byte{}, data...), 0, 0, 0, 0, 0))
+	require.NoError(t, err)
+	require.Equal(t, mint, padded)
+
+	// A Token program mint has no extensions.
+	plain, err := DecodeMint(base)
+	require.NoError(t, err)
+	require.Empty(t, plain.Extensions)
+	require.Nil(t, plain.TransferFeeConfig)
+
+	_, err = DecodeMint(data[:len(data)-1])
+	require.ErrorContains(t, err, "TokenMetadata is truncated")
+
+	wrongType := append([]byte{}, data...)
+	wrongType[token.ACCOUNT_SIZE] = byte(AccountTypeAccount)
+	_, err = DecodeMint(wrongType)
+	require.ErrorContains(t, err, "invalid account type")
+}
+
+func TestDecodeAccount(t *testing.T) {
+	base := encodeState(t, token.Account{
+		Mint:   solana.NewWallet().PublicKey(),
+		Owner:  solana.NewWallet().PublicKey(),
+		Amount: 10,
+		State:  token.Initialized,
+	})
+	withheld := make([]byte, 8)
+	binary.LittleEndian.PutUint64(withheld, 42)
+	data := encodeExtensions(base, AccountTypeAccount,
+		Extension{Type: ExtensionTransferFeeAmount, Data: withheld},
+		Extension{Type: ExtensionImmutableOwner, Data: []byte{}},
+	)
+
+	account, err := DecodeAccount(data)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), account.Amount)
+	require.Equal(t, uint64(42), account.WithheldAmount)
+	require.True(t, account.ImmutableOwner)
+	require.False(t, account.NonTransferable)
+	require.True(t, account.HasExtension(ExtensionTransferFeeAmount))
+
+	plain, err := DecodeAccount(base)
+	require.NoError(t, err)
+	require.Empty(t, plain.Extensions)
+}
+
+func TestGetMints(t *testing.T) {
+	srv := testutil.NewServer()
+	defer srv.Close()
+	client := rpc.New(srv.URL)
+
+	delegate := solana.NewWallet().PublicKey()
+	mint2022Address := solana.NewWallet().PublicKey()
+	mintAddress := solana.NewWallet().PublicKey()
+	base := encodeState(t, token.Mint{Decimals: 9, IsInitialized: true})
+
+	srv.SetAccount(mint2022Address, &testutil.Account{
+		Owner: solana.Token2022ProgramID,
+		Data:  encodeExtensions(base, AccountTypeMint, Extension{Type: ExtensionPermanentDelegate, Data: delegate[:]}),
+	})
+	srv.SetAccount(mintAddress, &testutil.Account{Owner: solana.TokenProgramID, Data: base})
+
+	mint, err := GetMint(context.Background(), client, mint2022Address)
+	require.NoError(t, err)
+	require.Equal(t, &delegate, mint.PermanentDelegate)
+
+	mints, err := GetMints(context.Background(), client, mintAddress, solana.NewWallet().PublicKey())
+	require.NoError(t, err)
+	require.Equal(t, uint8(9), mints[0].Decimals)
+	require.Nil(t, mints[1])
+
+	_, err = GetMint(context.Background(), client, solana.NewWallet().PublicKey())
+	require.ErrorContains(t, err, "not found")
+}
@@ -0,0 +1,135 @@
+// Package remotesigner signs with keys held by another process, reached over HTTP.
+//
+// The protocol is a single call: POST {"publicKey": base58, "message": base64} to the signer URL,
+// answered with {"signature": base58}, or with an error status and {"error": "..."}.
+// Handler serves it for a set of signers; Client is the solana.Signer calling it.
+package remotesigner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+type SignRequest struct {
+	PublicKey solana.PublicKey `json:"publicKey"`
+	Message   []byte           `json:"message"`
+}
+
+type SignResponse struct {
+	Signature *solana.Signature `json:"signature,omitempty"`
+	Error     string            `json:"error,omitempty"`
+}
+
+type Options struct {
+	// Client of the calls; http.DefaultClient by default.
+	HTTPClient *http.Client
+	// Headers added to the calls, e.g. an authorization token.
+	Header http.Header
+}
+
+// Client is the signer of a key held by a remote signer.
+type Client struct {
+	url       string
+	publicKey solana.PublicKey
+	opts      Options
+}
+
+var _ solana.Signer = (*Client)(nil)
+
+// New returns the signer of `publicKey` served at url.
+func New(url string, publicKey solana.PublicKey, opts *Options) *Client {
+	c := &Client{url: url, publicKey: publicKey}
+	if opts != nil {
+		c.opts = *opts
+	}
+	if c.opts.HTTPClient == nil {
+		c.opts.HTTPClient = http.DefaultClient
+	}
+	return c
+}
+
+func (c *Client) PublicKey() solana.PublicKey {
+	return c.publicKey
+}
+
+// SignMessage asks the remote signer for the signature, and checks it before returning it.
+func (c *Client) SignMessage(ctx context.Context, msg []byte) (solana.Signature, error) {
+	body, err := json.Marshal(&SignRequest{PublicKey: c.publicKey, Message: msg})
+	if err != nil {
+		return solana.Signature{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return solana.Signature{}, err
+	}
+	for name, values := range c.opts.Header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.opts.HTTPClient.Do(req)
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("remote signer: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("remote signer: %w", err)
+	}
+
+	var out SignResponse
+	if err := json.Unmarshal(data, &out); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return solana.Signature{}, fmt.Errorf("remote signer: status %d", resp.StatusCode)
+		}
+		return solana.Signature{}, fmt.Errorf("remote signer: decode response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || out.Error != "" {
+		return solana.Signature{}, fmt.Errorf("remote signer: status %d: %s", resp.StatusCode, out.Error)
+	}
+	if out.Signature == nil || !out.Signature.Verify(c.publicKey, msg) {
+		return solana.Signature{}, fmt.Errorf("remote signer: invalid signature for %s", c.publicKey)
+	}
+	return *out.Signature, nil
+}
+
+// Handler serves the remote signer protocol for the signers (a local stand-in of a signing
+// service, or the base of one). Requests for another key are answered 404.
+func Handler(signers ...solana.Signer) http.Handler {
+	byKey := make(map[solana.PublicKey]solana.Signer, len(signers))
+	for _, signer := range signers {
+		byKey[signer.PublicKey()] = signer
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reply := func(status int, resp *SignResponse) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(resp)
+		}
+		if r.Method != http.MethodPost {
+			reply(http.StatusMethodNotAllowed, &SignResponse{Error: "method not allowed"})
+			return
+		}
+		var req SignRequest
+		if err := json.NewDecoder(io.LimitReader(r.Body, 1<<16)).Decode(&req); err != nil {
+			reply(http.StatusBadRequest, &SignResponse{Error: fmt.Sprintf("invalid request: %s", err)})
+			return
+		}
+		signer, ok := byKey[req.PublicKey]
+		if !ok {
+			reply(http.StatusNotFound, &SignResponse{Error: fmt.Sprintf("unknown key %s", req.PublicKey)})
+			return
+		}
+		sig, err := signer.SignMessage(r.Context(), req.Message)
+		if err != nil {
+			reply(http.StatusInternalServerError, &SignResponse{Error: err.Error()})
+			return
+		}
+		reply(http.StatusOK, &SignResponse{Signature: &sig})
+	})
+}
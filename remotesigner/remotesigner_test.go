@@ -0,0 +1,48 @@
+package remotesigner
+
+import (
+	"context"
+	"crypto/ed25519"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/scatkit/pumpdexer/solana"
+	"github.com/stretchr/testify/require"
+)
+
+// lyingSigner claims a key but signs with another one.
+type lyingSigner struct {
+	solana.PrivateKey
+	claimed solana.PublicKey
+}
+
+func (s lyingSigner) PublicKey() solana.PublicKey { return s.claimed }
+
+func TestClient(t *testing.T) {
+	wallet, impostor := solana.NewWallet(), solana.NewWallet()
+	claimed := solana.NewWallet().PublicKey()
+	var auth string
+	handler := Handler(wallet, lyingSigner{PrivateKey: impostor.PrivateKey, claimed: claimed})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		handler.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	client := New(srv.URL, wallet.PublicKey(), &Options{Header: http.Header{"Authorization": {"Bearer token"}}})
+	require.Equal(t, wallet.PublicKey(), client.PublicKey())
+	sig, err := client.SignMessage(context.Background(), []byte("hello"))
+	require.NoError(t, err)
+	pub := wallet.PublicKey()
+	require.True(t, ed25519.Verify(pub[:], []byte("hello"), sig[:]))
+	require.Equal(t, "Bearer token", auth)
+
+	_, err = New(srv.URL, solana.NewWallet().PublicKey(), nil).SignMessage(context.Background(), []byte("hello"))
+	require.ErrorContains(t, err, "404")
+
+	// A signature that doesn't verify is rejected.
+	_, err = New(srv.URL, claimed, nil).SignMessage(context.Background(), []byte("hello"))
+	require.ErrorContains(t, err, "invalid signature")
+
+}
@@ -0,0 +1,275 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/scatkit/pumpdexer/rpc/jsonrpc"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// ErrNoMoreSignatures is returned by SignatureIterator.Next at the end of the history.
+var ErrNoMoreSignatures = errors.New("no more signatures")
+
+type SignatureIteratorOpts struct {
+	Commitment CommitmentType
+	// Where to start: the signatures older than this one. Zero starts at the newest;
+	// pass the Checkpoint of a previous iteration to resume it.
+	Before solana.Signature
+	// Where to stop; every condition that is set applies, the first one met ends the iteration:
+	// at this signature (excluded),
+	Until solana.Signature
+	// at the first signature of a slot lower than this one,
+	UntilSlot uint64
+	// at the first signature with a block time before this one.
+	UntilBlockTime time.Time
+	// Signatures per getSignaturesForAddress call; 1000 (the max) by default.
+	PageSize int
+
+	// Max requests per second, for all the calls of the iterator; 0 means no limit.
+	RequestsPerSecond float64
+	// Attempts at a call the node rate limits (HTTP 429); 5 by default.
+	MaxAttempts int
+	// Wait before the first retry, doubled at each attempt; 500ms by default.
+	RetryBackoff time.Duration
+
+	// Fetch the transaction of every signature with getTransaction.
+	FetchTransactions bool
+	// Options of getTransaction; base64 and v0 transactions by default.
+	TransactionOpts *GetTransactionOpts
+	// Max getTransaction calls in flight; 4 by default.
+	Concurrency int
+}
+
+// SignatureHistoryEntry is a signature of the history and, if asked for, its transaction.
+type SignatureHistoryEntry struct {
+	*TransactionSignature
+	Transaction *GetTransactionResult
+}
+
+// SignatureIterator walks the history of an address backward, page by page.
+// It is not safe for concurrent use.
+type SignatureIterator struct {
+	client  *Client
+	account solana.PublicKey
+	opts    SignatureIteratorOpts
+	limiter *rateLimiter
+
+	before     solana.Signature
+	checkpoint solana.Signature
+	buffer     []*SignatureHistoryEntry
+	done       bool
+}
+
+// NewSignatureIterator returns an iterator over the signatures of the transactions involving
+// the account, newest first.
+func (cl *Client) NewSignatureIterator(account solana.PublicKey, opts *SignatureIteratorOpts) *SignatureIterator {
+	it := &SignatureIterator{client: cl, account: account}
+	if opts != nil {
+		it.opts = *opts
+	}
+	if it.opts.PageSize <= 0 {
+		it.opts.PageSize = 1000
+	}
+	if it.opts.MaxAttempts <= 0 {
+		it.opts.MaxAttempts = 5
+	}
+	if it.opts.RetryBackoff <= 0 {
+		it.opts.RetryBackoff = 500 * time.Millisecond
+	}
+	if it.opts.Concurrency <= 0 {
+		it.opts.Concurrency = 4
+	}
+	if it.opts.TransactionOpts == nil {
+		maxVersion := uint64(0)
+		it.opts.TransactionOpts = &GetTransactionOpts{
+			Encoding:                       solana.EncodingBase64,
+			Commitment:                     it.opts.Commitment,
+			MaxSupportedTransactionVersion: &maxVersion,
+		}
+	}
+	it.limiter = newRateLimiter(it.opts.RequestsPerSecond)
+	it.before = it.opts.Before
+	it.checkpoint = it.opts.Before
+	return it
+}
+
+// Next returns the next (older) signature, or ErrNoMoreSignatures.
+// After an error other than ErrNoMoreSignatures, Next can be called again to retry.
+func (it *SignatureIterator) Next(ctx context.Context) (*SignatureHistoryEntry, error) {
+	for len(it.buffer) == 0 {
+		if it.done {
+			return nil, ErrNoMoreSignatures
+		}
+		if err := it.nextPage(ctx); err != nil {
+			return nil, err
+		}
+	}
+	entry := it.buffer[0]
+	it.buffer = it.buffer[1:]
+	it.checkpoint = entry.Signature
+	return entry, nil
+}
+
+// Checkpoint returns the last signature returned by Next (or the starting one):
+// an iteration resumes after it with SignatureIteratorOpts.Before.
+func (it *SignatureIterator) Checkpoint() solana.Signature {
+	return it.checkpoint
+}
+
+func (it *SignatureIterator) nextPage(ctx context.Context) error {
+	limit := it.opts.PageSize
+	var page []*TransactionSignature
+	err := it.call(ctx, func() (err error) {
+		page, err = it.client.GetSignaturesForAddressWithOpts(ctx, it.account, &GetSignaturesForAddressOpts{
+			Commitment: it.opts.Commitment,
+			Limit:      &limit,
+			Before:     it.before,
+			Until:      it.opts.Until,
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	done := len(page) < limit
+	entries := make([]*SignatureHistoryEntry, 0, len(page))
+	for _, sig := range page {
+		if it.reachedEnd(sig) {
+			done = true
+			break
+		}
+		entries = append(entries, &SignatureHistoryEntry{TransactionSignature: sig})
+	}
+	if it.opts.FetchTransactions {
+		if err := it.fetchTransactions(ctx, entries); err != nil {
+			return err
+		}
+	}
+	if len(page) > 0 {
+		it.before = page[len(page)-1].Signature
+	}
+	it.buffer, it.done = entries, done
+	return nil
+}
+
+func (it *SignatureIterator) reachedEnd(sig *TransactionSignature) bool {
+	if it.opts.UntilSlot > 0 && sig.Slot < it.opts.UntilSlot {
+		return true
+	}
+	if !it.opts.UntilBlockTime.IsZero() && sig.BlockTime != nil && sig.BlockTime.Time().Before(it.opts.UntilBlockTime) {
+		return true
+	}
+	return false
+}
+
+// fetchTransactions gets the transactions of the entries with at most opts.Concurrency calls in flight.
+func (it *SignatureIterator) fetchTransactions(ctx context.Context, entries []*SignatureHistoryEntry) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var firstErr error
+	sem := make(chan struct{}, it.opts.Concurrency)
+	for _, entry := range entries {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(entry *SignatureHistoryEntry) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			err := it.call(ctx, func() (err error) {
+				entry.Transaction, err = it.client.GetTransaction(ctx, entry.Signature, it.opts.TransactionOpts)
+				return err
+			})
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(entry)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// call runs an rpc call within the rate limit, retrying it while the node rate limits it.
+func (it *SignatureIterator) call(ctx context.Context, do func() error) error {
+	backoff := it.opts.RetryBackoff
+	for attempt := 1; ; attempt++ {
+		if err := it.limiter.wait(ctx); err != nil {
+			return err
+		}
+		err := do()
+		if err == nil || !isRateLimited(err) || attempt == it.opts.MaxAttempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func isRateLimited(err error) bool {
+	var httpErr *jsonrpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code == http.StatusTooManyRequests
+	}
+	var rpcErr *jsonrpc.RPCError
+	if errors.As(err, &rpcErr) {
+		return rpcErr.Code == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// rateLimiter spaces out calls evenly.
+type rateLimiter struct {
+	lock     sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// newRateLimiter returns a limiter of `perSecond` calls per second, or none if 0.
+func newRateLimiter(perSecond float64) *rateLimiter {
+	if perSecond <= 0 {
+		return &rateLimiter{}
+	}
+	return &rateLimiter{interval: time.Duration(float64(time.Second) / perSecond)}
+}
+
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l.interval == 0 {
+		return ctx.Err()
+	}
+	l.lock.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.lock.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Until(at)):
+		return nil
+	}
+}
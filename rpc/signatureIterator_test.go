@@ -0,0 +1,123 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/scatkit/pumpdexer/solana"
+	"github.com/scatkit/pumpdexer/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// signatureHistory serves 7 signatures for the account, newest first: slots 107 to 101,
+// a block time of `slot` seconds.
+func signatureHistory(t *testing.T) (*testutil.Server, solana.PublicKey, []solana.Signature) {
+	srv := testutil.NewServer()
+	t.Cleanup(srv.Close)
+	account := solana.NewWallet().PublicKey()
+	var sigs []solana.Signature
+	var entries []interface{}
+	for slot := uint64(107); slot > 100; slot-- {
+		sig := solana.Signature{byte(slot)}
+		blockTime := solana.UnixTimeSeconds(slot)
+		sigs = append(sigs, sig)
+		entries = append(entries, &TransactionSignature{Signature: sig, Slot: slot, BlockTime: &blockTime})
+		srv.SetTransaction(sig, map[string]interface{}{"slot": slot})
+	}
+	srv.SetSignaturesForAddress(account, entries...)
+	return srv, account, sigs
+}
+
+func collectSignatures(t *testing.T, it *SignatureIterator) (out []solana.Signature) {
+	for {
+		entry, err := it.Next(context.Background())
+		if err == ErrNoMoreSignatures {
+			return out
+		}
+		require.NoError(t, err)
+		out = append(out, entry.Signature)
+	}
+}
+
+func countMethod(srv *testutil.Server, method string) (count int) {
+	for _, m := range srv.Methods() {
+		if m == method {
+			count++
+		}
+	}
+	return count
+}
+
+func TestSignatureIterator(t *testing.T) {
+	srv, account, sigs := signatureHistory(t)
+	client := New(srv.URL)
+
+	it := client.NewSignatureIterator(account, &SignatureIteratorOpts{PageSize: 3})
+	require.Equal(t, sigs, collectSignatures(t, it))
+	require.Equal(t, 3, countMethod(srv, "getSignaturesForAddress"))
+	require.Equal(t, sigs[6], it.Checkpoint())
+	_, err := it.Next(context.Background())
+	require.Equal(t, ErrNoMoreSignatures, err)
+
+	require.Equal(t, sigs[:2], collectSignatures(t, client.NewSignatureIterator(account, &SignatureIteratorOpts{PageSize: 3, Until: sigs[2]})))
+	require.Equal(t, sigs[:4], collectSignatures(t, client.NewSignatureIterator(account, &SignatureIteratorOpts{PageSize: 3, UntilSlot: 104})))
+	require.Equal(t, sigs[:5], collectSignatures(t, client.NewSignatureIterator(account, &SignatureIteratorOpts{UntilBlockTime: time.Unix(103, 0)})))
+
+	// Resume from a checkpoint.
+	it = client.NewSignatureIterator(account, &SignatureIteratorOpts{PageSize: 2})
+	for i := 0; i < 3; i++ {
+		_, err := it.Next(context.Background())
+		require.NoError(t, err)
+	}
+	resumed := client.NewSignatureIterator(account, &SignatureIteratorOpts{PageSize: 2, Before: it.Checkpoint()})
+	require.Equal(t, sigs[3:], collectSignatures(t, resumed))
+}
+
+func TestSignatureIterator_FetchTransactions(t *testing.T) {
+	srv, account, sigs := signatureHistory(t)
+	client := New(srv.URL)
+
+	it := client.NewSignatureIterator(account, &SignatureIteratorOpts{PageSize: 4, FetchTransactions: true, Concurrency: 2})
+	for i := range sigs {
+		entry, err := it.Next(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, sigs[i], entry.Signature)
+		require.NotNil(t, entry.Transaction)
+		require.Equal(t, entry.Slot, entry.Transaction.Slot)
+	}
+	require.Equal(t, len(sigs), countMethod(srv, "getTransaction"))
+
+	// A transaction that can't be fetched fails the page, which is fetched again by the next call.
+	srv.SetTransaction(sigs[1], nil)
+	it = client.NewSignatureIterator(account, &SignatureIteratorOpts{PageSize: 4, FetchTransactions: true})
+	_, err := it.Next(context.Background())
+	require.Error(t, err)
+	srv.SetTransaction(sigs[1], map[string]interface{}{"slot": 106})
+	require.Equal(t, sigs, collectSignatures(t, it))
+}
+
+func TestSignatureIterator_RateLimits(t *testing.T) {
+	srv, account, sigs := signatureHistory(t)
+	client := New(srv.URL)
+
+	// The node rate limits twice, then answers.
+	srv.Enqueue("getSignaturesForAddress", &testutil.Error{Code: 429, Message: "Too many requests"}, &testutil.Error{Code: 429, Message: "Too many requests"})
+	it := client.NewSignatureIterator(account, &SignatureIteratorOpts{RetryBackoff: time.Millisecond})
+	require.Equal(t, sigs, collectSignatures(t, it))
+	require.Equal(t, 3, countMethod(srv, "getSignaturesForAddress"))
+
+	// Until it's too many attempts; other errors aren't retried.
+	srv.Enqueue("getSignaturesForAddress", &testutil.Error{Code: 429, Message: "Too many requests"}, &testutil.Error{Code: 429, Message: "Too many requests"})
+	_, err := client.NewSignatureIterator(account, &SignatureIteratorOpts{RetryBackoff: time.Millisecond, MaxAttempts: 2}).Next(context.Background())
+	require.Error(t, err)
+	srv.Enqueue("getSignaturesForAddress", &testutil.Error{Code: -32000, Message: "boom"})
+	_, err = client.NewSignatureIterator(account, nil).Next(context.Background())
+	require.Error(t, err)
+
+	// 7 pages at 100 calls per second take at least 60ms.
+	start := time.Now()
+	it = client.NewSignatureIterator(account, &SignatureIteratorOpts{PageSize: 1, RequestsPerSecond: 100})
+	require.Equal(t, sigs, collectSignatures(t, it))
+	require.GreaterOrEqual(t, time.Since(start), 60*time.Millisecond)
+}
@@ -8,9 +8,18 @@ import (
 
 
 type SimulateTransactionResponse struct{
-  Err interface{}       `json:"err,omitmepty"`
+  RPCContext
+  Value *SimulateTransactionResult `json:"value"`
+}
+
+type SimulateTransactionResult struct{
+  // Error if transaction failed, null if transaction succeeded.
+  Err interface{}       `json:"err,omitempty"`
+  // Array of log messages the transaction instructions output during execution,
+  // null if simulation failed before the transaction was able to execute.
   Logs []string         `json:"logs,omitempty"`
   Accounts []*Account   `json:"accounts"`
+  // The number of compute budget units consumed during the processing of this transaction.
   UnitsConsumed *uint64 `json:"unitsConsumed,omitempty"`
 }
 
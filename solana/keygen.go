@@ -0,0 +1,101 @@
+package solana
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// PrivateKeyFromSolanaKeygenFile reads a keypair file of the Solana CLI (e.g. ~/.config/solana/id.json):
+// a JSON array of the 64 bytes of the private key.
+func PrivateKeyFromSolanaKeygenFile(path string) (PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read keygen file: %w", err)
+	}
+	key, err := PrivateKeyFromSolanaKeygenFileBytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("keygen file %s: %w", path, err)
+	}
+	return key, nil
+}
+
+// PrivateKeyFromSolanaKeygenFileBytes decodes the content of a keypair file of the Solana CLI.
+func PrivateKeyFromSolanaKeygenFileBytes(data []byte) (PrivateKey, error) {
+	var values keygenBytes
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("decode keypair: %w", err)
+	}
+	if _, err := ValidatePrivateKey(values); err != nil {
+		return nil, err
+	}
+	// The second half is the public key, which must be the one of the seed.
+	if !bytes.Equal(ed25519.NewKeyFromSeed(values[:ed25519.SeedSize])[ed25519.SeedSize:], values[ed25519.SeedSize:]) {
+		return nil, fmt.Errorf("the public key of the keypair doesn't match its secret key")
+	}
+	return PrivateKey(values), nil
+}
+
+// WriteSolanaKeygenFile writes the key to a keypair file of the Solana CLI, readable by its owner only.
+// The directory is created if needed; an existing file is overwritten.
+func (key PrivateKey) WriteSolanaKeygenFile(path string) error {
+	if err := key.Validate(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create keygen file directory: %w", err)
+	}
+	if err := os.WriteFile(path, key.solanaKeygenFileBytes(), 0o600); err != nil {
+		return fmt.Errorf("write keygen file: %w", err)
+	}
+	return nil
+}
+
+// solanaKeygenFileBytes encodes the key the way solana-keygen does: [n,n,...] without spaces.
+func (key PrivateKey) solanaKeygenFileBytes() []byte {
+	buf := make([]byte, 0, len(key)*4+2)
+	buf = append(buf, '[')
+	for i, b := range key {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = strconv.AppendUint(buf, uint64(b), 10)
+	}
+	return append(buf, ']')
+}
+
+// keygenBytes decodes a JSON array of numbers into bytes
+// (a plain []byte would expect a base64 string).
+type keygenBytes []uint8
+
+func (b *keygenBytes) UnmarshalJSON(data []byte) error {
+	var numbers []uint16
+	if err := json.Unmarshal(data, &numbers); err != nil {
+		return err
+	}
+	out := make([]uint8, len(numbers))
+	for i, n := range numbers {
+		if n > 0xff {
+			return fmt.Errorf("value %d at index %d is not a byte", n, i)
+		}
+		out[i] = uint8(n)
+	}
+	*b = out
+	return nil
+}
+
+func WalletFromSolanaKeygenFile(path string) (*Wallet, error) {
+	k, err := PrivateKeyFromSolanaKeygenFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Wallet{PrivateKey: k}, nil
+}
+
+// SaveSolanaKeygenFile writes the key of the wallet to a keypair file of the Solana CLI.
+func (acc *Wallet) SaveSolanaKeygenFile(path string) error {
+	return acc.PrivateKey.WriteSolanaKeygenFile(path)
+}
@@ -281,14 +281,21 @@ func FindProgramAddress(seed [][]byte, programID PublicKey) (PublicKey, uint8, e
 // ATA is created from user's wallet + token mint's address
 func FindAssociatedTokenAddress(wallet PublicKey, mint PublicKey,
 ) (PublicKey, uint8, error){
-  return findAssociatedTokenAddressAndBumpSeed(wallet, mint, SPLAssociatedTokenAccountProgramID)
+  return FindAssociatedTokenAddressWithProgramID(wallet, mint, TokenProgramID)
 }
 
-func findAssociatedTokenAddressAndBumpSeed(walletAddress PublicKey, splTokenMintAddress PublicKey,programID PublicKey,
+// FindAssociatedTokenAddressWithProgramID is like FindAssociatedTokenAddress, for a mint
+// of the given token program (Token or Token-2022): the token program is part of the seeds.
+func FindAssociatedTokenAddressWithProgramID(wallet PublicKey, mint PublicKey, tokenProgramID PublicKey,
+) (PublicKey, uint8, error){
+  return findAssociatedTokenAddressAndBumpSeed(wallet, mint, tokenProgramID, SPLAssociatedTokenAccountProgramID)
+}
+
+func findAssociatedTokenAddressAndBumpSeed(walletAddress PublicKey, splTokenMintAddress PublicKey, tokenProgramID PublicKey, programID PublicKey,
 ) (PublicKey, uint8, error){
 	return FindProgramAddress([][]byte{
 		walletAddress[:],
-		TokenProgramID[:], // <-- this program defines a common implementation for Fungible and Non Fungible tokens
+		tokenProgramID[:], // <-- the token program owning the mint (Token or Token-2022)
 		splTokenMintAddress[:],
 	},
 		programID, // <-- ATA program
@@ -0,0 +1,197 @@
+package solana
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	bin "github.com/gagliardetto/binary"
+)
+
+const (
+	// PacketDataSize is the max size of a serialized transaction, signatures included
+	// (the IPv6 minimum MTU less the IP and UDP headers).
+	PacketDataSize = 1232
+	// MaxTransactionAccounts is the max number of accounts a transaction can lock,
+	// static and loaded from address tables.
+	MaxTransactionAccounts = 64
+)
+
+var (
+	ErrTransactionTooLarge = errors.New("transaction too large")
+	ErrTooManyAccounts     = errors.New("too many accounts")
+	ErrInvalidTransaction  = errors.New("invalid transaction")
+)
+
+// TransactionSize is what a transaction weighs against the limits of the network.
+type TransactionSize struct {
+	// Bytes of the serialized transaction, with all its signatures (signed or not yet).
+	Size       int
+	Signatures int
+	// Accounts in the message, and accounts loaded from address tables.
+	StaticAccounts int
+	LookupAccounts int
+	// Address tables used.
+	AddressTableLookups int
+}
+
+// Accounts returns the number of accounts the transaction locks.
+func (s *TransactionSize) Accounts() int {
+	return s.StaticAccounts + s.LookupAccounts
+}
+
+// Fits tells whether the transaction is within the size and account limits.
+func (s *TransactionSize) Fits() bool {
+	return s.Size <= PacketDataSize && s.Accounts() <= MaxTransactionAccounts
+}
+
+// EstimateSize returns the size of the transaction once signed, and its account and lookup counts.
+func (tx *Transaction) EstimateSize() (*TransactionSize, error) {
+	messageContent, err := tx.Message.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode message: %w", err)
+	}
+	numSignatures := int(tx.Message.Header.NumRequiredSignatures)
+	var signatureCount []byte
+	bin.EncodeCompactU16Length(&signatureCount, numSignatures)
+	return &TransactionSize{
+		Size:                len(signatureCount) + numSignatures*SignatureLength + len(messageContent),
+		Signatures:          numSignatures,
+		StaticAccounts:      tx.Message.numStaticAccounts(),
+		LookupAccounts:      tx.Message.NumLookups(),
+		AddressTableLookups: len(tx.Message.AddressTableLookups),
+	}, nil
+}
+
+// Validate checks that the transaction can land: well formed, and within the size and account limits.
+// The errors wrap ErrTransactionTooLarge, ErrTooManyAccounts or ErrInvalidTransaction.
+func (tx *Transaction) Validate() error {
+	msg := &tx.Message
+	header := msg.Header
+	numStatic := msg.numStaticAccounts()
+	numAccounts := numStatic + msg.NumLookups()
+
+	if len(msg.Instructions) == 0 {
+		return fmt.Errorf("%w: no instructions", ErrInvalidTransaction)
+	}
+	if header.NumRequiredSignatures == 0 {
+		return fmt.Errorf("%w: no fee payer", ErrInvalidTransaction)
+	}
+	if int(header.NumRequiredSignatures)+int(header.NumReadonlyUnsignedAccounts) > numStatic ||
+		header.NumReadonlySignedAccounts >= header.NumRequiredSignatures {
+		return fmt.Errorf("%w: header %+v doesn't match the %d account keys", ErrInvalidTransaction, header, numStatic)
+	}
+	if len(tx.Signatures) != 0 && len(tx.Signatures) != int(header.NumRequiredSignatures) {
+		return fmt.Errorf("%w: %d signatures for %d signers", ErrInvalidTransaction, len(tx.Signatures), header.NumRequiredSignatures)
+	}
+	seen := make(map[PublicKey]struct{}, numStatic)
+	for _, key := range msg.getStaticKeys() {
+		if _, ok := seen[key]; ok {
+			return fmt.Errorf("%w: account %s is listed twice", ErrInvalidTransaction, key)
+		}
+		seen[key] = struct{}{}
+	}
+	for i, lookup := range msg.AddressTableLookups {
+		if len(lookup.WritableIndexes)+len(lookup.ReadonlyIndexes) == 0 {
+			return fmt.Errorf("%w: address table lookup %d loads no account", ErrInvalidTransaction, i)
+		}
+	}
+	if numAccounts > MaxTransactionAccounts {
+		return fmt.Errorf("%w: %d accounts, max %d", ErrTooManyAccounts, numAccounts, MaxTransactionAccounts)
+	}
+	for i, instruction := range msg.Instructions {
+		switch {
+		case instruction.ProgramIDIndex == 0:
+			return fmt.Errorf("%w: the program of instruction %d is the fee payer", ErrInvalidTransaction, i)
+		case int(instruction.ProgramIDIndex) >= numAccounts:
+			return fmt.Errorf("%w: program index %d of instruction %d out of range", ErrInvalidTransaction, instruction.ProgramIDIndex, i)
+		case int(instruction.ProgramIDIndex) >= numStatic:
+			return fmt.Errorf("%w: the program of instruction %d is loaded from an address table", ErrInvalidTransaction, i)
+		}
+		for _, index := range instruction.Accounts {
+			if int(index) >= numAccounts {
+				return fmt.Errorf("%w: account index %d of instruction %d out of range", ErrInvalidTransaction, index, i)
+			}
+		}
+	}
+
+	size, err := tx.EstimateSize()
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidTransaction, err)
+	}
+	if size.Size > PacketDataSize {
+		return fmt.Errorf("%w: %d bytes, max %d", ErrTransactionTooLarge, size.Size, PacketDataSize)
+	}
+	return nil
+}
+
+// NewTransactionWithinLimits builds the transaction like NewTransaction, then validates it.
+// When it is too large, the accounts found in the address tables are loaded from them instead:
+// the tables that hold the most accounts of the transaction are added first, until it fits.
+// The transaction stays legacy if it fits without tables.
+// Tables passed with TransactionAddressTables in opts are replaced by `tables`.
+func NewTransactionWithinLimits(instructions []Instruction, recentBlockHash Hash, tables map[PublicKey]PublicKeySlice, opts ...TransactionOption) (*Transaction, error) {
+	options := transactionOptions{}
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+	ranked := rankAddressTables(instructions, options.payer, tables)
+
+	used := make(map[PublicKey]PublicKeySlice, len(ranked))
+	for i := 0; ; i++ {
+		withTables := append(append([]TransactionOption{}, opts...), TransactionAddressTables(used))
+		tx, err := NewTransaction(instructions, recentBlockHash, withTables...)
+		if err != nil {
+			return nil, err
+		}
+		err = tx.Validate()
+		if err == nil {
+			return tx, nil
+		}
+		if !errors.Is(err, ErrTransactionTooLarge) || i == len(ranked) {
+			return nil, err
+		}
+		used[ranked[i]] = tables[ranked[i]]
+	}
+}
+
+// rankAddressTables returns the tables holding accounts of the instructions that can be looked up
+// (not signers, nor programs, nor the payer), most such accounts first.
+func rankAddressTables(instructions []Instruction, payer PublicKey, tables map[PublicKey]PublicKeySlice) []PublicKey {
+	excluded := map[PublicKey]struct{}{payer: {}}
+	eligible := make(map[PublicKey]struct{})
+	for _, instruction := range instructions {
+		excluded[instruction.ProgramID()] = struct{}{}
+		for _, acc := range instruction.Accounts() {
+			if acc.IsSigner {
+				excluded[acc.PublicKey] = struct{}{}
+			}
+			eligible[acc.PublicKey] = struct{}{}
+		}
+	}
+	for key := range excluded {
+		delete(eligible, key)
+	}
+
+	counts := make(map[PublicKey]int, len(tables))
+	ranked := make([]PublicKey, 0, len(tables))
+	for tableKey, table := range tables {
+		found := make(map[PublicKey]struct{})
+		for _, address := range table {
+			if _, ok := eligible[address]; ok {
+				found[address] = struct{}{}
+			}
+		}
+		if len(found) > 0 {
+			counts[tableKey] = len(found)
+			ranked = append(ranked, tableKey)
+		}
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if counts[ranked[i]] != counts[ranked[j]] {
+			return counts[ranked[i]] > counts[ranked[j]]
+		}
+		return ranked[i].String() < ranked[j].String()
+	})
+	return ranked
+}
@@ -0,0 +1,160 @@
+package solana
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransaction_EstimateSize(t *testing.T) {
+	payer, cosigner := NewWallet(), NewWallet()
+	tx, err := NewTransaction([]Instruction{&testTransactionInstructions{
+		accounts:  []*AccountMeta{Meta(payer.PublicKey()).WRITE().SIGNER(), Meta(cosigner.PublicKey()).SIGNER(), Meta(NewWallet().PublicKey())},
+		data:      []byte{1, 2, 3},
+		programID: NewWallet().PublicKey(),
+	}}, Hash{}, TransactionPayer(payer.PublicKey()))
+	require.NoError(t, err)
+	require.NoError(t, tx.Validate())
+
+	size, err := tx.EstimateSize()
+	require.NoError(t, err)
+	require.Equal(t, 2, size.Signatures)
+	require.Equal(t, 4, size.StaticAccounts)
+	require.Equal(t, 4, size.Accounts())
+	require.Zero(t, size.LookupAccounts)
+	require.True(t, size.Fits())
+
+	// The estimate is the size once signed.
+	_, err = tx.SignWith(context.Background(), payer, cosigner)
+	require.NoError(t, err)
+	raw, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, len(raw), size.Size)
+	require.NoError(t, tx.Validate())
+}
+
+func TestTransaction_Validate(t *testing.T) {
+	payer := NewWallet().PublicKey()
+	programID := NewWallet().PublicKey()
+
+	_, err := NewTransaction(nil, Hash{}, TransactionPayer(payer))
+	require.Error(t, err)
+
+	// Too large.
+	tx, err := NewTransaction([]Instruction{&testTransactionInstructions{
+		accounts:  []*AccountMeta{Meta(payer).WRITE().SIGNER()},
+		data:      bytes.Repeat([]byte{1}, PacketDataSize),
+		programID: programID,
+	}}, Hash{})
+	require.NoError(t, err)
+	require.ErrorIs(t, tx.Validate(), ErrTransactionTooLarge)
+
+	// Small, but with too many accounts thanks to address tables.
+	message := Message{
+		Header:       MessageHeader{NumRequiredSignatures: 1, NumReadonlyUnsignedAccounts: 1},
+		AccountKeys:  PublicKeySlice{payer, programID},
+		Instructions: []CompiledInstruction{{ProgramIDIndex: 1, Accounts: []uint16{0, 2}}},
+	}
+	indexes := make([]uint8, MaxTransactionAccounts)
+	for i := range indexes {
+		indexes[i] = uint8(i)
+	}
+	message.SetAddressTableLookups([]MessageAddressTableLookup{{AccountKey: NewWallet().PublicKey(), ReadonlyIndexes: indexes}})
+	tx = &Transaction{Message: message}
+	require.ErrorIs(t, tx.Validate(), ErrTooManyAccounts)
+
+	// A program loaded from an address table.
+	message.AddressTableLookups[0].ReadonlyIndexes = indexes[:1]
+	message.Instructions[0].ProgramIDIndex = 2
+	tx = &Transaction{Message: message}
+	require.ErrorContains(t, tx.Validate(), "loaded from an address table")
+	message.Instructions[0].ProgramIDIndex = 1
+	tx = &Transaction{Message: message}
+	require.NoError(t, tx.Validate())
+
+	for name, broken := range map[string]func(tx *Transaction){
+		"no instructions":   func(tx *Transaction) { tx.Message.Instructions = nil },
+		"no fee payer":      func(tx *Transaction) { tx.Message.Header.NumRequiredSignatures = 0 },
+		"header":            func(tx *Transaction) { tx.Message.Header.NumReadonlyUnsignedAccounts = 2 },
+		"signatures":        func(tx *Transaction) { tx.Signatures = make([]Signature, 2) },
+		"duplicate account": func(tx *Transaction) { tx.Message.AccountKeys = PublicKeySlice{payer, payer} },
+		"payer program":     func(tx *Transaction) { tx.Message.Instructions[0].ProgramIDIndex = 0 },
+		"account index":     func(tx *Transaction) { tx.Message.Instructions[0].Accounts = []uint16{3} },
+		"empty lookup":      func(tx *Transaction) { tx.Message.AddressTableLookups[0].ReadonlyIndexes = nil },
+	} {
+		tx := &Transaction{Message: message}
+		tx.Message.AccountKeys = append(PublicKeySlice{}, message.AccountKeys...)
+		tx.Message.Instructions = []CompiledInstruction{{ProgramIDIndex: 1, Accounts: []uint16{0, 2}}}
+		tx.Message.AddressTableLookups = []MessageAddressTableLookup{{AccountKey: message.AddressTableLookups[0].AccountKey, ReadonlyIndexes: indexes[:1]}}
+		broken(tx)
+		require.ErrorIs(t, tx.Validate(), ErrInvalidTransaction, name)
+	}
+}
+
+func TestNewTransactionWithinLimits(t *testing.T) {
+	payer := NewWallet().PublicKey()
+	instructions := []Instruction{&testTransactionInstructions{
+		accounts:  []*AccountMeta{Meta(payer).WRITE().SIGNER(), Meta(NewWallet().PublicKey()).WRITE()},
+		data:      []byte{1},
+		programID: NewWallet().PublicKey(),
+	}}
+	table := PublicKeySlice{instructions[0].Accounts()[1].PublicKey}
+
+	// It fits: no tables.
+	tx, err := NewTransactionWithinLimits(instructions, Hash{}, map[PublicKey]PublicKeySlice{NewWallet().PublicKey(): table})
+	require.NoError(t, err)
+	require.Equal(t, MessageVersionLegacy, tx.Message.GetVersion())
+	require.Empty(t, tx.Message.AddressTableLookups)
+
+	// Too large, and no table to help.
+	large := []Instruction{&testTransactionInstructions{
+		accounts:  []*AccountMeta{Meta(payer).WRITE().SIGNER()},
+		data:      bytes.Repeat([]byte{1}, PacketDataSize),
+		programID: NewWallet().PublicKey(),
+	}}
+	_, err = NewTransactionWithinLimits(large, Hash{}, map[PublicKey]PublicKeySlice{NewWallet().PublicKey(): table})
+	require.ErrorIs(t, err, ErrTransactionTooLarge)
+
+	// 40 accounts don't fit as static keys: the table holding most of them is enough.
+	many := make([]*AccountMeta, 0, 41)
+	many = append(many, Meta(payer).WRITE().SIGNER())
+	var most, rest PublicKeySlice
+	for i := 0; i < 40; i++ {
+		key := NewWallet().PublicKey()
+		many = append(many, Meta(key))
+		if i < 30 {
+			most = append(most, key)
+		} else {
+			rest = append(rest, key)
+		}
+	}
+	mostTable, restTable := NewWallet().PublicKey(), NewWallet().PublicKey()
+	tx, err = NewTransactionWithinLimits([]Instruction{&testTransactionInstructions{accounts: many, data: []byte{1}, programID: NewWallet().PublicKey()}}, Hash{},
+		map[PublicKey]PublicKeySlice{mostTable: most, restTable: rest})
+	require.NoError(t, err)
+	require.Equal(t, MessageVersionV0, tx.Message.GetVersion())
+	require.Len(t, tx.Message.AddressTableLookups, 1)
+	require.Equal(t, mostTable, tx.Message.AddressTableLookups[0].AccountKey)
+	size, err := tx.EstimateSize()
+	require.NoError(t, err)
+	require.True(t, size.Fits())
+	require.Equal(t, 30, size.LookupAccounts)
+}
+
+func TestRankAddressTables(t *testing.T) {
+	payer, signer, programID := NewWallet().PublicKey(), NewWallet().PublicKey(), NewWallet().PublicKey()
+	a, b, c := NewWallet().PublicKey(), NewWallet().PublicKey(), NewWallet().PublicKey()
+	instructions := []Instruction{&testTransactionInstructions{
+		accounts:  []*AccountMeta{Meta(payer).WRITE().SIGNER(), Meta(signer).SIGNER(), Meta(a).WRITE(), Meta(b), Meta(c)},
+		programID: programID,
+	}}
+	small, large, useless := NewWallet().PublicKey(), NewWallet().PublicKey(), NewWallet().PublicKey()
+	ranked := rankAddressTables(instructions, payer, map[PublicKey]PublicKeySlice{
+		small:   {a, payer},
+		large:   {b, c, a, a},
+		useless: {signer, programID, payer, NewWallet().PublicKey()},
+	})
+	require.Equal(t, []PublicKey{large, small}, ranked)
+}
@@ -490,6 +490,83 @@ func (msg *Message) SetAddressTables(tables map[PublicKey]PublicKeySlice) error{
   return nil
 }
 
+// GetAddressTables returns the tables set with `SetAddressTables` (nil if not set).
+func (msg Message) GetAddressTables() map[PublicKey]PublicKeySlice{
+  return msg.addressTables
+}
+
+// GetAllKeys returns the static account keys followed by the keys loaded from the address tables:
+// first the writable ones, then the readonly ones, table after table.
+// This is the order instruction account indexes refer to.
+func (msg Message) GetAllKeys() (PublicKeySlice, error){
+  if msg.resolved || msg.NumLookups() == 0{
+    return msg.AccountKeys, nil
+  }
+  if msg.addressTables == nil{
+    return nil, fmt.Errorf("address tables not set: call SetAddressTables first")
+  }
+  keys := make(PublicKeySlice, 0, len(msg.AccountKeys)+msg.NumLookups())
+  keys = append(keys, msg.AccountKeys...)
+  var readonly PublicKeySlice
+  for _, lookup := range msg.AddressTableLookups{
+    table, ok := msg.addressTables[lookup.AccountKey]
+    if !ok{
+      return nil, fmt.Errorf("address table %s not set", lookup.AccountKey)
+    }
+    for _, idx := range lookup.WritableIndexes{
+      if int(idx) >= len(table){
+        return nil, fmt.Errorf("address table %s has no index %d", lookup.AccountKey, idx)
+      }
+      keys = append(keys, table[idx])
+    }
+    for _, idx := range lookup.ReadonlyIndexes{
+      if int(idx) >= len(table){
+        return nil, fmt.Errorf("address table %s has no index %d", lookup.AccountKey, idx)
+      }
+      readonly = append(readonly, table[idx])
+    }
+  }
+  return append(keys, readonly...), nil
+}
+
+// ResolveLookups appends the keys loaded from the address tables to `AccountKeys`
+// (see GetAllKeys), so instruction account indexes can be used on it directly.
+// The message still encodes only its static keys.
+func (msg *Message) ResolveLookups() error{
+  if msg.resolved{
+    return nil
+  }
+  keys, err := msg.GetAllKeys()
+  if err != nil{
+    return err
+  }
+  msg.AccountKeys = keys
+  msg.resolved = msg.NumLookups() > 0
+  return nil
+}
+
+// IsSignerIndex tells whether the account at the given index (in GetAllKeys order) signs the message.
+func (msg Message) IsSignerIndex(index int) bool{
+  return index >= 0 && index < int(msg.Header.NumRequiredSignatures)
+}
+
+// IsWritableIndex tells whether the account at the given index (in GetAllKeys order) is writable:
+// signed keys come first, then unsigned keys, each with their readonly keys last,
+// then the writable keys loaded from the address tables, then the readonly ones.
+func (msg Message) IsWritableIndex(index int) bool{
+  numStatic := msg.numStaticAccounts()
+  switch {
+  case index < 0:
+    return false
+  case index < int(msg.Header.NumRequiredSignatures):
+    return index < int(msg.Header.NumRequiredSignatures)-int(msg.Header.NumReadonlySignedAccounts)
+  case index < numStatic:
+    return index < numStatic-int(msg.Header.NumReadonlyUnsignedAccounts)
+  default:
+    return index < numStatic+msg.NumWritableLookups()
+  }
+}
+
 func (msg *Message) SetAddressTableLookups(lookups []MessageAddressTableLookup) *Message{
   msg.AddressTableLookups = lookups
   msg.version = MessageVersionV0
@@ -0,0 +1,107 @@
+package solana
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39"
+)
+
+// DefaultDerivationPath is the path of the first account of Phantom, Solflare and the other wallets
+// following BIP44 for Solana (coin type 501); the following accounts are m/44'/501'/1'/0', ...
+const DefaultDerivationPath = "m/44'/501'/0'/0'"
+
+// hardenedOffset is added to the index of a hardened child; SLIP-0010 only has those for ed25519.
+const hardenedOffset = 0x80000000
+
+// NewMnemonic returns a random BIP39 mnemonic (english) of `bits` bits of entropy:
+// 128 for 12 words, up to 256 for 24 words.
+func NewMnemonic(bits int) (string, error) {
+	entropy, err := bip39.NewEntropy(bits)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// PrivateKeyFromMnemonic derives a key from a BIP39 mnemonic and its passphrase ("" if none).
+// The BIP39 seed is derived along the SLIP-0010 path, such as DefaultDerivationPath.
+// An empty path gives the key of solana-keygen, made of the first 32 bytes of the seed.
+func PrivateKeyFromMnemonic(mnemonic string, passphrase string, path string) (PrivateKey, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mnemonic: %w", err)
+	}
+	if path == "" {
+		return PrivateKey(ed25519.NewKeyFromSeed(seed[:ed25519.SeedSize])), nil
+	}
+	return PrivateKeyFromSeed(seed, path)
+}
+
+// PrivateKeyFromSeed derives a key from a seed along a SLIP-0010 ed25519 path
+// (only hardened indexes: m/44'/501'/0'/0').
+func PrivateKeyFromSeed(seed []byte, path string) (PrivateKey, error) {
+	indexes, err := ParseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+	key, chainCode := slip10MasterKey(seed)
+	for _, index := range indexes {
+		key, chainCode = slip10Child(key, chainCode, index)
+	}
+	return PrivateKey(ed25519.NewKeyFromSeed(key)), nil
+}
+
+// ParseDerivationPath returns the indexes of a path such as m/44'/501'/0'/0',
+// with the hardened offset added. Every index must be hardened (' or h).
+func ParseDerivationPath(path string) ([]uint32, error) {
+	segments := strings.Split(path, "/")
+	if segments[0] != "m" {
+		return nil, fmt.Errorf("invalid derivation path %q: must start with m", path)
+	}
+	indexes := make([]uint32, 0, len(segments)-1)
+	for _, segment := range segments[1:] {
+		trimmed := strings.TrimRight(segment, "'hH")
+		if len(trimmed) != len(segment)-1 {
+			return nil, fmt.Errorf("invalid derivation path %q: %q is not a hardened index", path, segment)
+		}
+		index, err := strconv.ParseUint(trimmed, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid derivation path %q: %q is not an index", path, segment)
+		}
+		indexes = append(indexes, uint32(index)+hardenedOffset)
+	}
+	return indexes, nil
+}
+
+func slip10MasterKey(seed []byte) (key []byte, chainCode []byte) {
+	mac := hmac.New(sha512.New, []byte("ed25519 seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	return sum[:32], sum[32:]
+}
+
+func slip10Child(key []byte, chainCode []byte, index uint32) ([]byte, []byte) {
+	data := make([]byte, 0, 1+32+4)
+	data = append(data, 0)
+	data = append(data, key...)
+	data = binary.BigEndian.AppendUint32(data, index)
+	mac := hmac.New(sha512.New, chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+	return sum[:32], sum[32:]
+}
+
+// WalletFromMnemonic returns the wallet of a BIP39 mnemonic; see PrivateKeyFromMnemonic.
+func WalletFromMnemonic(mnemonic string, passphrase string, path string) (*Wallet, error) {
+	k, err := PrivateKeyFromMnemonic(mnemonic, passphrase, path)
+	if err != nil {
+		return nil, err
+	}
+	return &Wallet{PrivateKey: k}, nil
+}
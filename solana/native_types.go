@@ -1,6 +1,7 @@
 package solana
 
 import (
+	"crypto/ed25519"
 	"encoding/base64"
 	"fmt"
 
@@ -119,6 +120,11 @@ func (sig Signature) Equals(pb Signature) bool {
 	return sig == pb
 }
 
+// Verify checks that the signature is the one of msg by the key of pubkey.
+func (sig Signature) Verify(pubkey PublicKey, msg []byte) bool {
+	return ed25519.Verify(pubkey[:], msg, sig[:])
+}
+
 func (s Signature) String() string {
 	return base58.Encode(s[:])
 }
@@ -0,0 +1,158 @@
+package solana
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// OffchainMessageSigningDomain starts every off-chain message, so that its signature
+// can't be mistaken for the signature of a transaction.
+const OffchainMessageSigningDomain = "\xffsolana offchain"
+
+const (
+	// signing domain, version, format, length
+	offchainMessageHeaderLength = len(OffchainMessageSigningDomain) + 1 + 1 + 2
+	// OffchainMessageMaxLength is the longest message; Ledger devices only sign up to OffchainMessageMaxLedgerLength.
+	OffchainMessageMaxLength       = 0xffff - offchainMessageHeaderLength
+	OffchainMessageMaxLedgerLength = PacketDataSize - offchainMessageHeaderLength
+)
+
+type OffchainMessageFormat uint8
+
+const (
+	// Printable ASCII characters, at most OffchainMessageMaxLedgerLength bytes.
+	OffchainMessageFormatRestrictedASCII OffchainMessageFormat = iota
+	// UTF-8, at most OffchainMessageMaxLedgerLength bytes.
+	OffchainMessageFormatLimitedUTF8
+	// UTF-8, at most OffchainMessageMaxLength bytes.
+	OffchainMessageFormatExtendedUTF8
+)
+
+// OffchainMessage is a message signed off chain the way `solana sign-offchain-message` does
+// (version 0): the signature is the one of MarshalBinary(), the message behind a header.
+type OffchainMessage struct {
+	Version uint8
+	Format  OffchainMessageFormat
+	Message []byte
+}
+
+// NewOffchainMessage returns the version 0 off-chain message of msg, in the most restricted format that fits it.
+func NewOffchainMessage(msg []byte) (*OffchainMessage, error) {
+	out := &OffchainMessage{Message: msg}
+	switch {
+	case len(msg) == 0:
+		return nil, errors.New("empty off-chain message")
+	case len(msg) <= OffchainMessageMaxLedgerLength && isPrintableASCII(msg):
+		out.Format = OffchainMessageFormatRestrictedASCII
+	case len(msg) <= OffchainMessageMaxLedgerLength && utf8.Valid(msg):
+		out.Format = OffchainMessageFormatLimitedUTF8
+	case len(msg) <= OffchainMessageMaxLength && utf8.Valid(msg):
+		out.Format = OffchainMessageFormatExtendedUTF8
+	case len(msg) > OffchainMessageMaxLength:
+		return nil, fmt.Errorf("off-chain message of %d bytes is too long, max %d", len(msg), OffchainMessageMaxLength)
+	default:
+		return nil, errors.New("off-chain message is not valid UTF-8")
+	}
+	return out, nil
+}
+
+func (m *OffchainMessage) validate() error {
+	if m.Version != 0 {
+		return fmt.Errorf("unsupported off-chain message version %d", m.Version)
+	}
+	if len(m.Message) == 0 {
+		return errors.New("empty off-chain message")
+	}
+	switch m.Format {
+	case OffchainMessageFormatRestrictedASCII:
+		if len(m.Message) > OffchainMessageMaxLedgerLength || !isPrintableASCII(m.Message) {
+			return errors.New("off-chain message doesn't fit the restricted ASCII format")
+		}
+	case OffchainMessageFormatLimitedUTF8:
+		if len(m.Message) > OffchainMessageMaxLedgerLength || !utf8.Valid(m.Message) {
+			return errors.New("off-chain message doesn't fit the limited UTF-8 format")
+		}
+	case OffchainMessageFormatExtendedUTF8:
+		if len(m.Message) > OffchainMessageMaxLength || !utf8.Valid(m.Message) {
+			return errors.New("off-chain message doesn't fit the extended UTF-8 format")
+		}
+	default:
+		return fmt.Errorf("invalid off-chain message format %d", m.Format)
+	}
+	return nil
+}
+
+// MarshalBinary returns the bytes that are signed.
+func (m *OffchainMessage) MarshalBinary() ([]byte, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0, offchainMessageHeaderLength+len(m.Message))
+	out = append(out, OffchainMessageSigningDomain...)
+	out = append(out, m.Version, byte(m.Format))
+	out = binary.LittleEndian.AppendUint16(out, uint16(len(m.Message)))
+	return append(out, m.Message...), nil
+}
+
+func (m *OffchainMessage) UnmarshalBinary(data []byte) error {
+	if len(data) < offchainMessageHeaderLength || string(data[:len(OffchainMessageSigningDomain)]) != OffchainMessageSigningDomain {
+		return errors.New("not an off-chain message")
+	}
+	header := data[len(OffchainMessageSigningDomain):offchainMessageHeaderLength]
+	length := int(binary.LittleEndian.Uint16(header[2:]))
+	if len(data)-offchainMessageHeaderLength != length {
+		return fmt.Errorf("off-chain message length is %d, but %d bytes follow the header", length, len(data)-offchainMessageHeaderLength)
+	}
+	decoded := OffchainMessage{Version: header[0], Format: OffchainMessageFormat(header[1]), Message: data[offchainMessageHeaderLength:]}
+	if err := decoded.validate(); err != nil {
+		return err
+	}
+	*m = decoded
+	return nil
+}
+
+func (m *OffchainMessage) Sign(ctx context.Context, signer Signer) (Signature, error) {
+	data, err := m.MarshalBinary()
+	if err != nil {
+		return Signature{}, err
+	}
+	return signer.SignMessage(ctx, data)
+}
+
+func (m *OffchainMessage) Verify(pubkey PublicKey, sig Signature) bool {
+	data, err := m.MarshalBinary()
+	if err != nil {
+		return false
+	}
+	return sig.Verify(pubkey, data)
+}
+
+// SignOffchainMessage signs msg as an off-chain message, like `solana sign-offchain-message`.
+func SignOffchainMessage(ctx context.Context, signer Signer, msg []byte) (Signature, error) {
+	m, err := NewOffchainMessage(msg)
+	if err != nil {
+		return Signature{}, err
+	}
+	return m.Sign(ctx, signer)
+}
+
+// VerifyOffchainMessage checks the signature of msg as an off-chain message, like `solana verify-offchain-signature`.
+func VerifyOffchainMessage(pubkey PublicKey, msg []byte, sig Signature) bool {
+	m, err := NewOffchainMessage(msg)
+	if err != nil {
+		return false
+	}
+	return m.Verify(pubkey, sig)
+}
+
+func isPrintableASCII(b []byte) bool {
+	for _, c := range b {
+		if c < 0x20 || c > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -6,6 +6,28 @@ var(
 	SystemProgramID = MustPubkeyFromBase58("11111111111111111111111111111111")
 )
 
+var(
+  // Creates and manages the address lookup tables v0 transactions load accounts from.
+  AddressLookupTableProgramID = MustPubkeyFromBase58("AddressLookupTab1e1111111111111111111111111")
+)
+
+var(
+  // Sets the compute unit limit and price, the heap size and the loaded accounts data size limit of a transaction.
+  ComputeBudgetProgramID = MustPubkeyFromBase58("ComputeBudget111111111111111111111111111111")
+)
+
+var(
+  // Records a UTF-8 memo in the transaction log, verifying the signers it is given.
+  MemoProgramID = MustPubkeyFromBase58("MemoSq4gqABAXKb96qnH8TysNcWxMyWCqXgDLGmfcHr")
+  // The first version of the Memo program, which takes no signers.
+  MemoV1ProgramID = MustPubkeyFromBase58("Memo1UhkJRfHyvLMcW6Lqz9wm3EwufrCEVZp9WUAXpj")
+)
+
+var(
+  // Metaplex Token Metadata: the name, symbol, URI and creators of a mint, stored at a PDA of the mint.
+  TokenMetadataProgramID = MustPubkeyFromBase58("metaqbxxUerdq28cj1RbAWkYQm3ybzjb6a8bt518x1s")
+)
+
 var(
   // The Mint for native SOL Token accounts
 	SolMint    = MustPubkeyFromBase58("So11111111111111111111111111111111111111112")
@@ -16,6 +38,9 @@ var(
   // A Token program on the Solana blockchain.
   // This program defines a common implementation for Fungible and Non Fungible tokens.
   TokenProgramID = MustPubkeyFromBase58("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+  // The Token program with extensions (transfer fees, metadata, transfer hooks...).
+  // Mints and accounts share the Token layouts, extensions are appended after them.
+  Token2022ProgramID = MustPubkeyFromBase58("TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb")
   // This program defines the convention and provides the mechanism for mapping
 	// the user's wallet address to the associated token accounts they hold.
 	SPLAssociatedTokenAccountProgramID = MustPubkeyFromBase58("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
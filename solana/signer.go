@@ -0,0 +1,77 @@
+package solana
+
+import (
+	"context"
+	"fmt"
+)
+
+// Signer signs messages for an account, wherever its key lives: in memory (PrivateKey, *Wallet),
+// in an encrypted file, behind a remote service...
+type Signer interface {
+	PublicKey() PublicKey
+	// SignMessage returns the ed25519 signature of msg by the key of PublicKey().
+	SignMessage(ctx context.Context, msg []byte) (Signature, error)
+}
+
+var (
+	_ Signer = PrivateKey(nil)
+	_ Signer = (*Wallet)(nil)
+)
+
+// SignMessage makes a PrivateKey a Signer.
+func (key PrivateKey) SignMessage(_ context.Context, msg []byte) (Signature, error) {
+	return key.Sign(msg)
+}
+
+func (acc *Wallet) SignMessage(ctx context.Context, msg []byte) (Signature, error) {
+	return acc.PrivateKey.SignMessage(ctx, msg)
+}
+
+// PartialSignWith signs the transaction with the signers that are among its required signers;
+// the signatures of the others are left as they are (zero if not signed yet), to be added later,
+// by another party for instance. Signers that aren't required signers are ignored.
+func (tx *Transaction) PartialSignWith(ctx context.Context, signers ...Signer) (out []Signature, err error) {
+	messageContent, err := tx.Message.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode message for signing: %w", err)
+	}
+	signerKeys := tx.Message.signerKeys()
+
+	if len(tx.Signatures) == 0 {
+		tx.Signatures = make([]Signature, len(signerKeys))
+	} else if len(tx.Signatures) != len(signerKeys) {
+		return nil, fmt.Errorf("invalid signatures length, expected %d, actual %d", len(signerKeys), len(tx.Signatures))
+	}
+
+	for _, signer := range signers {
+		key := signer.PublicKey()
+		for i, signerKey := range signerKeys {
+			if !signerKey.Equals(key) {
+				continue
+			}
+			sig, err := signer.SignMessage(ctx, messageContent)
+			if err != nil {
+				return nil, fmt.Errorf("failed to sign with key %q: %w", key.String(), err)
+			}
+			tx.Signatures[i] = sig
+		}
+	}
+	return tx.Signatures, nil
+}
+
+// SignWith signs the transaction with the signers, which must include every required signer.
+func (tx *Transaction) SignWith(ctx context.Context, signers ...Signer) (out []Signature, err error) {
+	for _, key := range tx.Message.signerKeys() {
+		found := false
+		for _, signer := range signers {
+			if signer.PublicKey().Equals(key) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("signer key %q not found. Ensure all the signer keys are provided", key.String())
+		}
+	}
+	return tx.PartialSignWith(ctx, signers...)
+}
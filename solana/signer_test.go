@@ -0,0 +1,43 @@
+package solana
+
+import (
+	"context"
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransaction_SignWith(t *testing.T) {
+	payer, cosigner := NewWallet(), NewWallet()
+	tx, err := NewTransaction([]Instruction{&testTransactionInstructions{
+		accounts:  []*AccountMeta{Meta(payer.PublicKey()).WRITE().SIGNER(), Meta(cosigner.PublicKey()).SIGNER()},
+		data:      []byte{1},
+		programID: NewWallet().PublicKey(),
+	}}, Hash{}, TransactionPayer(payer.PublicKey()))
+	require.NoError(t, err)
+	payerKey, cosignerKey := payer.PublicKey(), cosigner.PublicKey()
+	msg, err := tx.Message.MarshalBinary()
+	require.NoError(t, err)
+
+	_, err = tx.SignWith(context.Background(), payer)
+	require.Error(t, err)
+
+	// The payer signs first, the co-signer adds its signature later.
+	sigs, err := tx.PartialSignWith(context.Background(), payer, NewWallet())
+	require.NoError(t, err)
+	require.Len(t, sigs, 2)
+	require.True(t, ed25519.Verify(payerKey[:], msg, sigs[0][:]))
+	require.True(t, sigs[1].IsZero())
+
+	sigs, err = tx.PartialSignWith(context.Background(), cosigner.PrivateKey)
+	require.NoError(t, err)
+	require.True(t, ed25519.Verify(payerKey[:], msg, sigs[0][:]))
+	require.True(t, ed25519.Verify(cosignerKey[:], msg, sigs[1][:]))
+
+	fresh := *tx
+	fresh.Signatures = nil
+	sigs, err = fresh.SignWith(context.Background(), cosigner, payer)
+	require.NoError(t, err)
+	require.Equal(t, tx.Signatures, sigs)
+}
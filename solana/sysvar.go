@@ -2,4 +2,5 @@ package solana
 
 var(
   SysVarRentPubkey = MustPubkeyFromBase58("SysvarRent111111111111111111111111111111111")
+  SysVarRecentBlockHashesPubkey = MustPubkeyFromBase58("SysvarRecentB1ockHashes11111111111111111111")
 )
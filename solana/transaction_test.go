@@ -80,3 +80,58 @@ func TestNewTransactions(t *testing.T) {
 		},
 	})
 }
+
+func TestResolveInstructionAccounts(t *testing.T) {
+	payer := NewWallet().PublicKey()
+	signer := NewWallet().PublicKey()
+	writable := NewWallet().PublicKey()
+	readonly := NewWallet().PublicKey()
+	programID := NewWallet().PublicKey()
+
+	accounts := []*AccountMeta{
+		{PublicKey: payer, IsSigner: true, IsWritable: true},
+		{PublicKey: signer, IsSigner: true},
+		{PublicKey: writable, IsWritable: true},
+		{PublicKey: readonly},
+	}
+	trx, err := NewTransaction([]Instruction{
+		&testTransactionInstructions{accounts: accounts, data: []byte{1}, programID: programID},
+	}, Hash{})
+	require.NoError(t, err)
+
+	resolved, err := trx.Message.Instructions[0].ResolveInstructionAccounts(&trx.Message)
+	require.NoError(t, err)
+	assert.Equal(t, accounts, resolved)
+
+	// v0: the loaded writable accounts come before the loaded readonly ones.
+	table := NewWallet().PublicKey()
+	loadedWritable, loadedReadonly := NewWallet().PublicKey(), NewWallet().PublicKey()
+	message := Message{
+		Header:      MessageHeader{NumRequiredSignatures: 1, NumReadonlyUnsignedAccounts: 1},
+		AccountKeys: PublicKeySlice{payer, programID},
+		Instructions: []CompiledInstruction{
+			{ProgramIDIndex: 1, Accounts: []uint16{0, 2, 3}},
+		},
+	}
+	message.SetAddressTableLookups([]MessageAddressTableLookup{
+		{AccountKey: table, WritableIndexes: []uint8{1}, ReadonlyIndexes: []uint8{0}},
+	})
+	_, err = message.Instructions[0].ResolveInstructionAccounts(&message)
+	require.Error(t, err, "tables not set")
+
+	require.NoError(t, message.SetAddressTables(map[PublicKey]PublicKeySlice{
+		table: {loadedReadonly, loadedWritable},
+	}))
+	resolved, err = message.Instructions[0].ResolveInstructionAccounts(&message)
+	require.NoError(t, err)
+	assert.Equal(t, []*AccountMeta{
+		{PublicKey: payer, IsSigner: true, IsWritable: true},
+		{PublicKey: loadedWritable, IsWritable: true},
+		{PublicKey: loadedReadonly},
+	}, resolved)
+	assert.False(t, message.IsWritableIndex(1), "the program is readonly")
+
+	message.Instructions[0].Accounts = []uint16{4}
+	_, err = message.Instructions[0].ResolveInstructionAccounts(&message)
+	require.Error(t, err)
+}
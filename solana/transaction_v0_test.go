@@ -0,0 +1,154 @@
+package solana
+
+import (
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/stretchr/testify/require"
+)
+
+// v0Fixture is a synthetic transaction loading accounts from two address tables.
+// Some accounts are in a table but can't be loaded from it: a signer and a program.
+type v0Fixture struct {
+	payer, signer, writable1, readonly, writable2, static, program PublicKey
+	tableA, tableB                                                 PublicKey
+	tables                                                         map[PublicKey]PublicKeySlice
+	instructions                                                   []Instruction
+}
+
+func testKey(b byte) PublicKey {
+	return PublicKey{b, 0xee}
+}
+
+func newV0Fixture() *v0Fixture {
+	f := &v0Fixture{
+		payer: testKey(1), signer: testKey(2), writable1: testKey(3), readonly: testKey(4),
+		writable2: testKey(5), static: testKey(6), program: testKey(7),
+		tableA: testKey(0xa0), tableB: testKey(0xb0),
+	}
+	f.tables = map[PublicKey]PublicKeySlice{
+		f.tableA: {testKey(9), f.writable1, f.readonly, f.signer, f.program},
+		// writable1 is in both tables: it is loaded from the first one (by key), tableA.
+		f.tableB: {f.writable2, f.writable1},
+	}
+	f.instructions = []Instruction{
+		&testTransactionInstructions{
+			accounts: []*AccountMeta{
+				Meta(f.payer).WRITE().SIGNER(), Meta(f.signer).SIGNER(), Meta(f.writable1).WRITE(),
+				Meta(f.readonly), Meta(f.writable2).WRITE(), Meta(f.static),
+			},
+			data:      []byte{0xde, 0xad},
+			programID: f.program,
+		},
+		&testTransactionInstructions{
+			accounts:  []*AccountMeta{Meta(f.writable2).WRITE(), Meta(f.readonly)},
+			programID: f.program,
+		},
+	}
+	return f
+}
+
+func TestNewTransaction_V0(t *testing.T) {
+	f := newV0Fixture()
+	blockhash := Hash{0x42}
+	tx, err := NewTransaction(f.instructions, blockhash, TransactionAddressTables(f.tables))
+	require.NoError(t, err)
+
+	// The wire format, assembled by hand from the compiler's rules (not recorded from a cluster,
+	// see TestNewTransaction_V0Recorded for that):
+	// static keys: payer, signer, static, program; loaded: writable1 (A), writable2 (B), readonly (A).
+	var want []byte
+	want = append(want, 0x80)             // version 0
+	want = append(want, 0x02, 0x01, 0x02) // header
+	want = append(want, 0x04)             // static keys
+	for _, key := range []PublicKey{f.payer, f.signer, f.static, f.program} {
+		want = append(want, key[:]...)
+	}
+	want = append(want, blockhash[:]...)
+	want = append(want, 0x02)                                                             // instructions
+	want = append(want, 0x03, 0x06, 0x00, 0x01, 0x04, 0x06, 0x05, 0x02, 0x02, 0xde, 0xad) // program, accounts, data
+	want = append(want, 0x03, 0x02, 0x05, 0x06, 0x00)
+	want = append(want, 0x02) // lookups
+	want = append(want, f.tableA[:]...)
+	want = append(want, 0x01, 0x01, 0x01, 0x02) // writable [1], readonly [2]
+	want = append(want, f.tableB[:]...)
+	want = append(want, 0x01, 0x00, 0x00) // writable [0], readonly []
+
+	msg, err := tx.Message.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, want, msg)
+	require.Equal(t, MessageVersionV0, tx.Message.GetVersion())
+	require.Equal(t, MessageHeader{NumRequiredSignatures: 2, NumReadonlySignedAccounts: 1, NumReadonlyUnsignedAccounts: 2}, tx.Message.Header)
+	require.NoError(t, tx.Validate())
+
+	// The instructions see the accounts they were given.
+	for i, instruction := range f.instructions {
+		resolved, err := tx.Message.Instructions[i].ResolveInstructionAccounts(&tx.Message)
+		require.NoError(t, err)
+		require.Equal(t, instruction.Accounts(), resolved, "instruction %d", i)
+	}
+
+	// The same transaction whatever the map order.
+	for i := 0; i < 20; i++ {
+		again, err := NewTransaction(newV0Fixture().instructions, blockhash, TransactionAddressTables(f.tables))
+		require.NoError(t, err)
+		againMsg, err := again.Message.MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, want, againMsg)
+	}
+}
+
+func TestNewTransaction_V0RoundTrip(t *testing.T) {
+	f := newV0Fixture()
+	tx, err := NewTransaction(f.instructions, Hash{0x42}, TransactionAddressTables(f.tables))
+	require.NoError(t, err)
+	tx.Signatures = make([]Signature, tx.Message.Header.NumRequiredSignatures)
+	raw, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	size, err := tx.EstimateSize()
+	require.NoError(t, err)
+	require.Equal(t, len(raw), size.Size)
+	require.Equal(t, 3, size.LookupAccounts)
+	require.Equal(t, 2, size.AddressTableLookups)
+
+	decoded := new(Transaction)
+	require.NoError(t, decoded.UnmarshalWithDecoder(bin.NewBinDecoder(raw)))
+	require.Equal(t, MessageVersionV0, decoded.Message.GetVersion())
+	reencoded, err := decoded.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, raw, reencoded)
+
+	require.NoError(t, decoded.Message.SetAddressTables(f.tables))
+	keys, err := decoded.Message.GetAllKeys()
+	require.NoError(t, err)
+	require.Equal(t, PublicKeySlice{f.payer, f.signer, f.static, f.program, f.writable1, f.writable2, f.readonly}, keys)
+	for i, instruction := range f.instructions {
+		resolved, err := decoded.Message.Instructions[i].ResolveInstructionAccounts(&decoded.Message)
+		require.NoError(t, err)
+		require.Equal(t, instruction.Accounts(), resolved, "instruction %d", i)
+	}
+}
+
+func TestNewTransaction_V0Tables(t *testing.T) {
+	f := newV0Fixture()
+
+	// No account of the instructions in the tables: a legacy transaction.
+	tx, err := NewTransaction(f.instructions, Hash{}, TransactionAddressTables(map[PublicKey]PublicKeySlice{
+		f.tableA: {testKey(9), f.payer, f.signer, f.program},
+	}))
+	require.NoError(t, err)
+	require.Equal(t, MessageVersionLegacy, tx.Message.GetVersion())
+	require.Len(t, tx.Message.AccountKeys, 7)
+
+	// Only the tables used are looked up.
+	tx, err = NewTransaction(f.instructions, Hash{}, TransactionAddressTables(map[PublicKey]PublicKeySlice{
+		f.tableA: {testKey(9)},
+		f.tableB: {f.readonly},
+	}))
+	require.NoError(t, err)
+	require.Equal(t, []MessageAddressTableLookup{{AccountKey: f.tableB, ReadonlyIndexes: []uint8{0}}}, []MessageAddressTableLookup(tx.Message.AddressTableLookups))
+
+	tooLarge := make(PublicKeySlice, 257)
+	_, err = NewTransaction(f.instructions, Hash{}, TransactionAddressTables(map[PublicKey]PublicKeySlice{f.tableA: tooLarge}))
+	require.Error(t, err)
+}
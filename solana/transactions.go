@@ -1,6 +1,7 @@
 package solana
 
 import (
+	"bytes"
 	"encoding/base64"
 	"fmt"
 	"sort"
@@ -105,6 +106,28 @@ type CompiledInstruction struct {
 	Data Base58 `json:"data"`
 }
 
+// ResolveInstructionAccounts returns the accounts of the instruction, with their signer
+// and writable flags, as the program sees them. The address tables of a v0 message must be set
+// (see Message.SetAddressTables) for the accounts it loads from them.
+func (ci *CompiledInstruction) ResolveInstructionAccounts(message *Message) ([]*AccountMeta, error) {
+	keys, err := message.GetAllKeys()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*AccountMeta, len(ci.Accounts))
+	for i, index := range ci.Accounts {
+		if int(index) >= len(keys) {
+			return nil, fmt.Errorf("account index %d out of range: the message has %d accounts", index, len(keys))
+		}
+		out[i] = &AccountMeta{
+			PublicKey:  keys[index],
+			IsWritable: message.IsWritableIndex(int(index)),
+			IsSigner:   message.IsSignerIndex(int(index)),
+		}
+	}
+	return out, nil
+}
+
 type Instruction interface {
 	ProgramID() PublicKey     // <-- the programID the instruction acts on
 	Accounts() []*AccountMeta // <-- returns the list of accounts the instructions require
@@ -130,6 +153,8 @@ func TransactionPayer(payer PublicKey) TransactionOption {
 	return transactionOptionFunc(func(opts *transactionOptions) { opts.payer = payer })
 }
 
+// TransactionAddressTables makes a v0 transaction loading from the tables (table key -> addresses)
+// the accounts that aren't signers nor programs. Tables holding none of them are left out.
 func TransactionAddressTables(tables map[PublicKey]PublicKeySlice) TransactionOption {
 	return transactionOptionFunc(func(opts *transactionOptions) { opts.addressTables = tables })
 }
@@ -142,7 +167,7 @@ type addressTablePubkeyWithIndex struct {
 }
 
 func NewTransaction(instructions []Instruction, recentBlockHash Hash, opts ...TransactionOption) (*Transaction, error) {
-	if len(instructions) < 0 {
+	if len(instructions) == 0 {
 		return nil, fmt.Errorf("requires at least one instruction to create a transaction")
 	}
 
@@ -170,8 +195,18 @@ func NewTransaction(instructions []Instruction, recentBlockHash Hash, opts ...Tr
 		}
 	}
 
+	// Tables are searched in the order of their keys, so that the message doesn't depend on the map order:
+	// an address found in several tables is loaded from the first one.
+	addressTableKeys := make(PublicKeySlice, 0, len(options.addressTables))
+	for addressTablePubKey := range options.addressTables {
+		addressTableKeys = append(addressTableKeys, addressTablePubKey)
+	}
+	sort.Slice(addressTableKeys, func(i, j int) bool {
+		return bytes.Compare(addressTableKeys[i][:], addressTableKeys[j][:]) < 0
+	})
 	addressLookupKeysMap := make(map[PublicKey]addressTablePubkeyWithIndex) // all accounts from tables as map
-	for addressTablePubKey, addressTable := range options.addressTables {   // map[addressTablePubKey] = PublicKeySlice
+	for _, addressTablePubKey := range addressTableKeys {
+		addressTable := options.addressTables[addressTablePubKey]
 		if len(addressTable) > 256 {
 			return nil, fmt.Errorf("max lookup table index exceeded for %s table", addressTablePubKey)
 		}
@@ -181,7 +216,7 @@ func NewTransaction(instructions []Instruction, recentBlockHash Hash, opts ...Tr
 			if ok {
 				continue
 			}
-			addressLookupKeysMap[address] = addressTablePubkeyWithIndex{addressTable: address, index: uint8(i)}
+			addressLookupKeysMap[address] = addressTablePubkeyWithIndex{addressTable: addressTablePubKey, index: uint8(i)}
 		}
 	}
 
@@ -271,13 +306,10 @@ func NewTransaction(instructions []Instruction, recentBlockHash Hash, opts ...Tr
 
 	message := Message{RecentBlockhash: recentBlockHash}
 
-	lookupsMap := make(map[PublicKey]struct { // extended MessageAddressTableLookup
-		AccountKey      PublicKey // the account key of address table (what accesses the table)
-		WritableIndexes []uint8
-		Writable        []PublicKey
-		ReadonlyIndexes []uint8
-		Readonly        []PublicKey
-	})
+	// The lookups of the tables used, in the order of addressTableKeys.
+	lookupsMap := make(map[PublicKey]*MessageAddressTableLookup)
+	lookupsWritableKeys := make(map[PublicKey]PublicKeySlice)
+	lookupsReadonlyKeys := make(map[PublicKey]PublicKeySlice)
 
 	for idx, acc := range allKeys {
 		if DebugNewTransaction {
@@ -288,15 +320,19 @@ func NewTransaction(instructions []Instruction, recentBlockHash Hash, opts ...Tr
 		_, IsInvoke := programIDsMap[acc.PublicKey]                                     // comma-ok synax* | IsInvoke is a bool in this case
 
 		if isPresentInTables && idx != 0 && !acc.IsSigner && !IsInvoke { // Present - not feePayer - not signer - no programID
-			lookup := lookupsMap[addressLookupKeyEntry.addressTable]
+			table := addressLookupKeyEntry.addressTable
+			lookup, ok := lookupsMap[table]
+			if !ok {
+				lookup = &MessageAddressTableLookup{AccountKey: table}
+				lookupsMap[table] = lookup
+			}
 			if acc.IsWritable {
 				lookup.WritableIndexes = append(lookup.WritableIndexes, addressLookupKeyEntry.index)
-				lookup.Writable = append(lookup.Writable, acc.PublicKey)
+				lookupsWritableKeys[table] = append(lookupsWritableKeys[table], acc.PublicKey)
 			} else {
 				lookup.ReadonlyIndexes = append(lookup.ReadonlyIndexes, addressLookupKeyEntry.index)
-				lookup.Writable = append(lookup.Readonly, acc.PublicKey)
+				lookupsReadonlyKeys[table] = append(lookupsReadonlyKeys[table], acc.PublicKey)
 			}
-			lookupsMap[addressLookupKeyEntry.addressTable] = lookup
 			continue
 		}
 
@@ -313,24 +349,23 @@ func NewTransaction(instructions []Instruction, recentBlockHash Hash, opts ...Tr
 		}
 	}
 
-	var lookupsWritableKeys []PublicKey
-	var lookupsReadonlyKeys []PublicKey
-
+	// Loaded accounts are indexed after the static ones: the writable ones of every table, then the readonly ones.
+	var loadedKeys PublicKeySlice
 	if len(lookupsMap) > 0 {
 		lookups := make([]MessageAddressTableLookup, 0, len(lookupsMap))
-		for tablePublicKey, lkp := range lookupsMap {
-			lookupsWritableKeys = append(lookupsWritableKeys, lkp.Writable...)
-			lookupsReadonlyKeys = append(lookupsReadonlyKeys, lkp.Readonly...)
-
-			lookups = append(lookups, MessageAddressTableLookup{
-				AccountKey:      tablePublicKey,
-				WritableIndexes: lkp.WritableIndexes,
-				ReadonlyIndexes: lkp.ReadonlyIndexes,
-			})
-			if DebugNewTransaction {
-				zlog.Debug("filled lookupsWritableKeys", zap.Int("writable_keys", len(lookupsWritableKeys)))
-				zlog.Debug("filled lookupsWritableKeys", zap.Int("readonly_keys", len(lookupsReadonlyKeys)))
+		var readonlyKeys PublicKeySlice
+		for _, tablePublicKey := range addressTableKeys {
+			lookup, ok := lookupsMap[tablePublicKey]
+			if !ok {
+				continue
 			}
+			lookups = append(lookups, *lookup)
+			loadedKeys = append(loadedKeys, lookupsWritableKeys[tablePublicKey]...)
+			readonlyKeys = append(readonlyKeys, lookupsReadonlyKeys[tablePublicKey]...)
+		}
+		loadedKeys = append(loadedKeys, readonlyKeys...)
+		if DebugNewTransaction {
+			zlog.Debug("accounts loaded from address tables", zap.Int("lookups", len(lookups)), zap.Int("loaded_keys", len(loadedKeys)))
 		}
 
 		err := message.SetAddressTables(options.addressTables)
@@ -344,16 +379,12 @@ func NewTransaction(instructions []Instruction, recentBlockHash Hash, opts ...Tr
 	}
 
 	var idx uint16
-	accountKeyIndex := make(map[string]uint16, len(message.AccountKeys)+len(lookupsWritableKeys)+len(lookupsReadonlyKeys))
+	accountKeyIndex := make(map[string]uint16, len(message.AccountKeys)+len(loadedKeys))
 	for _, acc := range message.AccountKeys {
 		accountKeyIndex[acc.String()] = idx
 		idx++
 	}
-	for _, acc := range lookupsWritableKeys {
-		accountKeyIndex[acc.String()] = idx
-		idx++
-	}
-	for _, acc := range lookupsReadonlyKeys {
+	for _, acc := range loadedKeys {
 		accountKeyIndex[acc.String()] = idx
 		idx++
 	}
@@ -434,6 +465,44 @@ func (tx *Transaction) Sign(getter privateKeyGetter) (out []Signature, err error
 	return tx.PartialSign(getter)
 }
 
+// VerifySignatures checks that the transaction has the signatures of all its signers, and that they are valid.
+func (tx *Transaction) VerifySignatures() error {
+	missing, err := tx.VerifyPartialSignatures()
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing signature of %s", missing[0])
+	}
+	return nil
+}
+
+// VerifyPartialSignatures checks the signatures the transaction has, and returns the signers
+// still missing (whose signatures are zero): what to check before co-signing.
+func (tx *Transaction) VerifyPartialSignatures() (missing []PublicKey, err error) {
+	if int(tx.Message.Header.NumRequiredSignatures) > len(tx.Message.AccountKeys) {
+		return nil, fmt.Errorf("the message requires %d signatures but has %d account keys", tx.Message.Header.NumRequiredSignatures, len(tx.Message.AccountKeys))
+	}
+	signerKeys := tx.Message.signerKeys()
+	if len(tx.Signatures) != len(signerKeys) {
+		return nil, fmt.Errorf("invalid signatures length, expected %d, actual %d", len(signerKeys), len(tx.Signatures))
+	}
+	messageContent, err := tx.Message.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode message for verification: %w", err)
+	}
+	for i, sig := range tx.Signatures {
+		if sig.IsZero() {
+			missing = append(missing, signerKeys[i])
+			continue
+		}
+		if !sig.Verify(signerKeys[i], messageContent) {
+			return nil, fmt.Errorf("invalid signature by %s", signerKeys[i])
+		}
+	}
+	return missing, nil
+}
+
 func (tx Transaction) ToBase64() (string, error) {
 	txs_bytes, err := tx.MarshalBinary()
 	if err != nil {
@@ -0,0 +1,99 @@
+package solana
+
+import (
+	"context"
+	"crypto/ed25519"
+	crypto_rand "crypto/rand"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/mr-tron/base58"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+type VanityOpts struct {
+	// The address must start with Prefix and end with Suffix; at least one of them is required.
+	Prefix string
+	Suffix string
+	// Compare without case: faster to find, as more addresses match.
+	IgnoreCase bool
+	// Keys generated in parallel; runtime.NumCPU() by default.
+	Workers int
+}
+
+// VanityResult is a key found by GrindVanityKey, and how many keys were generated to find it.
+type VanityResult struct {
+	PrivateKey PrivateKey
+	Attempts   uint64
+}
+
+// GrindVanityKey generates random keys on all cores until the address of one matches,
+// or until ctx is done. Each base58 character asked for makes it about 58 times longer.
+func GrindVanityKey(ctx context.Context, opts VanityOpts) (*VanityResult, error) {
+	if opts.Prefix == "" && opts.Suffix == "" {
+		return nil, errors.New("vanity: a prefix or a suffix is required")
+	}
+	for _, pattern := range []string{opts.Prefix, opts.Suffix} {
+		for _, c := range pattern {
+			valid := strings.ContainsRune(base58Alphabet, c)
+			if opts.IgnoreCase {
+				valid = strings.ContainsAny(base58Alphabet, strings.ToLower(string(c))+strings.ToUpper(string(c)))
+			}
+			if !valid {
+				return nil, fmt.Errorf("vanity: %q is not a base58 character", c)
+			}
+		}
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = runtime.NumCPU()
+	}
+	prefix, suffix := opts.Prefix, opts.Suffix
+	if opts.IgnoreCase {
+		prefix, suffix = strings.ToLower(prefix), strings.ToLower(suffix)
+	}
+
+	grindCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var attempts atomic.Uint64
+	var once sync.Once
+	var found PrivateKey
+	var firstErr error
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for grindCtx.Err() == nil {
+				pub, priv, err := ed25519.GenerateKey(crypto_rand.Reader)
+				attempts.Add(1)
+				if err != nil {
+					once.Do(func() { firstErr = err })
+					cancel()
+					return
+				}
+				address := base58.Encode(pub)
+				if opts.IgnoreCase {
+					address = strings.ToLower(address)
+				}
+				if strings.HasPrefix(address, prefix) && strings.HasSuffix(address, suffix) {
+					once.Do(func() { found = PrivateKey(priv) })
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if found != nil {
+		return &VanityResult{PrivateKey: found, Attempts: attempts.Load()}, nil
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return nil, ctx.Err()
+}
@@ -0,0 +1,78 @@
+package solana
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransaction_VerifySignatures(t *testing.T) {
+	payer, cosigner := NewWallet(), NewWallet()
+	tx, err := NewTransaction([]Instruction{&testTransactionInstructions{
+		accounts:  []*AccountMeta{Meta(payer.PublicKey()).WRITE().SIGNER(), Meta(cosigner.PublicKey()).SIGNER()},
+		data:      []byte{1},
+		programID: NewWallet().PublicKey(),
+	}}, Hash{}, TransactionPayer(payer.PublicKey()))
+	require.NoError(t, err)
+	require.Error(t, tx.VerifySignatures())
+
+	_, err = tx.PartialSignWith(context.Background(), payer)
+	require.NoError(t, err)
+	missing, err := tx.VerifyPartialSignatures()
+	require.NoError(t, err)
+	require.Equal(t, []PublicKey{cosigner.PublicKey()}, missing)
+	require.ErrorContains(t, tx.VerifySignatures(), "missing signature")
+
+	_, err = tx.PartialSignWith(context.Background(), cosigner)
+	require.NoError(t, err)
+	require.NoError(t, tx.VerifySignatures())
+
+	// A signature of another message, or by another key.
+	msg, err := tx.Message.MarshalBinary()
+	require.NoError(t, err)
+	require.True(t, tx.Signatures[1].Verify(cosigner.PublicKey(), msg))
+	require.False(t, tx.Signatures[1].Verify(payer.PublicKey(), msg))
+	tx.Signatures[0], tx.Signatures[1] = tx.Signatures[1], tx.Signatures[0]
+	require.ErrorContains(t, tx.VerifySignatures(), "invalid signature by "+payer.PublicKey().String())
+	tx.Signatures = tx.Signatures[:1]
+	require.Error(t, tx.VerifySignatures())
+}
+
+func TestOffchainMessage(t *testing.T) {
+	m, err := NewOffchainMessage([]byte("Hello, world!"))
+	require.NoError(t, err)
+	require.Equal(t, OffchainMessageFormatRestrictedASCII, m.Format)
+	data, err := m.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, "ff736f6c616e61206f6666636861696e"+"00"+"00"+"0d00"+hex.EncodeToString([]byte("Hello, world!")), hex.EncodeToString(data))
+
+	var decoded OffchainMessage
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.Equal(t, *m, decoded)
+	require.Error(t, decoded.UnmarshalBinary(data[:len(data)-1]))
+
+	m, err = NewOffchainMessage([]byte("Привет"))
+	require.NoError(t, err)
+	require.Equal(t, OffchainMessageFormatLimitedUTF8, m.Format)
+	m, err = NewOffchainMessage([]byte(strings.Repeat("a", 2000)))
+	require.NoError(t, err)
+	require.Equal(t, OffchainMessageFormatExtendedUTF8, m.Format)
+	_, err = NewOffchainMessage([]byte{0xff, 0xfe})
+	require.Error(t, err)
+	_, err = NewOffchainMessage(nil)
+	require.Error(t, err)
+
+	wallet := NewWallet()
+	sig, err := SignOffchainMessage(context.Background(), wallet, []byte("I own this wallet"))
+	require.NoError(t, err)
+	require.True(t, VerifyOffchainMessage(wallet.PublicKey(), []byte("I own this wallet"), sig))
+	require.False(t, VerifyOffchainMessage(wallet.PublicKey(), []byte("I own that wallet"), sig))
+	require.False(t, VerifyOffchainMessage(NewWallet().PublicKey(), []byte("I own this wallet"), sig))
+	// Not the signature of the raw bytes.
+	raw, err := wallet.PrivateKey.Sign([]byte("I own this wallet"))
+	require.NoError(t, err)
+	require.False(t, VerifyOffchainMessage(wallet.PublicKey(), []byte("I own this wallet"), raw))
+}
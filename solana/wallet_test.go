@@ -0,0 +1,116 @@
+package solana
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSolanaKeygenFile(t *testing.T) {
+	wallet := NewWallet()
+	path := filepath.Join(t.TempDir(), "keys", "id.json")
+	require.NoError(t, wallet.SaveSolanaKeygenFile(path))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(data), "["))
+	require.NotContains(t, string(data), " ")
+
+	loaded, err := WalletFromSolanaKeygenFile(path)
+	require.NoError(t, err)
+	require.Equal(t, wallet.PrivateKey, loaded.PrivateKey)
+
+	_, err = PrivateKeyFromSolanaKeygenFileBytes([]byte("[1,2,3]"))
+	require.Error(t, err)
+	_, err = PrivateKeyFromSolanaKeygenFileBytes([]byte("[256" + strings.Repeat(",0", 63) + "]"))
+	require.Error(t, err)
+	// A public half that isn't the one of the secret half.
+	tampered := append(PrivateKey{}, wallet.PrivateKey...)
+	tampered[40] ^= 1
+	_, err = PrivateKeyFromSolanaKeygenFileBytes(tampered.solanaKeygenFileBytes())
+	require.Error(t, err)
+}
+
+func TestSLIP10Derivation(t *testing.T) {
+	// Test vector 1 for ed25519 of SLIP-0010.
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	for path, want := range map[string]string{
+		"m":        "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7",
+		"m/0'":     "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3",
+		"m/0H/1'":  "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2",
+		"m/0h/1'/": "",
+	} {
+		key, err := PrivateKeyFromSeed(seed, path)
+		if want == "" {
+			require.Error(t, err, path)
+			continue
+		}
+		require.NoError(t, err, path)
+		require.Equal(t, want, hex.EncodeToString(key[:32]), path)
+	}
+
+	for _, path := range []string{"44'/501'", "m/44'/501", "m/x'", "m/2147483648'"} {
+		_, err := ParseDerivationPath(path)
+		require.Error(t, err, path)
+	}
+	indexes, err := ParseDerivationPath(DefaultDerivationPath)
+	require.NoError(t, err)
+	require.Equal(t, []uint32{44 + hardenedOffset, 501 + hardenedOffset, hardenedOffset, hardenedOffset}, indexes)
+}
+
+func TestWalletFromMnemonic(t *testing.T) {
+	const mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	// The BIP39 seed of the mnemonic with the passphrase TREZOR.
+	seed, _ := hex.DecodeString("c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04")
+
+	wallet, err := WalletFromMnemonic(mnemonic, "TREZOR", DefaultDerivationPath)
+	require.NoError(t, err)
+	derived, err := PrivateKeyFromSeed(seed, DefaultDerivationPath)
+	require.NoError(t, err)
+	require.Equal(t, derived, wallet.PrivateKey)
+
+	// No path: the key of solana-keygen.
+	wallet, err = WalletFromMnemonic(mnemonic, "TREZOR", "")
+	require.NoError(t, err)
+	require.Equal(t, seed[:32], []byte(wallet.PrivateKey[:32]))
+
+	other, err := WalletFromMnemonic(mnemonic, "", DefaultDerivationPath)
+	require.NoError(t, err)
+	require.NotEqual(t, derived, other.PrivateKey)
+
+	_, err = WalletFromMnemonic("abandon abandon abandon", "", DefaultDerivationPath)
+	require.Error(t, err)
+
+	generated, err := NewMnemonic(256)
+	require.NoError(t, err)
+	require.Len(t, strings.Fields(generated), 24)
+	_, err = WalletFromMnemonic(generated, "", DefaultDerivationPath)
+	require.NoError(t, err)
+}
+
+func TestGrindVanityKey(t *testing.T) {
+	result, err := GrindVanityKey(context.Background(), VanityOpts{Prefix: "a", IgnoreCase: true, Workers: 2})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(strings.ToLower(result.PrivateKey.PublicKey().String()), "a"))
+	require.NotZero(t, result.Attempts)
+
+	_, err = GrindVanityKey(context.Background(), VanityOpts{})
+	require.Error(t, err)
+	_, err = GrindVanityKey(context.Background(), VanityOpts{Prefix: "0"})
+	require.Error(t, err)
+	_, err = GrindVanityKey(context.Background(), VanityOpts{Suffix: "l"})
+	require.Error(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = GrindVanityKey(ctx, VanityOpts{Prefix: "zzzzzzzzzz"})
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,218 @@
+package testutil
+
+import (
+	stdjson "encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// pubsub tracks the websocket connections and the subscriptions opened on them.
+// Every `*Subscribe` request is acknowledged with a new subscription number (starting at 1),
+// every `*Unsubscribe` with `true`; any other method is answered like over HTTP.
+type pubsub struct {
+	mu        sync.Mutex
+	upgrader  websocket.Upgrader
+	conns     map[*pubsubConn]struct{}
+	opened    int
+	nextSubID uint64
+	subs      map[uint64]*pubsubSubscription
+}
+
+type pubsubConn struct {
+	mu   sync.Mutex // serializes writes
+	conn *websocket.Conn
+}
+
+func (c *pubsubConn) writeJSON(v interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn.WriteJSON(v)
+}
+
+type pubsubSubscription struct {
+	id   uint64
+	req  Request
+	conn *pubsubConn
+}
+
+func (ps *pubsub) init() {
+	ps.conns = make(map[*pubsubConn]struct{})
+	ps.subs = make(map[uint64]*pubsubSubscription)
+}
+
+func (srv *Server) servePubsub(rw http.ResponseWriter, req *http.Request) {
+	ps := &srv.pubsub
+	conn, err := ps.upgrader.Upgrade(rw, req, nil)
+	if err != nil {
+		return
+	}
+	pc := &pubsubConn{conn: conn}
+	ps.mu.Lock()
+	ps.conns[pc] = struct{}{}
+	ps.opened++
+	ps.mu.Unlock()
+
+	defer func() {
+		ps.mu.Lock()
+		delete(ps.conns, pc)
+		for id, sub := range ps.subs {
+			if sub.conn == pc {
+				delete(ps.subs, id)
+			}
+		}
+		ps.mu.Unlock()
+		conn.Close()
+	}()
+
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var rpcReq rpcRequest
+		if err := stdjson.Unmarshal(msg, &rpcReq); err != nil {
+			continue
+		}
+		r := &Request{Method: rpcReq.Method, Params: rpcReq.Params, ID: rpcReq.ID, Websocket: true}
+
+		switch {
+		case strings.HasSuffix(r.Method, "Unsubscribe"):
+			var subID uint64
+			r.Param(0, &subID)
+			ps.mu.Lock()
+			_, found := ps.subs[subID]
+			delete(ps.subs, subID)
+			ps.mu.Unlock()
+			srv.record(r)
+			pc.writeJSON(map[string]interface{}{"jsonrpc": "2.0", "result": found, "id": r.ID})
+		case strings.HasSuffix(r.Method, "Subscribe"):
+			// the subscription is registered before the request is recorded, so a test
+			// that saw the request can notify right away
+			ps.mu.Lock()
+			ps.nextSubID++
+			subID := ps.nextSubID
+			pc.writeJSON(map[string]interface{}{"jsonrpc": "2.0", "result": subID, "id": r.ID})
+			ps.subs[subID] = &pubsubSubscription{id: subID, req: *r, conn: pc}
+			ps.mu.Unlock()
+			srv.record(r)
+		default:
+			pc.writeJSON(srv.answer(r))
+		}
+	}
+}
+
+func (srv *Server) record(req *Request) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.requests = append(srv.requests, *req)
+}
+
+// NumConns returns the number of websocket connections opened so far (including closed ones).
+func (srv *Server) NumConns() int {
+	srv.pubsub.mu.Lock()
+	defer srv.pubsub.mu.Unlock()
+	return srv.pubsub.opened
+}
+
+// NumSubscriptions returns the number of live subscriptions over every connection.
+func (srv *Server) NumSubscriptions() int {
+	srv.pubsub.mu.Lock()
+	defer srv.pubsub.mu.Unlock()
+	return len(srv.pubsub.subs)
+}
+
+// CloseConnections drops every websocket connection, like a node restarting.
+func (srv *Server) CloseConnections() {
+	srv.pubsub.mu.Lock()
+	defer srv.pubsub.mu.Unlock()
+	for pc := range srv.pubsub.conns {
+		pc.conn.Close()
+	}
+}
+
+// Notify sends `result` to every `subscribeMethod` subscription accepted by `match` (nil matches all).
+// Returns the number of notifications written.
+func (srv *Server) Notify(subscribeMethod string, match func(req *Request) bool, result interface{}) int {
+	sent := 0
+	for _, sub := range srv.subscriptions(subscribeMethod) {
+		if match == nil || match(&sub.req) {
+			sent += sub.notify(result)
+		}
+	}
+	return sent
+}
+
+// notify writes a notification; the method is derived from the subscribe method
+// (accountSubscribe -> accountNotification).
+func (sub *pubsubSubscription) notify(result interface{}) int {
+	err := sub.conn.writeJSON(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  strings.TrimSuffix(sub.req.Method, "Subscribe") + "Notification",
+		"params":  map[string]interface{}{"result": result, "subscription": sub.id},
+	})
+	if err != nil {
+		return 0
+	}
+	return 1
+}
+
+// NotifyAccount updates the account (see SetAccount) and notifies its subscribers,
+// each one with the encoding it asked for.
+func (srv *Server) NotifyAccount(pubkey solana.PublicKey, account *Account) int {
+	srv.SetAccount(pubkey, account)
+
+	sent := 0
+	for _, sub := range srv.subscriptions("accountSubscribe") {
+		var subscribed solana.PublicKey
+		if !sub.req.Param(0, &subscribed) || !subscribed.Equals(pubkey) {
+			continue
+		}
+		sent += sub.notify(srv.withContext(encodeAccount(account, sub.req.Config())))
+	}
+	return sent
+}
+
+// NotifyLogs sends a logs notification for a transaction that mentions the given accounts.
+// It reaches the "all" subscriptions and the "mentions" subscriptions of one of these accounts.
+func (srv *Server) NotifyLogs(signature solana.Signature, logs []string, txErr interface{}, mentions ...solana.PublicKey) int {
+	result := srv.withContext(map[string]interface{}{
+		"signature": signature.String(),
+		"err":       txErr,
+		"logs":      logs,
+	})
+	return srv.Notify("logsSubscribe", func(req *Request) bool {
+		var filter string
+		if req.Param(0, &filter) {
+			return filter == "all" || filter == "allWithVotes"
+		}
+		var byMentions struct {
+			Mentions []solana.PublicKey `json:"mentions"`
+		}
+		if !req.Param(0, &byMentions) {
+			return false
+		}
+		for _, wanted := range byMentions.Mentions {
+			for _, mentioned := range mentions {
+				if wanted.Equals(mentioned) {
+					return true
+				}
+			}
+		}
+		return false
+	}, result)
+}
+
+func (srv *Server) subscriptions(method string) (out []*pubsubSubscription) {
+	srv.pubsub.mu.Lock()
+	defer srv.pubsub.mu.Unlock()
+	for _, sub := range srv.pubsub.subs {
+		if sub.req.Method == method {
+			out = append(out, sub)
+		}
+	}
+	return out
+}
@@ -0,0 +1,485 @@
+// Package testutil provides a local Solana node stand-in for tests.
+//
+// Server answers JSON-RPC requests over HTTP and pubsub requests over websocket
+// (same address, see URL and WSURL). Responses are either built from the state
+// the test sets (accounts, blockhash, signature statuses, transactions...),
+// scripted per method, or replayed from a recording.
+package testutil
+
+import (
+	"encoding/base64"
+	stdjson "encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/mr-tron/base58"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// Request is a JSON-RPC request received by the server (over HTTP or websocket).
+type Request struct {
+	Method    string
+	Params    []stdjson.RawMessage
+	ID        stdjson.RawMessage
+	Websocket bool
+}
+
+// Param decodes the i-th param into out; returns false when the param is missing or doesn't fit.
+func (r *Request) Param(i int, out interface{}) bool {
+	if i >= len(r.Params) {
+		return false
+	}
+	return stdjson.Unmarshal(r.Params[i], out) == nil
+}
+
+// Config decodes the trailing config object (e.g {"commitment":"finalized"}) of the request.
+func (r *Request) Config() map[string]interface{} {
+	if len(r.Params) == 0 {
+		return nil
+	}
+	var conf map[string]interface{}
+	if stdjson.Unmarshal(r.Params[len(r.Params)-1], &conf) != nil {
+		return nil
+	}
+	return conf
+}
+
+// Error is a JSON-RPC error returned by a Handler.
+type Error struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("rpc error %d: %s", e.Code, e.Message)
+}
+
+// Handler computes the result of a request. Returning an *Error sends it as the rpc error.
+type Handler func(req *Request) (interface{}, error)
+
+// Account is the on-chain state served by getAccountInfo, getMultipleAccounts,
+// getBalance and account notifications.
+type Account struct {
+	Lamports   uint64
+	Owner      solana.PublicKey
+	Data       []byte
+	Executable bool
+	RentEpoch  uint64
+}
+
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	slot     uint64
+	handlers map[string]Handler
+	scripted map[string][]interface{}
+	requests []Request
+
+	accounts             map[solana.PublicKey]*Account
+	blockhash            solana.Hash
+	lastValidBlockHeight uint64
+	statuses             map[solana.Signature]interface{}
+	transactions         map[solana.Signature]interface{}
+	signatures           map[solana.PublicKey][]interface{}
+	sent                 [][]byte
+
+	pubsub pubsub
+}
+
+// NewServer starts a server. Close it when done.
+func NewServer() *Server {
+	srv := &Server{
+		slot:         1,
+		handlers:     make(map[string]Handler),
+		scripted:     make(map[string][]interface{}),
+		accounts:     make(map[solana.PublicKey]*Account),
+		statuses:     make(map[solana.Signature]interface{}),
+		transactions: make(map[solana.Signature]interface{}),
+		signatures:   make(map[solana.PublicKey][]interface{}),
+	}
+	srv.pubsub.init()
+	srv.registerBuiltins()
+	srv.Server = httptest.NewServer(http.HandlerFunc(srv.serveHTTP))
+	return srv
+}
+
+// WSURL returns the pubsub endpoint.
+func (srv *Server) WSURL() string {
+	return "ws" + strings.TrimPrefix(srv.Server.URL, "http")
+}
+
+// Handle overrides how the server answers `method`.
+func (srv *Server) Handle(method string, handler Handler) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.handlers[method] = handler
+}
+
+// SetResult makes the server always answer `method` with `result`.
+// A stdjson.RawMessage is sent as is, which makes it easy to replay what a real node returned.
+func (srv *Server) SetResult(method string, result interface{}) {
+	srv.Handle(method, func(*Request) (interface{}, error) { return result, nil })
+}
+
+// SetError makes the server always answer `method` with an rpc error.
+func (srv *Server) SetError(method string, code int, message string) {
+	srv.Handle(method, func(*Request) (interface{}, error) { return nil, &Error{Code: code, Message: message} })
+}
+
+// Enqueue scripts the next answers to `method`, in order. An element can be an *Error.
+// Once the script is consumed the server falls back to the handler of the method.
+func (srv *Server) Enqueue(method string, results ...interface{}) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.scripted[method] = append(srv.scripted[method], results...)
+}
+
+// RecordedResponse is an entry of a recording; `Error` wins over `Result` when set.
+type RecordedResponse struct {
+	Method string             `json:"method"`
+	Result stdjson.RawMessage `json:"result"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// LoadRecording enqueues the responses of a recording: a JSON array of RecordedResponse.
+func (srv *Server) LoadRecording(r io.Reader) error {
+	var recording []RecordedResponse
+	if err := stdjson.NewDecoder(r).Decode(&recording); err != nil {
+		return fmt.Errorf("unable to decode recording: %w", err)
+	}
+	for _, resp := range recording {
+		if resp.Error != nil {
+			srv.Enqueue(resp.Method, resp.Error)
+			continue
+		}
+		srv.Enqueue(resp.Method, resp.Result)
+	}
+	return nil
+}
+
+// Requests returns every request received so far, in order.
+func (srv *Server) Requests() []Request {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	return append([]Request(nil), srv.requests...)
+}
+
+// Methods returns the method of every request received so far, in order.
+func (srv *Server) Methods() (out []string) {
+	for _, req := range srv.Requests() {
+		out = append(out, req.Method)
+	}
+	return out
+}
+
+// SetSlot sets the slot reported in the context of the responses.
+func (srv *Server) SetSlot(slot uint64) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.slot = slot
+}
+
+// SetAccount sets (or with nil, removes) the state of an account.
+func (srv *Server) SetAccount(pubkey solana.PublicKey, account *Account) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if account == nil {
+		delete(srv.accounts, pubkey)
+		return
+	}
+	srv.accounts[pubkey] = account
+}
+
+// SetLatestBlockhash sets what getLatestBlockhash returns.
+func (srv *Server) SetLatestBlockhash(hash solana.Hash, lastValidBlockHeight uint64) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.blockhash = hash
+	srv.lastValidBlockHeight = lastValidBlockHeight
+}
+
+// SetSignatureStatus sets what getSignatureStatuses returns for `sig` (typically a *rpc.SignatureStatusesResult).
+// Unknown signatures are returned as null.
+func (srv *Server) SetSignatureStatus(sig solana.Signature, status interface{}) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.statuses[sig] = status
+}
+
+// SetTransaction sets what getTransaction returns for `sig`. Use a stdjson.RawMessage to replay a recorded one.
+func (srv *Server) SetTransaction(sig solana.Signature, result interface{}) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.transactions[sig] = result
+}
+
+// SetSignaturesForAddress sets the history of an address, newest first
+// (elements are typically *rpc.TransactionSignature). getSignaturesForAddress pages through it
+// with `before`, `until` and `limit` like a node does.
+func (srv *Server) SetSignaturesForAddress(address solana.PublicKey, signatures ...interface{}) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.signatures[address] = signatures
+}
+
+// SentTransactions returns the raw transactions received through sendTransaction.
+func (srv *Server) SentTransactions() [][]byte {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	return append([][]byte(nil), srv.sent...)
+}
+
+type rpcRequest struct {
+	Method string               `json:"method"`
+	Params []stdjson.RawMessage `json:"params"`
+	ID     stdjson.RawMessage   `json:"id"`
+}
+
+func (srv *Server) serveHTTP(rw http.ResponseWriter, req *http.Request) {
+	if websocket.IsWebSocketUpgrade(req) {
+		srv.servePubsub(rw, req)
+		return
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var rpcReq rpcRequest
+	if err := stdjson.Unmarshal(body, &rpcReq); err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+	stdjson.NewEncoder(rw).Encode(srv.answer(&Request{Method: rpcReq.Method, Params: rpcReq.Params, ID: rpcReq.ID}))
+}
+
+// answer records the request and builds its JSON-RPC response.
+func (srv *Server) answer(req *Request) map[string]interface{} {
+	srv.mu.Lock()
+	srv.requests = append(srv.requests, *req)
+	var result interface{}
+	var err error
+	if script := srv.scripted[req.Method]; len(script) > 0 {
+		result, srv.scripted[req.Method] = script[0], script[1:]
+		if rpcErr, ok := result.(*Error); ok {
+			result, err = nil, rpcErr
+		}
+		srv.mu.Unlock()
+	} else {
+		handler, found := srv.handlers[req.Method]
+		srv.mu.Unlock()
+		if !found {
+			err = &Error{Code: -32601, Message: "Method not found"}
+		} else {
+			result, err = handler(req)
+		}
+	}
+
+	id := req.ID
+	if id == nil {
+		id = stdjson.RawMessage("null")
+	}
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": id}
+	if err != nil {
+		var rpcErr *Error
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &Error{Code: -32603, Message: err.Error()}
+		}
+		resp["error"] = rpcErr
+		return resp
+	}
+	if result == nil {
+		result = stdjson.RawMessage("null")
+	}
+	resp["result"] = result
+	return resp
+}
+
+// withContext wraps a value like the node does: {"context":{"slot":..},"value":..}
+func (srv *Server) withContext(value interface{}) map[string]interface{} {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	return map[string]interface{}{"context": map[string]interface{}{"slot": srv.slot}, "value": value}
+}
+
+// encodeAccount renders an account like the node does for the requested encoding and data slice.
+func encodeAccount(acc *Account, conf map[string]interface{}) interface{} {
+	if acc == nil {
+		return nil
+	}
+	data := acc.Data
+	if slice, ok := conf["dataSlice"].(map[string]interface{}); ok {
+		offset, _ := slice["offset"].(float64)
+		length, _ := slice["length"].(float64)
+		start := min(int(offset), len(data))
+		end := min(start+int(length), len(data))
+		data = data[start:end]
+	}
+
+	encoded := []string{base64.StdEncoding.EncodeToString(data), "base64"}
+	if encoding, _ := conf["encoding"].(string); encoding == string(solana.EncodingBase58) {
+		encoded = []string{base58.Encode(data), "base58"}
+	}
+	return map[string]interface{}{
+		"lamports":   acc.Lamports,
+		"owner":      acc.Owner.String(),
+		"data":       encoded,
+		"executable": acc.Executable,
+		"rentEpoch":  acc.RentEpoch,
+		"space":      len(acc.Data),
+	}
+}
+
+func (srv *Server) account(pubkey solana.PublicKey) *Account {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	return srv.accounts[pubkey]
+}
+
+func (srv *Server) registerBuiltins() {
+	srv.handlers["getAccountInfo"] = func(req *Request) (interface{}, error) {
+		var pubkey solana.PublicKey
+		if !req.Param(0, &pubkey) {
+			return nil, &Error{Code: -32602, Message: "Invalid param: expected a pubkey"}
+		}
+		return srv.withContext(encodeAccount(srv.account(pubkey), req.Config())), nil
+	}
+	srv.handlers["getMultipleAccounts"] = func(req *Request) (interface{}, error) {
+		var pubkeys []solana.PublicKey
+		if !req.Param(0, &pubkeys) {
+			return nil, &Error{Code: -32602, Message: "Invalid param: expected an array of pubkeys"}
+		}
+		conf := req.Config()
+		out := make([]interface{}, len(pubkeys))
+		for i, pubkey := range pubkeys {
+			out[i] = encodeAccount(srv.account(pubkey), conf)
+		}
+		return srv.withContext(out), nil
+	}
+	srv.handlers["getBalance"] = func(req *Request) (interface{}, error) {
+		var pubkey solana.PublicKey
+		if !req.Param(0, &pubkey) {
+			return nil, &Error{Code: -32602, Message: "Invalid param: expected a pubkey"}
+		}
+		var lamports uint64
+		if acc := srv.account(pubkey); acc != nil {
+			lamports = acc.Lamports
+		}
+		return srv.withContext(lamports), nil
+	}
+	srv.handlers["getLatestBlockhash"] = func(req *Request) (interface{}, error) {
+		srv.mu.Lock()
+		value := map[string]interface{}{"blockhash": srv.blockhash.String(), "lastValidBlockHeight": srv.lastValidBlockHeight}
+		srv.mu.Unlock()
+		return srv.withContext(value), nil
+	}
+	srv.handlers["getSlot"] = func(req *Request) (interface{}, error) {
+		srv.mu.Lock()
+		defer srv.mu.Unlock()
+		return srv.slot, nil
+	}
+	srv.handlers["getSignatureStatuses"] = func(req *Request) (interface{}, error) {
+		var sigs []solana.Signature
+		if !req.Param(0, &sigs) {
+			return nil, &Error{Code: -32602, Message: "Invalid param: expected an array of signatures"}
+		}
+		srv.mu.Lock()
+		out := make([]interface{}, len(sigs))
+		for i, sig := range sigs {
+			out[i] = srv.statuses[sig]
+		}
+		srv.mu.Unlock()
+		return srv.withContext(out), nil
+	}
+	srv.handlers["getTransaction"] = func(req *Request) (interface{}, error) {
+		var sig solana.Signature
+		if !req.Param(0, &sig) {
+			return nil, &Error{Code: -32602, Message: "Invalid param: expected a signature"}
+		}
+		srv.mu.Lock()
+		defer srv.mu.Unlock()
+		return srv.transactions[sig], nil
+	}
+	srv.handlers["getSignaturesForAddress"] = func(req *Request) (interface{}, error) {
+		var address solana.PublicKey
+		if !req.Param(0, &address) {
+			return nil, &Error{Code: -32602, Message: "Invalid param: expected a pubkey"}
+		}
+		srv.mu.Lock()
+		history := srv.signatures[address]
+		srv.mu.Unlock()
+		return pageSignatures(history, req.Config()), nil
+	}
+	srv.handlers["sendTransaction"] = func(req *Request) (interface{}, error) {
+		var encoded string
+		if !req.Param(0, &encoded) {
+			return nil, &Error{Code: -32602, Message: "Invalid param: expected an encoded transaction"}
+		}
+		var raw []byte
+		var err error
+		if encoding, _ := req.Config()["encoding"].(string); encoding == string(solana.EncodingBase58) {
+			raw, err = base58.Decode(encoded)
+		} else {
+			raw, err = base64.StdEncoding.DecodeString(encoded)
+		}
+		// the first signature is the transaction id: a compact-u16 count (1 byte below 128) then 64 bytes
+		if err != nil || len(raw) < 1+solana.SignatureLength {
+			return nil, &Error{Code: -32602, Message: "failed to deserialize transaction"}
+		}
+		srv.mu.Lock()
+		srv.sent = append(srv.sent, raw)
+		srv.mu.Unlock()
+		return solana.SignatureFromBytes(raw[1 : 1+solana.SignatureLength]).String(), nil
+	}
+}
+
+// pageSignatures applies `before`, `until` and `limit` to a newest-first history.
+func pageSignatures(history []interface{}, conf map[string]interface{}) []interface{} {
+	before, _ := conf["before"].(string)
+	until, _ := conf["until"].(string)
+	limit := 1000
+	if l, ok := conf["limit"].(float64); ok && l > 0 {
+		limit = int(l)
+	}
+
+	out := make([]interface{}, 0)
+	started := before == ""
+	for _, entry := range history {
+		sig := signatureOf(entry)
+		if !started {
+			started = sig == before
+			continue
+		}
+		if until != "" && sig == until {
+			break
+		}
+		if len(out) == limit {
+			break
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+func signatureOf(entry interface{}) string {
+	data, err := stdjson.Marshal(entry)
+	if err != nil {
+		return ""
+	}
+	var withSig struct {
+		Signature string `json:"signature"`
+	}
+	stdjson.Unmarshal(data, &withSig)
+	return withSig.Signature
+}
@@ -0,0 +1,183 @@
+package testutil
+
+import (
+	"context"
+	stdjson "encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scatkit/pumpdexer/rpc"
+	"github.com/scatkit/pumpdexer/solana"
+	"github.com/scatkit/pumpdexer/ws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T) *Server {
+	srv := NewServer()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestServer_RPC(t *testing.T) {
+	srv := newTestServer(t)
+	client := rpc.New(srv.URL)
+	ctx := context.Background()
+
+	owner := solana.NewWallet().PublicKey()
+	account := solana.NewWallet().PublicKey()
+	srv.SetSlot(42)
+	srv.SetAccount(account, &Account{Lamports: 5_000, Owner: owner, Data: []byte{1, 2, 3, 4}})
+
+	info, err := client.GetAccountInfo(ctx, account)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), info.Context.Slot)
+	assert.Equal(t, uint64(5_000), info.Value.Lamports)
+	assert.Equal(t, owner, info.Value.Owner)
+	assert.Equal(t, []byte{1, 2, 3, 4}, info.GetBinary())
+
+	sliced, err := client.GetAccountInfoWithOpts(ctx, account, &rpc.GetAccountInfoOpts{
+		Encoding:  solana.EncodingBase58,
+		DataSlice: &rpc.DataSlice{Offset: ptr(uint64(1)), Length: ptr(uint64(2))},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{2, 3}, sliced.GetBinary())
+
+	missing := solana.NewWallet().PublicKey()
+	multiple, err := client.GetMultipleAccounts(ctx, account, missing)
+	require.NoError(t, err)
+	require.Len(t, multiple.Value, 2)
+	assert.NotNil(t, multiple.Value[0])
+	assert.Nil(t, multiple.Value[1])
+
+	hash := solana.Hash(solana.NewWallet().PublicKey())
+	srv.SetLatestBlockhash(hash, 1234)
+	latest, err := client.GetLatestBlockhash(ctx, rpc.CommitmentFinalized)
+	require.NoError(t, err)
+	assert.Equal(t, hash, latest.Value.Blockhash)
+	assert.Equal(t, uint64(1234), latest.Value.LastValueBlockHeight)
+
+	landed := solana.SignatureFromBytes(make([]byte, solana.SignatureLength))
+	srv.SetSignatureStatus(landed, &rpc.SignatureStatusesResult{Slot: 40, ConfirmationStatus: rpc.ConfirmationStatusFinalized})
+	unknown := solana.Signature{1}
+	statuses, err := client.GetSignatureStatuses(ctx, false, landed, unknown)
+	require.NoError(t, err)
+	require.Len(t, statuses.Value, 2)
+	assert.Equal(t, uint64(40), statuses.Value[0].Slot)
+	assert.Nil(t, statuses.Value[1])
+
+	assert.Equal(t,
+		[]string{"getAccountInfo", "getAccountInfo", "getMultipleAccounts", "getLatestBlockhash", "getSignatureStatuses"},
+		srv.Methods(),
+	)
+}
+
+func TestServer_ScriptedResponses(t *testing.T) {
+	srv := newTestServer(t)
+	client := rpc.New(srv.URL)
+	ctx := context.Background()
+	account := solana.NewWallet().PublicKey()
+
+	srv.Enqueue("getBalance", &Error{Code: 429, Message: "Too many requests"}, stdjson.RawMessage(`{"context":{"slot":7},"value":99}`))
+
+	_, err := client.GetBalance(ctx, account, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Too many requests")
+
+	balance, err := client.GetBalance(ctx, account, "")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(99), balance.Value)
+
+	// script consumed: back to the state of the server
+	srv.SetAccount(account, &Account{Lamports: 1})
+	balance, err = client.GetBalance(ctx, account, "")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), balance.Value)
+
+	require.NoError(t, srv.LoadRecording(strings.NewReader(`[
+		{"method": "getMinimumBalanceForRentExemption", "result": 2039280},
+		{"method": "getMinimumBalanceForRentExemption", "error": {"code": -32602, "message": "Invalid params"}}
+	]`)))
+	rent, err := client.GetMinimumBalanceForRentExemption(ctx, 165, "")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2039280), rent)
+	_, err = client.GetMinimumBalanceForRentExemption(ctx, 165, "")
+	require.Error(t, err)
+	_, err = client.GetMinimumBalanceForRentExemption(ctx, 165, "")
+	require.Error(t, err, "unknown method")
+}
+
+func TestServer_SignaturesForAddressPagination(t *testing.T) {
+	srv := newTestServer(t)
+	client := rpc.New(srv.URL)
+	ctx := context.Background()
+	address := solana.NewWallet().PublicKey()
+
+	var history []interface{}
+	var sigs []solana.Signature
+	for i := 0; i < 5; i++ {
+		sig := solana.Signature{byte(i + 1)}
+		sigs = append(sigs, sig)
+		history = append(history, &rpc.TransactionSignature{Signature: sig, Slot: uint64(100 - i)})
+	}
+	srv.SetSignaturesForAddress(address, history...)
+
+	page, err := client.GetSignaturesForAddressWithOpts(ctx, address, &rpc.GetSignaturesForAddressOpts{Limit: ptr(2)})
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, sigs[0], page[0].Signature)
+	assert.Equal(t, sigs[1], page[1].Signature)
+
+	page, err = client.GetSignaturesForAddressWithOpts(ctx, address, &rpc.GetSignaturesForAddressOpts{Before: sigs[1], Until: sigs[4]})
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, sigs[2], page[0].Signature)
+	assert.Equal(t, sigs[3], page[1].Signature)
+}
+
+func TestServer_Pubsub(t *testing.T) {
+	srv := newTestServer(t)
+	ctx := context.Background()
+
+	client, err := ws.Connect(ctx, srv.WSURL())
+	require.NoError(t, err)
+	defer client.Close()
+
+	account := solana.NewWallet().PublicKey()
+	accSub, err := client.AccountSubscribeWithOpts(account, rpc.CommitmentConfirmed, solana.EncodingBase64)
+	require.NoError(t, err)
+	logSub, err := client.LogSubscribeToAddress(account, rpc.CommitmentConfirmed)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return srv.NumSubscriptions() == 2 }, 2*time.Second, 5*time.Millisecond)
+
+	srv.SetSlot(9)
+	require.Equal(t, 1, srv.NotifyAccount(account, &Account{Lamports: 77, Data: []byte{9}}))
+	got, err := accSub.Recv(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(9), got.Context.Slot)
+	assert.Equal(t, uint64(77), got.Value.Lamports)
+	assert.Equal(t, []byte{9}, got.Value.Data.GetBinary())
+
+	// the account update is visible over HTTP as well
+	info, err := rpc.New(srv.URL).GetAccountInfo(ctx, account)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(77), info.Value.Lamports)
+
+	sig := solana.Signature{7}
+	assert.Equal(t, 0, srv.NotifyLogs(sig, []string{"Program log: elsewhere"}, nil, solana.NewWallet().PublicKey()))
+	require.Equal(t, 1, srv.NotifyLogs(sig, []string{"Program log: hello"}, nil, account))
+	logs, err := logSub.Recv(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, sig, logs.Value.Signature)
+	assert.Equal(t, []string{"Program log: hello"}, logs.Value.Logs)
+
+	accSub.Unsubscribe()
+	require.Eventually(t, func() bool { return srv.NumSubscriptions() == 1 }, 2*time.Second, 5*time.Millisecond)
+	assert.Equal(t, 0, srv.NotifyAccount(account, &Account{Lamports: 78}))
+	assert.Equal(t, 1, srv.NumConns())
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,328 @@
+package tracker
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/scatkit/pumpdexer/inspector"
+	"github.com/scatkit/pumpdexer/rpc"
+	"github.com/scatkit/pumpdexer/solana"
+)
+
+// EventKind is the kind of activity of a wallet.
+type EventKind string
+
+const (
+	// The wallet bought a token with a quote asset (SOL, USDC...).
+	EventBuy EventKind = "buy"
+	// The wallet sold a token for a quote asset.
+	EventSell EventKind = "sell"
+	// The wallet swapped a token for another, neither being a quote asset.
+	EventSwap EventKind = "swap"
+	// The wallet sent or received a token, or SOL, outside of a DEX.
+	EventTransfer EventKind = "transfer"
+	// The wallet launched a token (and possibly bought it in the same transaction).
+	EventLaunch EventKind = "launch"
+)
+
+// Event is an activity of a tracked wallet.
+type Event struct {
+	Kind      EventKind               `json:"kind"`
+	Wallet    solana.PublicKey        `json:"wallet"`
+	Signature solana.Signature        `json:"signature"`
+	Slot      uint64                  `json:"slot"`
+	BlockTime *solana.UnixTimeSeconds `json:"blockTime,omitempty"`
+	// Whether the event was found by backfilling rather than by the subscription.
+	Backfilled bool `json:"backfilled"`
+
+	// Name of the DEX the trade or launch went through, "" for transfers.
+	DEX string `json:"dex,omitempty"`
+
+	// Token bought, sold, received, sent or launched (solana.SolMint for SOL transfers),
+	// and the amount the wallet's balance changed by (0 for a launch without a dev buy).
+	Mint     solana.PublicKey `json:"mint"`
+	Amount   uint64           `json:"amount"`
+	Decimals uint8            `json:"decimals"`
+
+	// Asset paid for a buy, received for a sell, given for a swap.
+	QuoteMint     solana.PublicKey `json:"quoteMint,omitempty"`
+	QuoteAmount   uint64           `json:"quoteAmount,omitempty"`
+	QuoteDecimals uint8            `json:"quoteDecimals,omitempty"`
+	// Quote per token, in UI amounts.
+	Price float64 `json:"price,omitempty"`
+
+	// Transfers: whether the wallet received it, and the other side when it can be told.
+	Incoming     bool             `json:"incoming,omitempty"`
+	Counterparty solana.PublicKey `json:"counterparty,omitempty"`
+
+	// Transaction fee in lamports, if the wallet paid it.
+	Fee uint64 `json:"fee"`
+}
+
+// DEX is a program whose instructions make trades.
+type DEX struct {
+	Name      string
+	ProgramID solana.PublicKey
+	// Launch returns the mint an instruction of the program launches, if it does.
+	// Nil if the program doesn't launch tokens.
+	Launch func(instruction *inspector.Instruction) (mint solana.PublicKey, ok bool)
+}
+
+var (
+	// Anchor discriminator of the pump.fun "create" instruction.
+	pumpFunCreateDiscriminator = []byte{24, 30, 200, 40, 5, 28, 7, 119}
+	// Instruction tag of the Raydium AMM v4 "initialize2" instruction.
+	raydiumAmmV4Initialize2 = byte(1)
+)
+
+// Program IDs of the DEXes and launchpads we trade on.
+var (
+	PumpFunProgramID          = solana.MustPubkeyFromBase58("6EF8rrecthR5Dkzon8Nwu78hRvfCKubJ14M5uBEwF6P")
+	PumpSwapProgramID         = solana.MustPubkeyFromBase58("pAMMBay6oceH9fJKBRHGP5D4bD4sWpmSwMn52FaXXfA")
+	RaydiumAmmV4ProgramID     = solana.MustPubkeyFromBase58("675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8")
+	RaydiumCpmmProgramID      = solana.MustPubkeyFromBase58("CPMMoo8L3F4NbTegBCKVNunggL7H1ZpdTHKxQB5qKP1C")
+	RaydiumClmmProgramID      = solana.MustPubkeyFromBase58("CAMMCzo5YL8w4VFF8KVHrK22GGUsp5VTaW7grrKgrWqK")
+	RaydiumLaunchLabProgramID = solana.MustPubkeyFromBase58("LanMV9sAd7wArD4vJFi2qDdfnVhFxYSUg6eADduJ3uj")
+	MeteoraDlmmProgramID      = solana.MustPubkeyFromBase58("LBUZKhRxPF3XUpBCjp4YzTKgLccjZhTSDM9YuVaPwxo")
+	MeteoraPoolsProgramID     = solana.MustPubkeyFromBase58("Eo7WjKq67rjJQSZxS6z3YkapzY3eMj6Xy8X5EQVn5UaB")
+	OrcaWhirlpoolProgramID    = solana.MustPubkeyFromBase58("whirLbMiicVdio4qvUfM5KAg6Ct8VwpYzGff3uctyCc")
+)
+
+// KnownDEXes are the DEXes and launchpads classified by default.
+var KnownDEXes = []DEX{
+	{
+		Name:      "PumpFun",
+		ProgramID: PumpFunProgramID,
+		Launch: func(instruction *inspector.Instruction) (solana.PublicKey, bool) {
+			// The mint is the first account of "create".
+			if !bytes.HasPrefix(instruction.Data, pumpFunCreateDiscriminator) || len(instruction.Accounts) == 0 {
+				return solana.PublicKey{}, false
+			}
+			return instruction.Accounts[0].PublicKey, true
+		},
+	},
+	{
+		Name:      "RaydiumAmmV4",
+		ProgramID: RaydiumAmmV4ProgramID,
+		Launch: func(instruction *inspector.Instruction) (solana.PublicKey, bool) {
+			// "initialize2" lists the coin mint at 8 and the pc mint at 9; the token is the one that isn't SOL.
+			if len(instruction.Data) == 0 || instruction.Data[0] != raydiumAmmV4Initialize2 || len(instruction.Accounts) < 10 {
+				return solana.PublicKey{}, false
+			}
+			if coin := instruction.Accounts[8].PublicKey; coin != solana.SolMint {
+				return coin, true
+			}
+			return instruction.Accounts[9].PublicKey, true
+		},
+	},
+	{Name: "PumpSwap", ProgramID: PumpSwapProgramID},
+	{Name: "RaydiumCpmm", ProgramID: RaydiumCpmmProgramID},
+	{Name: "RaydiumClmm", ProgramID: RaydiumClmmProgramID},
+	{Name: "RaydiumLaunchLab", ProgramID: RaydiumLaunchLabProgramID},
+	{Name: "MeteoraDlmm", ProgramID: MeteoraDlmmProgramID},
+	{Name: "MeteoraPools", ProgramID: MeteoraPoolsProgramID},
+	{Name: "OrcaWhirlpool", ProgramID: OrcaWhirlpoolProgramID},
+}
+
+// DefaultQuoteMints are the assets prices are quoted in: SOL, USDC and USDT.
+var DefaultQuoteMints = []solana.PublicKey{
+	solana.SolMint,
+	solana.MustPubkeyFromBase58("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"),
+	solana.MustPubkeyFromBase58("Es9vMFrzaCERmJfrF4H2FYD4KCoNkY11McCe8BenwNYB"),
+}
+
+// Classifier tells what a transaction did for a wallet.
+type Classifier struct {
+	DEXes      []DEX
+	QuoteMints []solana.PublicKey
+}
+
+// NewClassifier returns a classifier of the known DEXes and default quote mints.
+func NewClassifier() *Classifier {
+	return &Classifier{DEXes: KnownDEXes, QuoteMints: DefaultQuoteMints}
+}
+
+// Classify returns the activity of the wallet in a transaction fetched with rpc.Client.GetTransaction
+// (in a binary encoding), or nil if the transaction failed or is none of the event kinds for it.
+func (c *Classifier) Classify(wallet solana.PublicKey, result *rpc.GetTransactionResult) (*Event, error) {
+	if result == nil || result.Transaction == nil || result.Meta == nil {
+		return nil, errors.New("transaction result is empty")
+	}
+	if result.Meta.Err != nil {
+		return nil, nil
+	}
+	tx, err := result.Transaction.GetTransaction()
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode transaction: %w", err)
+	}
+	if tx == nil || len(tx.Signatures) == 0 {
+		return nil, errors.New("transaction result is empty")
+	}
+	inspected, err := inspector.Inspect(tx, result.Meta)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := inspector.BalanceChangesOf(tx, result.Meta)
+	if err != nil {
+		return nil, err
+	}
+
+	event := &Event{
+		Wallet:    wallet,
+		Signature: tx.Signatures[0],
+		Slot:      result.Slot,
+		BlockTime: result.BlockTime,
+	}
+	if wallet == changes.FeePayer {
+		event.Fee = changes.Fee
+	}
+
+	dex, launched, isLaunch := c.findDEX(wallet, inspected.Instructions)
+	swap, err := changes.Swap(wallet)
+	if err != nil && !errors.Is(err, inspector.ErrNoSwap) {
+		return nil, err
+	}
+	switch {
+	case isLaunch:
+		event.Kind = EventLaunch
+		event.DEX = dex.Name
+		event.Mint = launched
+		if swap != nil && swap.OutputMint == launched {
+			setBought(event, swap)
+		}
+		return event, nil
+	case dex != nil && swap != nil:
+		event.DEX = dex.Name
+		switch {
+		case c.isQuote(swap.InputMint):
+			event.Kind = EventBuy
+			setBought(event, swap)
+		case c.isQuote(swap.OutputMint):
+			event.Kind = EventSell
+			setSold(event, swap)
+		default:
+			event.Kind = EventSwap
+			setBought(event, swap)
+		}
+		return event, nil
+	case dex == nil && transferOf(wallet, changes, event):
+		event.Kind = EventTransfer
+		return event, nil
+	}
+	return nil, nil
+}
+
+// setBought fills the event with the output of the swap as the token, its input as the quote.
+func setBought(event *Event, swap *inspector.Swap) {
+	event.Mint, event.Amount, event.Decimals = swap.OutputMint, swap.OutputAmount, swap.OutputDecimals
+	event.QuoteMint, event.QuoteAmount, event.QuoteDecimals = swap.InputMint, swap.InputAmount, swap.InputDecimals
+	event.Price = swap.Price
+}
+
+// setSold fills the event with the input of the swap as the token, its output as the quote.
+func setSold(event *Event, swap *inspector.Swap) {
+	event.Mint, event.Amount, event.Decimals = swap.InputMint, swap.InputAmount, swap.InputDecimals
+	event.QuoteMint, event.QuoteAmount, event.QuoteDecimals = swap.OutputMint, swap.OutputAmount, swap.OutputDecimals
+	event.Price = 1 / swap.Price
+}
+
+func (c *Classifier) isQuote(mint solana.PublicKey) bool {
+	for _, quote := range c.QuoteMints {
+		if quote == mint {
+			return true
+		}
+	}
+	return false
+}
+
+// findDEX returns the first known DEX invoked by the transaction,
+// or the one that launched a token if the wallet signed a launch.
+func (c *Classifier) findDEX(wallet solana.PublicKey, instructions []*inspector.Instruction) (found *DEX, launched solana.PublicKey, isLaunch bool) {
+	var walk func(instructions []*inspector.Instruction) bool
+	walk = func(instructions []*inspector.Instruction) bool {
+		for _, instruction := range instructions {
+			for i := range c.DEXes {
+				dex := &c.DEXes[i]
+				if dex.ProgramID != instruction.ProgramID {
+					continue
+				}
+				if found == nil {
+					found = dex
+				}
+				if dex.Launch == nil || !signedBy(instruction, wallet) {
+					continue
+				}
+				if mint, ok := dex.Launch(instruction); ok {
+					found, launched, isLaunch = dex, mint, true
+					return true
+				}
+			}
+			if walk(instruction.Inner) {
+				return true
+			}
+		}
+		return false
+	}
+	walk(instructions)
+	return found, launched, isLaunch
+}
+
+func signedBy(instruction *inspector.Instruction, wallet solana.PublicKey) bool {
+	for _, account := range instruction.Accounts {
+		if account.IsSigner && account.PublicKey == wallet {
+			return true
+		}
+	}
+	return false
+}
+
+// transferOf fills the event with the token the wallet sent or received,
+// or else the SOL it did (the fee aside). Returns false if its balances didn't change.
+func transferOf(wallet solana.PublicKey, changes *inspector.BalanceChanges, event *Event) bool {
+	for _, delta := range changes.Tokens {
+		if delta.Owner != wallet || delta.Pre == delta.Post || delta.Mint == solana.SolMint {
+			continue
+		}
+		event.Mint, event.Decimals = delta.Mint, delta.Decimals
+		event.Incoming = delta.Post > delta.Pre
+		if event.Incoming {
+			event.Amount = delta.Post - delta.Pre
+		} else {
+			event.Amount = delta.Pre - delta.Post
+		}
+		for _, other := range changes.Tokens {
+			if other.Owner != wallet && other.Mint == delta.Mint && other.Delta.CmpAbs(delta.Delta) == 0 && other.Delta.Sign() == -delta.Delta.Sign() {
+				event.Counterparty = other.Owner
+				break
+			}
+		}
+		return true
+	}
+
+	lamports := changes.SOLDeltaOf(wallet)
+	if wallet == changes.FeePayer {
+		lamports += int64(changes.Fee)
+	}
+	if lamports == 0 {
+		return false
+	}
+	event.Mint, event.Decimals = solana.SolMint, inspector.SOLDecimals
+	event.Incoming = lamports > 0
+	if event.Incoming {
+		event.Amount = uint64(lamports)
+	} else {
+		event.Amount = uint64(-lamports)
+	}
+	for _, other := range changes.SOL {
+		delta := other.Delta
+		if other.Account == changes.FeePayer {
+			delta += int64(changes.Fee)
+		}
+		if other.Account != wallet && delta == -lamports {
+			event.Counterparty = other.Account
+			break
+		}
+	}
+	return true
+}
@@ -0,0 +1,192 @@
+package tracker
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/scatkit/pumpdexer/inspector"
+	"github.com/scatkit/pumpdexer/programs/system"
+	"github.com/scatkit/pumpdexer/rpc"
+	"github.com/scatkit/pumpdexer/solana"
+	"github.com/stretchr/testify/require"
+)
+
+type rawInstruction struct {
+	programID solana.PublicKey
+	accounts  []*solana.AccountMeta
+	data      []byte
+}
+
+func (inst rawInstruction) ProgramID() solana.PublicKey     { return inst.programID }
+func (inst rawInstruction) Accounts() []*solana.AccountMeta { return inst.accounts }
+func (inst rawInstruction) Data() ([]byte, error)           { return inst.data, nil }
+
+// transactionResult returns what getTransaction serves for a transaction of the wallet (the fee payer).
+func transactionResult(t *testing.T, sig solana.Signature, wallet solana.PublicKey, instructions []solana.Instruction, meta *rpc.TransactionMeta) map[string]interface{} {
+	tx, err := solana.NewTransaction(instructions, solana.Hash{}, solana.TransactionPayer(wallet))
+	require.NoError(t, err)
+	tx.Signatures = []solana.Signature{sig}
+	raw, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	return map[string]interface{}{
+		"slot":        42,
+		"transaction": []string{base64.StdEncoding.EncodeToString(raw), "base64"},
+		"meta":        meta,
+	}
+}
+
+func decodeResult(t *testing.T, result map[string]interface{}) *rpc.GetTransactionResult {
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	out := new(rpc.GetTransactionResult)
+	require.NoError(t, json.Unmarshal(data, out))
+	return out
+}
+
+func tokenBalance(index uint16, owner, mint solana.PublicKey, amount string, decimals uint8) rpc.TokenBalance {
+	return rpc.TokenBalance{AccountIndex: index, Owner: &owner, Mint: mint, UiTokenAmount: &rpc.UiTokenAmount{Amount: amount, Decimals: decimals}}
+}
+
+// pumpTrade is a trade of the wallet on pump.fun: it gives `lamports` for `tokens` (or the opposite if negative).
+// Accounts: wallet, wallet's token account, bonding curve, curve's token account, program.
+func pumpTrade(t *testing.T, sig solana.Signature, wallet, mint solana.PublicKey, data []byte, lamports int64, tokens int64) map[string]interface{} {
+	walletATA, curve, curveATA := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	instructions := []solana.Instruction{rawInstruction{
+		programID: PumpFunProgramID,
+		accounts: []*solana.AccountMeta{
+			solana.Meta(mint).WRITE(),
+			solana.Meta(wallet).WRITE().SIGNER(),
+			solana.Meta(walletATA).WRITE(),
+			solana.Meta(curve).WRITE(),
+			solana.Meta(curveATA).WRITE(),
+		},
+		data: data,
+	}}
+	const fee, start, walletTokens, curveTokens = 5_000, 10_000_000_000, 500_000_000, 800_000_000_000_000
+	itoa := func(v int64) string { return strconv.FormatInt(v, 10) }
+	meta := &rpc.TransactionMeta{
+		Fee: fee,
+		// wallet, mint, wallet ATA, curve, curve ATA, program
+		PreBalances:  []uint64{start, 1, 2_039_280, 1_000_000_000, 2_039_280, 1},
+		PostBalances: []uint64{uint64(start - fee - lamports), 1, 2_039_280, uint64(1_000_000_000 + lamports), 2_039_280, 1},
+		PreTokenBalances: []rpc.TokenBalance{
+			tokenBalance(2, wallet, mint, itoa(walletTokens), 6),
+			tokenBalance(4, curve, mint, itoa(curveTokens), 6),
+		},
+		PostTokenBalances: []rpc.TokenBalance{
+			tokenBalance(2, wallet, mint, itoa(walletTokens+tokens), 6),
+			tokenBalance(4, curve, mint, itoa(curveTokens-tokens), 6),
+		},
+	}
+	return transactionResult(t, sig, wallet, instructions, meta)
+}
+
+func TestClassify_Trades(t *testing.T) {
+	wallet, mint := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	classifier := NewClassifier()
+	buyData := []byte{102, 6, 61, 18, 1, 218, 235, 234}
+
+	event, err := classifier.Classify(wallet, decodeResult(t, pumpTrade(t, solana.Signature{1}, wallet, mint, buyData, 1_000_000_000, 2_000_000_000)))
+	require.NoError(t, err)
+	require.Equal(t, &Event{
+		Kind:          EventBuy,
+		Wallet:        wallet,
+		Signature:     solana.Signature{1},
+		Slot:          42,
+		DEX:           "PumpFun",
+		Mint:          mint,
+		Amount:        2_000_000_000,
+		Decimals:      6,
+		QuoteMint:     solana.SolMint,
+		QuoteAmount:   1_000_000_000,
+		QuoteDecimals: 9,
+		Price:         0.0005,
+		Fee:           5_000,
+	}, event)
+
+	event, err = classifier.Classify(wallet, decodeResult(t, pumpTrade(t, solana.Signature{2}, wallet, mint, buyData, -400_000_000, -500_000_000)))
+	require.NoError(t, err)
+	require.Equal(t, EventSell, event.Kind)
+	require.Equal(t, mint, event.Mint)
+	require.Equal(t, uint64(500_000_000), event.Amount)
+	require.Equal(t, uint64(400_000_000), event.QuoteAmount)
+	require.InDelta(t, 0.0008, event.Price, 1e-12)
+
+	// The wallet launches the token and buys it in the same instruction.
+	event, err = classifier.Classify(wallet, decodeResult(t, pumpTrade(t, solana.Signature{3}, wallet, mint, pumpFunCreateDiscriminator, 1_000_000_000, 2_000_000_000)))
+	require.NoError(t, err)
+	require.Equal(t, EventLaunch, event.Kind)
+	require.Equal(t, mint, event.Mint)
+	require.Equal(t, uint64(2_000_000_000), event.Amount)
+	require.Equal(t, uint64(1_000_000_000), event.QuoteAmount)
+
+	// Seen from the bonding curve, which didn't sign it, the launch is a sell.
+	curveResult := decodeResult(t, pumpTrade(t, solana.Signature{4}, wallet, mint, pumpFunCreateDiscriminator, 1_000_000_000, 2_000_000_000))
+	tx, err := curveResult.Transaction.GetTransaction()
+	require.NoError(t, err)
+	curve := tx.Message.AccountKeys[3]
+	event, err = classifier.Classify(curve, curveResult)
+	require.NoError(t, err)
+	require.Equal(t, EventSell, event.Kind)
+	require.Zero(t, event.Fee)
+
+	failed := decodeResult(t, pumpTrade(t, solana.Signature{5}, wallet, mint, buyData, 1_000_000_000, 2_000_000_000))
+	failed.Meta.Err = map[string]interface{}{"InstructionError": []interface{}{0, "Custom"}}
+	event, err = classifier.Classify(wallet, failed)
+	require.NoError(t, err)
+	require.Nil(t, event)
+}
+
+func TestClassify_Transfers(t *testing.T) {
+	wallet, friend := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	classifier := NewClassifier()
+
+	result := decodeResult(t, transactionResult(t, solana.Signature{1}, wallet, []solana.Instruction{
+		system.NewTransferInstruction(3_000_000, wallet, friend).Build(),
+	}, &rpc.TransactionMeta{
+		Fee:          5_000,
+		PreBalances:  []uint64{1_000_000_000, 0, 1},
+		PostBalances: []uint64{1_000_000_000 - 3_000_000 - 5_000, 3_000_000, 1},
+	}))
+	event, err := classifier.Classify(wallet, result)
+	require.NoError(t, err)
+	require.Equal(t, EventTransfer, event.Kind)
+	require.Equal(t, solana.SolMint, event.Mint)
+	require.Equal(t, uint64(3_000_000), event.Amount)
+	require.Equal(t, uint8(inspector.SOLDecimals), event.Decimals)
+	require.False(t, event.Incoming)
+	require.Equal(t, friend, event.Counterparty)
+
+	event, err = classifier.Classify(friend, result)
+	require.NoError(t, err)
+	require.True(t, event.Incoming)
+	require.Equal(t, wallet, event.Counterparty)
+
+	// A token transfer: the wallet sends 7 tokens to the friend.
+	mint := solana.NewWallet().PublicKey()
+	walletATA, friendATA := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	result = decodeResult(t, transactionResult(t, solana.Signature{2}, wallet, []solana.Instruction{rawInstruction{
+		programID: solana.TokenProgramID,
+		accounts:  []*solana.AccountMeta{solana.Meta(walletATA).WRITE(), solana.Meta(friendATA).WRITE(), solana.Meta(wallet).SIGNER()},
+		data:      []byte{3, 7, 0, 0, 0, 0, 0, 0, 0},
+	}}, &rpc.TransactionMeta{
+		Fee:               5_000,
+		PreBalances:       []uint64{1_000_000_000, 2_039_280, 2_039_280, 1},
+		PostBalances:      []uint64{1_000_000_000 - 5_000, 2_039_280, 2_039_280, 1},
+		PreTokenBalances:  []rpc.TokenBalance{tokenBalance(1, wallet, mint, "10", 0), tokenBalance(2, friend, mint, "0", 0)},
+		PostTokenBalances: []rpc.TokenBalance{tokenBalance(1, wallet, mint, "3", 0), tokenBalance(2, friend, mint, "7", 0)},
+	}))
+	event, err = classifier.Classify(wallet, result)
+	require.NoError(t, err)
+	require.Equal(t, EventTransfer, event.Kind)
+	require.Equal(t, mint, event.Mint)
+	require.Equal(t, uint64(7), event.Amount)
+	require.Equal(t, friend, event.Counterparty)
+
+	// Nothing changed for a bystander.
+	event, err = classifier.Classify(solana.NewWallet().PublicKey(), result)
+	require.NoError(t, err)
+	require.Nil(t, event)
+}
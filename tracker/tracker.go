@@ -0,0 +1,389 @@
+// Package tracker follows wallets and reports what they trade, launch and transfer.
+//
+// Each wallet is followed with a logsSubscribe "mentions" subscription; every new signature
+// is fetched with getTransaction and classified. Gaps (the tracker started from a known
+// signature, or the subscription dropped) are backfilled with getSignaturesForAddress.
+package tracker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/scatkit/pumpdexer/rpc"
+	"github.com/scatkit/pumpdexer/solana"
+	"github.com/scatkit/pumpdexer/ws"
+)
+
+// Subscriber opens logs subscriptions: a *ws.Client or a *ws.Pool.
+type Subscriber interface {
+	LogSubscribeToAddress(mentions solana.PublicKey, commitment rpc.CommitmentType, opts ...ws.SubscriptionOption) (*ws.LogSubscription, error)
+}
+
+var ErrAlreadyTracked = errors.New("wallet is already tracked")
+
+type Options struct {
+	// Commitment of the subscriptions and fetches; getTransaction needs at least "confirmed",
+	// the default.
+	Commitment rpc.CommitmentType
+	// Classifier of the transactions; NewClassifier() by default.
+	Classifier *Classifier
+	// Size of the events channel; 256 by default.
+	EventsBuffer int
+	// Number of signatures asked per getSignaturesForAddress page; 1000 (the max) by default.
+	BackfillPageSize int
+	// Attempts at fetching a transaction the node doesn't serve yet; 5 by default.
+	FetchAttempts int
+	// Delay between fetch attempts, and between resubscription attempts; 500ms by default.
+	RetryDelay time.Duration
+	// Rounds of fetch attempts at a transaction before giving up on it; 3 by default.
+	// A transaction that couldn't be fetched is tried again before the next one of the
+	// wallet, and after the backfill that follows a resubscription.
+	RetryRounds int
+	// Options of the logs subscriptions.
+	SubscriptionOptions []ws.SubscriptionOption
+}
+
+// Tracker follows wallets. Events are delivered on Events(), in the order each wallet
+// made them; a reader must keep up, as the tracker waits for room in the channel.
+type Tracker struct {
+	rpcClient  *rpc.Client
+	subscriber Subscriber
+	opts       Options
+
+	events chan *Event
+	errs   chan error
+
+	lock    sync.Mutex
+	wallets map[solana.PublicKey]*trackedWallet
+	wg      sync.WaitGroup
+}
+
+// trackedWallet is the entry of a tracked wallet, told apart from a later one for the same wallet.
+type trackedWallet struct {
+	cancel context.CancelFunc
+}
+
+func New(rpcClient *rpc.Client, subscriber Subscriber, opts *Options) *Tracker {
+	t := &Tracker{
+		rpcClient:  rpcClient,
+		subscriber: subscriber,
+		wallets:    make(map[solana.PublicKey]*trackedWallet),
+	}
+	if opts != nil {
+		t.opts = *opts
+	}
+	if t.opts.Commitment == "" {
+		t.opts.Commitment = rpc.CommitmentConfirmed
+	}
+	if t.opts.Classifier == nil {
+		t.opts.Classifier = NewClassifier()
+	}
+	if t.opts.EventsBuffer <= 0 {
+		t.opts.EventsBuffer = 256
+	}
+	if t.opts.BackfillPageSize <= 0 {
+		t.opts.BackfillPageSize = 1000
+	}
+	if t.opts.FetchAttempts <= 0 {
+		t.opts.FetchAttempts = 5
+	}
+	if t.opts.RetryDelay <= 0 {
+		t.opts.RetryDelay = 500 * time.Millisecond
+	}
+	if t.opts.RetryRounds <= 0 {
+		t.opts.RetryRounds = 3
+	}
+	t.events = make(chan *Event, t.opts.EventsBuffer)
+	t.errs = make(chan error, 64)
+	return t
+}
+
+// Events returns the channel of the events of all the tracked wallets.
+func (t *Tracker) Events() <-chan *Event {
+	return t.events
+}
+
+// Errors returns the channel of the errors met while following wallets (fetches that failed,
+// dropped subscriptions...). The tracker keeps going; errors are discarded when nobody reads them.
+func (t *Tracker) Errors() <-chan error {
+	return t.errs
+}
+
+// Track starts following a wallet until ctx is done, Untrack or Close.
+// If lastSeen is set, the transactions after it are backfilled first
+// (e.g. the last signature processed before a restart).
+func (t *Tracker) Track(ctx context.Context, wallet solana.PublicKey, lastSeen solana.Signature) error {
+	ctx, cancel := context.WithCancel(ctx)
+	entry := &trackedWallet{cancel: cancel}
+	t.lock.Lock()
+	if _, ok := t.wallets[wallet]; ok {
+		t.lock.Unlock()
+		cancel()
+		return ErrAlreadyTracked
+	}
+	// The wallet is reserved while subscribing, without holding the lock.
+	t.wallets[wallet] = entry
+	t.wg.Add(1)
+	t.lock.Unlock()
+
+	sub, err := t.subscriber.LogSubscribeToAddress(wallet, t.opts.Commitment, t.opts.SubscriptionOptions...)
+	if err != nil {
+		t.forget(wallet, entry)
+		t.wg.Done()
+		return fmt.Errorf("unable to subscribe to the logs of %s: %w", wallet, err)
+	}
+
+	w := &followedWallet{wallet: wallet, last: lastSeen, seen: newSignatureSet(4096)}
+	go func() {
+		defer t.wg.Done()
+		defer t.forget(wallet, entry)
+		t.follow(ctx, w, sub)
+	}()
+	return nil
+}
+
+// forget removes the entry of a wallet that stopped being followed,
+// unless the wallet has been tracked again since.
+func (t *Tracker) forget(wallet solana.PublicKey, entry *trackedWallet) {
+	t.lock.Lock()
+	if t.wallets[wallet] == entry {
+		delete(t.wallets, wallet)
+	}
+	t.lock.Unlock()
+	entry.cancel()
+}
+
+// Untrack stops following a wallet.
+func (t *Tracker) Untrack(wallet solana.PublicKey) {
+	t.lock.Lock()
+	entry, ok := t.wallets[wallet]
+	delete(t.wallets, wallet)
+	t.lock.Unlock()
+	if ok {
+		entry.cancel()
+	}
+}
+
+// Close stops following every wallet and waits for them to stop.
+func (t *Tracker) Close() {
+	t.lock.Lock()
+	for wallet, entry := range t.wallets {
+		entry.cancel()
+		delete(t.wallets, wallet)
+	}
+	t.lock.Unlock()
+	t.wg.Wait()
+}
+
+type followedWallet struct {
+	wallet solana.PublicKey
+	// Newest signature processed, where a backfill stops.
+	last solana.Signature
+	seen *signatureSet
+	// Signatures whose transaction couldn't be fetched or classified yet, oldest first.
+	failed []*failedSignature
+}
+
+type failedSignature struct {
+	signature  solana.Signature
+	backfilled bool
+	rounds     int
+}
+
+func (w *followedWallet) isFailed(signature solana.Signature) bool {
+	for _, failed := range w.failed {
+		if failed.signature == signature {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *Tracker) follow(ctx context.Context, w *followedWallet, sub *ws.LogSubscription) {
+	for {
+		// Subscribed first, so that what happens during the backfill is not missed;
+		// it is deduplicated by the seen signatures.
+		t.backfill(ctx, w)
+		for {
+			res, err := sub.Recv(ctx)
+			if err != nil {
+				break
+			}
+			t.process(ctx, w, res.Value.Signature, res.Value.Err != nil, false)
+		}
+		sub.Unsubscribe()
+		if ctx.Err() != nil {
+			return
+		}
+		t.reportError(fmt.Errorf("logs subscription of %s ended, resubscribing", w.wallet))
+
+		for sub = nil; sub == nil; {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(t.opts.RetryDelay):
+			}
+			var err error
+			if sub, err = t.subscriber.LogSubscribeToAddress(w.wallet, t.opts.Commitment, t.opts.SubscriptionOptions...); err != nil {
+				t.reportError(fmt.Errorf("unable to resubscribe to the logs of %s: %w", w.wallet, err))
+			}
+		}
+	}
+}
+
+// backfill processes the transactions of the wallet after the last one processed, oldest first.
+func (t *Tracker) backfill(ctx context.Context, w *followedWallet) {
+	if w.last.IsZero() {
+		return
+	}
+	var missed []*rpc.SignatureHistoryEntry
+	it := t.rpcClient.NewSignatureIterator(w.wallet, &rpc.SignatureIteratorOpts{
+		Commitment: t.opts.Commitment,
+		Until:      w.last,
+		PageSize:   t.opts.BackfillPageSize,
+	})
+	for {
+		entry, err := it.Next(ctx)
+		if errors.Is(err, rpc.ErrNoMoreSignatures) {
+			break
+		}
+		if err != nil {
+			t.reportError(fmt.Errorf("unable to backfill %s: %w", w.wallet, err))
+			break
+		}
+		missed = append(missed, entry)
+	}
+	for i := len(missed) - 1; i >= 0; i-- {
+		t.process(ctx, w, missed[i].Signature, missed[i].Err != nil, true)
+	}
+	t.retryFailed(ctx, w)
+}
+
+// process handles a new signature of the wallet, after the ones that failed before it.
+func (t *Tracker) process(ctx context.Context, w *followedWallet, signature solana.Signature, failed bool, backfilled bool) {
+	if w.seen.has(signature) || w.isFailed(signature) {
+		return
+	}
+	t.retryFailed(ctx, w)
+	if t.handle(ctx, w, &failedSignature{signature: signature, backfilled: backfilled}, failed) {
+		w.last = signature
+	}
+}
+
+// retryFailed handles again the signatures that failed, oldest first.
+func (t *Tracker) retryFailed(ctx context.Context, w *followedWallet) {
+	failed := w.failed
+	w.failed = nil
+	for _, entry := range failed {
+		t.handle(ctx, w, entry, false)
+	}
+}
+
+// handle fetches and classifies the transaction of a signature, and sends its event.
+// The signature is only marked as seen once that succeeded; otherwise it is kept
+// in the failed ones to be tried again, until RetryRounds.
+func (t *Tracker) handle(ctx context.Context, w *followedWallet, entry *failedSignature, failed bool) bool {
+	if !failed {
+		event, err := t.fetchEvent(ctx, w, entry.signature)
+		if err != nil {
+			if ctx.Err() != nil {
+				return false
+			}
+			if entry.rounds++; entry.rounds < t.opts.RetryRounds {
+				w.failed = append(w.failed, entry)
+				t.reportError(fmt.Errorf("%w, will retry", err))
+			} else {
+				t.reportError(fmt.Errorf("%w, giving up after %d rounds", err, entry.rounds))
+			}
+			return false
+		}
+		if event != nil {
+			event.Backfilled = entry.backfilled
+			select {
+			case t.events <- event:
+			case <-ctx.Done():
+				return false
+			}
+		}
+	}
+	w.seen.add(entry.signature)
+	return true
+}
+
+// fetchEvent fetches the transaction of a signature and classifies it; the event is nil
+// when the transaction is not one the tracker reports.
+func (t *Tracker) fetchEvent(ctx context.Context, w *followedWallet, signature solana.Signature) (*Event, error) {
+	result, err := t.fetch(ctx, signature)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch %s: %w", signature, err)
+	}
+	event, err := t.opts.Classifier.Classify(w.wallet, result)
+	if err != nil {
+		return nil, fmt.Errorf("unable to classify %s: %w", signature, err)
+	}
+	return event, nil
+}
+
+// fetch gets a transaction, retrying while the node doesn't serve it yet
+// (notifications can come before the transaction is queryable).
+func (t *Tracker) fetch(ctx context.Context, signature solana.Signature) (*rpc.GetTransactionResult, error) {
+	maxVersion := uint64(0)
+	opts := &rpc.GetTransactionOpts{
+		Encoding:                       solana.EncodingBase64,
+		Commitment:                     t.opts.Commitment,
+		MaxSupportedTransactionVersion: &maxVersion,
+	}
+	var err error
+	for attempt := 0; attempt < t.opts.FetchAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(t.opts.RetryDelay):
+			}
+		}
+		var result *rpc.GetTransactionResult
+		if result, err = t.rpcClient.GetTransaction(ctx, signature, opts); err == nil {
+			return result, nil
+		}
+	}
+	return nil, err
+}
+
+func (t *Tracker) reportError(err error) {
+	select {
+	case t.errs <- err:
+	default:
+	}
+}
+
+// signatureSet remembers the last `size` signatures added.
+type signatureSet struct {
+	set  map[solana.Signature]struct{}
+	ring []solana.Signature
+	next int
+}
+
+func newSignatureSet(size int) *signatureSet {
+	return &signatureSet{set: make(map[solana.Signature]struct{}, size), ring: make([]solana.Signature, size)}
+}
+
+func (s *signatureSet) has(signature solana.Signature) bool {
+	_, ok := s.set[signature]
+	return ok
+}
+
+// add returns false if the signature is already in the set.
+func (s *signatureSet) add(signature solana.Signature) bool {
+	if _, ok := s.set[signature]; ok {
+		return false
+	}
+	delete(s.set, s.ring[s.next])
+	s.ring[s.next] = signature
+	s.next = (s.next + 1) % len(s.ring)
+	s.set[signature] = struct{}{}
+	return true
+}
@@ -0,0 +1,213 @@
+package tracker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/scatkit/pumpdexer/programs/system"
+	"github.com/scatkit/pumpdexer/rpc"
+	"github.com/scatkit/pumpdexer/solana"
+	"github.com/scatkit/pumpdexer/testutil"
+	"github.com/scatkit/pumpdexer/ws"
+	"github.com/stretchr/testify/require"
+)
+
+// dialingSubscriber opens every subscription on a new connection, so that
+// the tracker can resubscribe after the server dropped the previous one.
+type dialingSubscriber struct {
+	t   *testing.T
+	url string
+}
+
+func (d *dialingSubscriber) LogSubscribeToAddress(mentions solana.PublicKey, commitment rpc.CommitmentType, opts ...ws.SubscriptionOption) (*ws.LogSubscription, error) {
+	client, err := ws.Connect(context.Background(), d.url)
+	if err != nil {
+		return nil, err
+	}
+	d.t.Cleanup(client.Close)
+	return client.LogSubscribeToAddress(mentions, commitment, opts...)
+}
+
+func nextEvent(t *testing.T, tracker *Tracker) *Event {
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case event := <-tracker.Events():
+			return event
+		case err := <-tracker.Errors():
+			t.Log(err)
+		case <-timeout:
+			t.Fatal("no event")
+			return nil
+		}
+	}
+}
+
+func TestTracker(t *testing.T) {
+	srv := testutil.NewServer()
+	defer srv.Close()
+	wallet, friend, mint := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	buyData := []byte{102, 6, 61, 18, 1, 218, 235, 234}
+	transfer := func(sig solana.Signature) map[string]interface{} {
+		return transactionResult(t, sig, wallet, []solana.Instruction{
+			system.NewTransferInstruction(1_000_000, wallet, friend).Build(),
+		}, &rpc.TransactionMeta{
+			Fee:          5_000,
+			PreBalances:  []uint64{1_000_000_000, 0, 1},
+			PostBalances: []uint64{1_000_000_000 - 1_000_000 - 5_000, 1_000_000, 1},
+		})
+	}
+	history := func(sigs ...solana.Signature) {
+		entries := make([]interface{}, len(sigs))
+		for i, sig := range sigs {
+			entries[i] = &rpc.TransactionSignature{Signature: sig, Slot: 42}
+		}
+		srv.SetSignaturesForAddress(wallet, entries...)
+	}
+
+	// The tracker stopped at 1; 2 and 3 happened since.
+	srv.SetTransaction(solana.Signature{2}, pumpTrade(t, solana.Signature{2}, wallet, mint, buyData, 1_000_000_000, 2_000_000_000))
+	srv.SetTransaction(solana.Signature{3}, transfer(solana.Signature{3}))
+	history(solana.Signature{3}, solana.Signature{2}, solana.Signature{1})
+
+	tracker := New(rpc.New(srv.URL), &dialingSubscriber{t: t, url: srv.WSURL()}, &Options{RetryDelay: 20 * time.Millisecond})
+	defer tracker.Close()
+	require.NoError(t, tracker.Track(context.Background(), wallet, solana.Signature{1}))
+	require.ErrorIs(t, tracker.Track(context.Background(), wallet, solana.Signature{}), ErrAlreadyTracked)
+
+	event := nextEvent(t, tracker)
+	require.Equal(t, solana.Signature{2}, event.Signature)
+	require.Equal(t, EventBuy, event.Kind)
+	require.True(t, event.Backfilled)
+	event = nextEvent(t, tracker)
+	require.Equal(t, solana.Signature{3}, event.Signature)
+	require.Equal(t, EventTransfer, event.Kind)
+
+	// Live: 3 again is skipped, 4 is only served by the node after a while.
+	require.Eventually(t, func() bool { return srv.NumSubscriptions() == 1 }, 2*time.Second, 5*time.Millisecond)
+	require.Equal(t, 1, srv.NotifyLogs(solana.Signature{3}, nil, nil, wallet))
+	require.Equal(t, 1, srv.NotifyLogs(solana.Signature{4}, nil, nil, wallet))
+	time.AfterFunc(50*time.Millisecond, func() {
+		srv.SetTransaction(solana.Signature{4}, pumpTrade(t, solana.Signature{4}, wallet, mint, buyData, -1_000_000_000, -400_000_000))
+	})
+	event = nextEvent(t, tracker)
+	require.Equal(t, solana.Signature{4}, event.Signature)
+	require.Equal(t, EventSell, event.Kind)
+	require.False(t, event.Backfilled)
+
+	// The connection drops and 5 happens meanwhile: it is backfilled once resubscribed.
+	srv.SetTransaction(solana.Signature{5}, transfer(solana.Signature{5}))
+	history(solana.Signature{5}, solana.Signature{4}, solana.Signature{3}, solana.Signature{2}, solana.Signature{1})
+	srv.CloseConnections()
+	event = nextEvent(t, tracker)
+	require.Equal(t, solana.Signature{5}, event.Signature)
+	require.True(t, event.Backfilled)
+
+	tracker.Untrack(wallet)
+	require.Eventually(t, func() bool { return srv.NumSubscriptions() == 0 }, 2*time.Second, 5*time.Millisecond)
+}
+
+// gatedSubscriber blocks the subscriptions to a wallet until its gate is closed,
+// and fails them while failing is set.
+type gatedSubscriber struct {
+	dialingSubscriber
+	gate    chan struct{}
+	blocked solana.PublicKey
+	failing bool
+}
+
+func (g *gatedSubscriber) LogSubscribeToAddress(mentions solana.PublicKey, commitment rpc.CommitmentType, opts ...ws.SubscriptionOption) (*ws.LogSubscription, error) {
+	if mentions == g.blocked {
+		<-g.gate
+	}
+	if g.failing {
+		return nil, errors.New("subscription refused")
+	}
+	return g.dialingSubscriber.LogSubscribeToAddress(mentions, commitment, opts...)
+}
+
+func TestTracker_TrackAgain(t *testing.T) {
+	srv := testutil.NewServer()
+	defer srv.Close()
+	wallet, slow := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	subscriber := &gatedSubscriber{
+		dialingSubscriber: dialingSubscriber{t: t, url: srv.WSURL()},
+		gate:              make(chan struct{}),
+		blocked:           slow,
+	}
+	tracker := New(rpc.New(srv.URL), subscriber, &Options{RetryDelay: 20 * time.Millisecond})
+	defer tracker.Close()
+
+	// A slow subscription reserves its wallet without blocking the others.
+	slowDone := make(chan error, 1)
+	go func() { slowDone <- tracker.Track(context.Background(), slow, solana.Signature{}) }()
+	require.Eventually(t, func() bool {
+		return errors.Is(tracker.Track(context.Background(), slow, solana.Signature{}), ErrAlreadyTracked)
+	}, 2*time.Second, 5*time.Millisecond)
+
+	// A wallet is tracked again once the context it was tracked with ends.
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, tracker.Track(ctx, wallet, solana.Signature{}))
+	cancel()
+	require.Eventually(t, func() bool {
+		return tracker.Track(context.Background(), wallet, solana.Signature{}) == nil
+	}, 2*time.Second, 5*time.Millisecond)
+	require.Eventually(t, func() bool { return srv.NumSubscriptions() == 1 }, 2*time.Second, 5*time.Millisecond)
+
+	// A failed subscription frees its wallet.
+	subscriber.failing = true
+	close(subscriber.gate)
+	require.ErrorContains(t, <-slowDone, "subscription refused")
+	subscriber.failing = false
+	require.NoError(t, tracker.Track(context.Background(), slow, solana.Signature{}))
+	require.Eventually(t, func() bool { return srv.NumSubscriptions() == 2 }, 2*time.Second, 5*time.Millisecond)
+}
+
+func TestTracker_RetryFailedFetch(t *testing.T) {
+	srv := testutil.NewServer()
+	defer srv.Close()
+	wallet, friend := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	transfer := func(sig solana.Signature) map[string]interface{} {
+		return transactionResult(t, sig, wallet, []solana.Instruction{
+			system.NewTransferInstruction(1_000_000, wallet, friend).Build(),
+		}, &rpc.TransactionMeta{
+			Fee:          5_000,
+			PreBalances:  []uint64{1_000_000_000, 0, 1},
+			PostBalances: []uint64{1_000_000_000 - 1_000_000 - 5_000, 1_000_000, 1},
+		})
+	}
+
+	tracker := New(rpc.New(srv.URL), &dialingSubscriber{t: t, url: srv.WSURL()}, &Options{
+		FetchAttempts: 1,
+		RetryDelay:    10 * time.Millisecond,
+	})
+	defer tracker.Close()
+	require.NoError(t, tracker.Track(context.Background(), wallet, solana.Signature{}))
+	require.Eventually(t, func() bool { return srv.NumSubscriptions() == 1 }, 2*time.Second, 5*time.Millisecond)
+
+	// 1 can't be fetched: it is kept, not marked as seen.
+	require.Equal(t, 1, srv.NotifyLogs(solana.Signature{1}, nil, nil, wallet))
+	select {
+	case err := <-tracker.Errors():
+		require.ErrorContains(t, err, "will retry")
+	case <-time.After(2 * time.Second):
+		t.Fatal("no error")
+	}
+
+	// Once the node serves it, it is reported before the next signature.
+	srv.SetTransaction(solana.Signature{1}, transfer(solana.Signature{1}))
+	srv.SetTransaction(solana.Signature{2}, transfer(solana.Signature{2}))
+	require.Equal(t, 1, srv.NotifyLogs(solana.Signature{2}, nil, nil, wallet))
+	require.Equal(t, solana.Signature{1}, nextEvent(t, tracker).Signature)
+	require.Equal(t, solana.Signature{2}, nextEvent(t, tracker).Signature)
+
+	// A signature notified again after it was reported is skipped.
+	require.Equal(t, 1, srv.NotifyLogs(solana.Signature{1}, nil, nil, wallet))
+	select {
+	case event := <-tracker.Events():
+		t.Fatalf("unexpected event %s", event.Signature)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -3,7 +3,6 @@ package ws
 import (
 	//"fmt"
 	"context"
-	"errors"
 
 	"github.com/scatkit/pumpdexer/rpc"
 	"github.com/scatkit/pumpdexer/solana"
@@ -20,16 +19,12 @@ type AccountResult struct {
 	} `json:"value"`
 }
 
+// AccountSubscribeWithOpts subscribes to an account. The optional SubscriptionOption(s)
+// configure the stream buffer size and the overflow policy.
 func (cl *Client) AccountSubscribeWithOpts(account solana.PublicKey, commitment rpc.CommitmentType, encoding solana.EncodingType,
+	opts ...SubscriptionOption,
 ) (*AccountSubscription, error) {
-	params := []interface{}{account.String()} 
-	conf := map[string]interface{}{"encoding": "base64"}
-	if encoding != "" {
-		conf["encoding"] = encoding
-	}
-	if commitment != "" {
-		conf["commitment"] = commitment
-	}
+	params, conf := accountSubscribeParams(account, commitment, encoding)
 
 	genSub, err := cl.subscribe( // returns a new subscription
 		params,
@@ -41,6 +36,7 @@ func (cl *Client) AccountSubscribeWithOpts(account solana.PublicKey, commitment
 			err := decodeResponseFromMessage(msg, &acc_res)
 			return &acc_res, err
 		},
+		opts...,
 	)
 
 	if err != nil {
@@ -52,41 +48,57 @@ func (cl *Client) AccountSubscribeWithOpts(account solana.PublicKey, commitment
 	}, nil
 }
 
+func accountSubscribeParams(account solana.PublicKey, commitment rpc.CommitmentType, encoding solana.EncodingType,
+) ([]interface{}, map[string]interface{}) {
+	params := []interface{}{account.String()}
+	conf := map[string]interface{}{"encoding": "base64"}
+	if encoding != "" {
+		conf["encoding"] = encoding
+	}
+	if commitment != "" {
+		conf["commitment"] = commitment
+	}
+	return params, conf
+}
+
 type AccountSubscription struct {
 	sub *Subscription
 }
 
 func (ac *AccountSubscription) Recv(ctx context.Context) (*AccountResult, error) {
-	select {
-	case <-ctx.Done():
-		return nil, ctx.Err()
-	case d, ok := <-ac.sub.stream:
-		if !ok {
-			return nil, errors.New("sub is no longer active") // channel is locked
-		}
-		// type assertion tells the complier: trust me, d contains a value of AccountResult
-		return d.(*AccountResult), nil // d (interface contating a result) is evaluated as AccountResult and returned
-	case err := <-ac.sub.err:
+	d, err := ac.sub.recv(ctx)
+	if err != nil {
 		return nil, err
 	}
+	return d.(*AccountResult), nil
 }
 
 func (ac *AccountSubscription) Err() <-chan error {
 	return ac.sub.err
 }
 
+// Done returns a channel that's closed when the subscription ends.
+func (ac *AccountSubscription) Done() <-chan struct{} {
+	return ac.sub.Done()
+}
+
 func (ac *AccountSubscription) Response() <-chan *AccountResult {
 	typedChan := make(chan *AccountResult, 1)
 	go func(ch chan *AccountResult) {
-		d, ok := <-ac.sub.stream
-		if !ok {
-			return
+		select {
+		case d := <-ac.sub.stream:
+			ch <- d.(*AccountResult)
+		case <-ac.sub.done:
 		}
-		ch <- d.(*AccountResult)
 	}(typedChan)
 	return typedChan
 }
 
+// Dropped returns the number of messages discarded by the overflow policy.
+func (ac *AccountSubscription) Dropped() uint64 {
+	return ac.sub.Dropped()
+}
+
 func (ac *AccountSubscription) Unsubscribe() {
 	ac.sub.Unsubscribe()
 }
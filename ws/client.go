@@ -2,8 +2,10 @@ package ws
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"strconv"
 	"sync"
@@ -33,62 +35,102 @@ type Client struct {
 	connCtx                 context.Context
 	connCtxCancel           context.CancelFunc
 	lock                    sync.RWMutex
-	subscriptionByRequestID map[uint64]*Subscription
-	subscriptionByWSSubID   map[uint64]*Subscription
+	subscriptionByRequestID map[uint64]*serverSubscription
+	subscriptionByWSSubID   map[uint64]*serverSubscription
+	subscriptionByKey       map[string]*serverSubscription // identical requests share one server subscription
 	reconnectOnErr          bool
 	shortID                 bool
+	closed                  bool // set by Close
 }
 
+var ErrClientClosed = errors.New("ws client closed")
+
+// Close closes the connection and ends every subscription with ErrClientClosed.
+// The subscriptions are ended before the connection is closed, so that the read error
+// the reader gets from the closed connection doesn't reach them first.
 func (cl *Client) Close() {
 	cl.lock.Lock()
 	defer cl.lock.Unlock()
+	if cl.closed {
+		return
+	}
+	cl.closed = true
 	cl.connCtxCancel()
+	cl.closeAllSubscriptionLocked(ErrClientClosed)
 	cl.conn.Close()
 }
 
+// closeSubscription closes the server subscription along with all of its local subscribers.
 func (cl *Client) closeSubscription(reqID uint64, err error) {
 	cl.lock.Lock()
 	defer cl.lock.Unlock()
 
-	sub, found := cl.subscriptionByRequestID[reqID]
+	server, found := cl.subscriptionByRequestID[reqID]
 	if !found {
 		return
 	}
 
-	sub.err <- err
+	for _, sub := range server.subscribers {
+		sub.close(err)
+	}
+	server.subscribers = nil
 
-	err = cl.unsubscribe(sub.subID, sub.unsubscribeMethod)
+	err = cl.unsubscribe(server.subID, server.unsubscribeMethod)
 	if err != nil {
 		zlog.Warn("unable to send rpc unsubscribe call", zap.Error(err))
 	}
 
 	//deletes key-value paris from the map
-	delete(cl.subscriptionByRequestID, sub.req.ID)
-	delete(cl.subscriptionByWSSubID, sub.subID)
+	cl.forgetServerSubscription(server)
 }
 
 func (cl *Client) subscribe(params []interface{}, conf map[string]interface{},
-	subscriptionMethod string, unsubscribeMethod string, decoderFunc decoderFunc,
+	subscriptionMethod string, unsubscribeMethod string, decoderFunc decoderFunc, opts ...SubscriptionOption,
 ) (*Subscription, error) {
 	cl.lock.Lock()
 	defer cl.lock.Unlock()
+	if cl.closed {
+		return nil, ErrClientClosed
+	}
 
 	req := newRequest(params, subscriptionMethod, conf, cl.shortID) // returns a request struct (payload)
-	data, err := req.encode()                                       // serializing the request into bytes
+	key, err := subscriptionKey(subscriptionMethod, params, conf)
+	if err != nil {
+		return nil, fmt.Errorf("subscribe: %w", err)
+	}
+
+	sub := newSubscription(newSubscriptionOptions(opts))
+	sub.closeFunc = func() {
+		cl.removeSubscriber(sub, nil)
+	}
+
+	// An identical subscription is already open: fan out its results instead of asking the node again
+	if server, found := cl.subscriptionByKey[key]; found {
+		sub.server = server
+		server.subscribers = append(server.subscribers, sub)
+		zlog.Debug("attached subscriber to existing ws subscription",
+			zap.Uint64("request_id", server.req.ID),
+			zap.Int("subscribers", len(server.subscribers)),
+		)
+		return sub, nil
+	}
+
+	data, err := req.encode() // serializing the request into bytes
 	if err != nil {
 		return nil, fmt.Errorf("subscribe: unable to encode the subscription request: %w", err)
 	}
 
-	sub := newSubscription(
-		req,
-		func(err error) {
-			cl.closeSubscription(req.ID, err)
-		},
-		unsubscribeMethod,
-		decoderFunc,
-	)
+	server := &serverSubscription{
+		key:               key,
+		req:               req,
+		unsubscribeMethod: unsubscribeMethod,
+		decoderFunc:       decoderFunc,
+		subscribers:       []*Subscription{sub},
+	}
+	sub.server = server
 
-	cl.subscriptionByRequestID[req.ID] = sub
+	cl.subscriptionByRequestID[req.ID] = server
+	cl.subscriptionByKey[key] = server
 	zlog.Info("added new subscription to websocket client", zap.Int("count", len(cl.subscriptionByRequestID)))
 	zlog.Debug("writing data to conn", zap.String("data", string(data)))
 
@@ -99,6 +141,7 @@ func (cl *Client) subscribe(params []interface{}, conf map[string]interface{},
 	err = cl.conn.WriteMessage(websocket.TextMessage, data)
 	if err != nil {
 		delete(cl.subscriptionByRequestID, req.ID)
+		delete(cl.subscriptionByKey, key)
 		return nil, fmt.Errorf("unable to write request: %w", err)
 	}
 
@@ -151,8 +194,9 @@ func Connect(ctx context.Context, rpcEndpoint string) (client *Client, err error
 func ConnectWithOptions(ctx context.Context, rpcEndpoint string, opts *Options) (client *Client, err error) {
 	client = &Client{
 		rpcURL:                  rpcEndpoint,
-		subscriptionByRequestID: map[uint64]*Subscription{},
-		subscriptionByWSSubID:   map[uint64]*Subscription{},
+		subscriptionByRequestID: map[uint64]*serverSubscription{},
+		subscriptionByWSSubID:   map[uint64]*serverSubscription{},
+		subscriptionByKey:       map[string]*serverSubscription{},
 	}
 
 	// Customize how the client connects to the server
@@ -175,13 +219,34 @@ func ConnectWithOptions(ctx context.Context, rpcEndpoint string, opts *Options)
 		httpHeader = opts.HttpHeader
 	}
 
+	// The dialer only watches ctx while connecting: a node that accepts the connection but never
+	// answers the handshake would hold it until HandshakeTimeout. Interrupt the handshake when ctx ends.
+	handshakeDone := make(chan struct{})
+	dialer.NetDialContext = func(dialCtx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := (&net.Dialer{}).DialContext(dialCtx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		go func() {
+			select {
+			case <-ctx.Done():
+				conn.SetDeadline(time.Now())
+			case <-handshakeDone:
+			}
+		}()
+		return conn, nil
+	}
+
 	var resp *http.Response // to hold the http resp from DialContext
 	// Makes a connection to a websocket. httpHeader (optinal) sent along with handshake request
 	// Context to limit the dialing duration
 	client.conn, resp, err = dialer.DialContext(ctx, rpcEndpoint, httpHeader)
+	close(handshakeDone)
 
 	if err != nil {
-		if resp != nil {
+		if ctx.Err() != nil {
+			err = fmt.Errorf("new ws client: dial: %w", ctx.Err())
+		} else if resp != nil {
 			body, _ := io.ReadAll(resp.Body)
 			err = fmt.Errorf("new ws client: dial: %w, status: %s, body: %q", err, resp.Status, string(body))
 		} else {
@@ -232,6 +297,7 @@ func (cl *Client) receiveMessages() {
 			_, msgInBytes, err := cl.conn.ReadMessage()
 			if err != nil {
 				cl.closeAllSubscription(err)
+				cl.connCtxCancel() // the connection is dead, stop pinging
 				return
 			}
 
@@ -243,13 +309,25 @@ func (cl *Client) receiveMessages() {
 func (cl *Client) closeAllSubscription(err error) {
 	cl.lock.Lock()
 	defer cl.lock.Unlock()
+	cl.closeAllSubscriptionLocked(err)
+}
 
-	for _, sub := range cl.subscriptionByRequestID {
-		sub.err <- err
+// closeAllSubscriptionLocked ends every subscription; the caller must hold the lock.
+// The server subscriptions are emptied, so a later Unsubscribe doesn't write to the dead connection.
+func (cl *Client) closeAllSubscriptionLocked(err error) {
+	if cl.closed {
+		err = ErrClientClosed
+	}
+	for _, server := range cl.subscriptionByRequestID {
+		for _, sub := range server.subscribers {
+			sub.close(err)
+		}
+		server.subscribers = nil
 	}
 
-	cl.subscriptionByRequestID = map[uint64]*Subscription{}
-	cl.subscriptionByWSSubID = map[uint64]*Subscription{}
+	cl.subscriptionByRequestID = map[uint64]*serverSubscription{}
+	cl.subscriptionByWSSubID = map[uint64]*serverSubscription{}
+	cl.subscriptionByKey = map[string]*serverSubscription{}
 }
 
 func getUint64WithOk(data []byte, path ...string) (uint64, bool) {
@@ -300,31 +378,36 @@ func (cl *Client) handleSubscriptionMessage(subID uint64, message []byte) {
 	}
 
 	cl.lock.RLock()
-	sub, found := cl.subscriptionByWSSubID[subID]
+	server, found := cl.subscriptionByWSSubID[subID]
+	var subscribers []*Subscription
+	if found {
+		subscribers = append(subscribers, server.subscribers...)
+	}
 	cl.lock.RUnlock()
 	if !found {
 		zlog.Warn("unable to find subsscription for ws message", zap.Uint64("subscription_id", subID))
 		return
 	}
 
-	//Decode the subscription using the decoderFunc
-	result, err := sub.decoderFunc(message)
+	//Decode the subscription once using the decoderFunc, then fan it out to every subscriber
+	result, err := server.decoderFunc(message)
 	if err != nil {
-		cl.closeSubscription(sub.req.ID, fmt.Errorf("Unable to decode client's response: %w", err))
+		cl.closeSubscription(server.req.ID, fmt.Errorf("Unable to decode client's response: %w", err))
 		return
 	}
 
-	// Check if the channel is full
-	if len(sub.stream) >= cap(sub.stream) {
-		zlog.Warn("closing ws client subscription... not consuming fast enought", zap.Uint64("request_id", sub.req.ID))
-		cl.closeSubscription(sub.req.ID, fmt.Errorf("reached channel's max cap %d", len(sub.stream)))
-		return
-	}
+	for _, sub := range subscribers {
+		// Push the result according to the subscription's overflow policy
+		if err := sub.deliver(cl.connCtx, result); err != nil {
+			zlog.Warn("closing ws client subscription... not consuming fast enought", zap.Uint64("request_id", server.req.ID))
+			cl.removeSubscriber(sub, err)
+			continue
+		}
 
-	if !sub.closed {
-		sub.stream <- result
+		if traceEnabled && sub.Dropped() > 0 {
+			zlog.Debug("subscription dropped messages", zap.Uint64("request_id", server.req.ID), zap.Uint64("dropped", sub.Dropped()))
+		}
 	}
-
 	return
 }
 
@@ -339,7 +422,7 @@ func (cl *Client) handleNewSubscriptionMessage(requestID, subID uint64) {
 		)
 	}
 
-	callBack, found := cl.subscriptionByRequestID[requestID] // returns *Subscription
+	callBack, found := cl.subscriptionByRequestID[requestID] // returns *serverSubscription
 	//fmt.Println(found)
 	if !found {
 		zlog.Error("cannot find websocket message handler for a new stream.... this should not happen",
@@ -348,8 +431,18 @@ func (cl *Client) handleNewSubscriptionMessage(requestID, subID uint64) {
 	}
 
 	callBack.subID = subID
+	callBack.confirmed = true
 	cl.subscriptionByWSSubID[subID] = callBack
 
+	// Every local subscriber left before the node acknowledged the subscription
+	if len(callBack.subscribers) == 0 {
+		if err := cl.unsubscribe(subID, callBack.unsubscribeMethod); err != nil {
+			zlog.Warn("unable to send rpc unsubscribe call", zap.Error(err))
+		}
+		cl.forgetServerSubscription(callBack)
+		return
+	}
+
 	zlog.Debug("registered ws subscription",
 		zap.Uint64("subscription_id", subID),
 		zap.Uint64("request_id", requestID),
@@ -2,7 +2,6 @@ package ws
 
 import (
 	"context"
-	"errors"
 
 	"github.com/scatkit/pumpdexer/rpc"
 	"github.com/scatkit/pumpdexer/solana"
@@ -30,25 +29,31 @@ const (
 	LogsSubcribeFilterAllWithVotes LogsSubscribeFilterType = "allWithVotes"
 )
 
-func (cl *Client) LogSubscribe(filter LogsSubscribeFilterType, commitment rpc.CommitmentType) (*LogSubscription, error) {
-	return cl.logSubscribe(filter, commitment)
+func (cl *Client) LogSubscribe(filter LogsSubscribeFilterType, commitment rpc.CommitmentType, opts ...SubscriptionOption) (*LogSubscription, error) {
+	return cl.logSubscribe(filter, commitment, opts...)
 }
 
-func (cl *Client) LogSubscribeToAddress(mentions solana.PublicKey, commitment rpc.CommitmentType) (*LogSubscription, error) {
-	return cl.logSubscribe(
-		map[string]interface{}{
-			"mentions": []string{mentions.String()}, // mentions is an array of a signle pubkey put in the object
-		},
-		commitment,
-	)
+func (cl *Client) LogSubscribeToAddress(mentions solana.PublicKey, commitment rpc.CommitmentType, opts ...SubscriptionOption) (*LogSubscription, error) {
+	return cl.logSubscribe(mentionsFilter(mentions), commitment, opts...)
 }
 
-func (cl *Client) logSubscribe(filter interface{}, commitment rpc.CommitmentType) (*LogSubscription, error) {
+func mentionsFilter(mentions solana.PublicKey) map[string]interface{} {
+	return map[string]interface{}{
+		"mentions": []string{mentions.String()}, // mentions is an array of a signle pubkey put in the object
+	}
+}
+
+func logSubscribeParams(filter interface{}, commitment rpc.CommitmentType) ([]interface{}, map[string]interface{}) {
 	params := []interface{}{filter}
 	conf := map[string]interface{}{}
 	if commitment != "" {
 		conf["commitment"] = commitment
 	}
+	return params, conf
+}
+
+func (cl *Client) logSubscribe(filter interface{}, commitment rpc.CommitmentType, opts ...SubscriptionOption) (*LogSubscription, error) {
+	params, conf := logSubscribeParams(filter, commitment)
 
 	genSub, err := cl.subscribe(
 		params,
@@ -60,6 +65,7 @@ func (cl *Client) logSubscribe(filter interface{}, commitment rpc.CommitmentType
 			err := decodeResponseFromMessage(msg, &res)
 			return &res, err
 		},
+		opts...,
 	)
 	if err != nil {
 		return nil, err
@@ -76,36 +82,39 @@ type LogSubscription struct {
 }
 
 func (ls *LogSubscription) Recv(ctx context.Context) (*LogResult, error) {
-	select {
-	case <-ctx.Done():
-		return nil, ctx.Err()
-	case d, ok := <-ls.sub.stream:
-		if !ok {
-			return nil, errors.New("Subscription is closed")
-		}
-		return d.(*LogResult), nil
-	case err := <-ls.sub.err:
+	d, err := ls.sub.recv(ctx)
+	if err != nil {
 		return nil, err
 	}
+	return d.(*LogResult), nil
 }
 
 func (ls *LogSubscription) Err() <-chan error {
 	return ls.sub.err
 }
 
+// Done returns a channel that's closed when the subscription ends.
+func (ls *LogSubscription) Done() <-chan struct{} {
+	return ls.sub.Done()
+}
+
 func (ls *LogSubscription) Response() <-chan *LogResult {
 	typedChan := make(chan *LogResult, 1)
 	go func(ch chan *LogResult) {
-		d, ok := <-ls.sub.stream
-		if !ok {
-			return
+		select {
+		case d := <-ls.sub.stream:
+			ch <- d.(*LogResult)
+		case <-ls.sub.done:
 		}
-		ch <- d.(*LogResult)
 	}(typedChan)
-
 	return typedChan
 }
 
+// Dropped returns the number of messages discarded by the overflow policy.
+func (ls *LogSubscription) Dropped() uint64 {
+	return ls.sub.Dropped()
+}
+
 func (ls *LogSubscription) Unsubscribe() {
 	ls.sub.Unsubscribe()
 }
@@ -0,0 +1,101 @@
+package ws
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// serverSubscription is a subscription as the RPC node knows it.
+// Identical subscribe requests (same method, params and config) share a single
+// serverSubscription: the node is asked only once, every message is decoded once
+// and the result is fanned out to each local Subscription.
+// NOTE: the decoded results are shared between subscribers and must be treated as read-only.
+type serverSubscription struct {
+	key               string
+	req               *request
+	subID             uint64
+	confirmed         bool // the node acknowledged the subscription and sent its subID
+	unsubscribeMethod string
+	decoderFunc       decoderFunc
+	subscribers       []*Subscription
+}
+
+// subscriptionKey identifies identical subscribe requests (the request ID is left out).
+// The params are laid out exactly like newRequest does.
+func subscriptionKey(method string, params []interface{}, conf map[string]interface{}) (string, error) {
+	if params != nil && conf != nil {
+		params = append(params[:len(params):len(params)], conf)
+	}
+	data, err := json.Marshal(params)
+	if err != nil {
+		return "", fmt.Errorf("unable to encode subscription params: %w", err)
+	}
+	return method + ":" + string(data), nil
+}
+
+func (ss *serverSubscription) removeSubscriber(sub *Subscription) bool {
+	for i, s := range ss.subscribers {
+		if s == sub {
+			ss.subscribers = append(ss.subscribers[:i], ss.subscribers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// removeSubscriber closes a local subscriber and detaches it from its server subscription.
+// The rpc unsubscribe call is sent only when the last local subscriber leaves.
+func (cl *Client) removeSubscriber(sub *Subscription, err error) {
+	cl.lock.Lock()
+	defer cl.lock.Unlock()
+
+	sub.close(err)
+
+	server := sub.server
+	if !server.removeSubscriber(sub) {
+		return
+	}
+	if len(server.subscribers) > 0 {
+		zlog.Debug("removed subscriber from shared ws subscription",
+			zap.Uint64("request_id", server.req.ID),
+			zap.Int("subscribers", len(server.subscribers)),
+		)
+		return
+	}
+
+	if !server.confirmed {
+		// The subID is not known yet: the unsubscribe call goes out once the node acknowledges
+		// (see handleNewSubscriptionMessage). New identical requests get a fresh server subscription.
+		delete(cl.subscriptionByKey, server.key)
+		return
+	}
+	if err := cl.unsubscribe(server.subID, server.unsubscribeMethod); err != nil {
+		zlog.Warn("unable to send rpc unsubscribe call", zap.Error(err))
+	}
+	cl.forgetServerSubscription(server)
+}
+
+func (cl *Client) hasSubscription(key string) bool {
+	cl.lock.RLock()
+	defer cl.lock.RUnlock()
+	_, found := cl.subscriptionByKey[key]
+	return found
+}
+
+// numSubscriptions returns the number of server subscriptions open on the connection.
+func (cl *Client) numSubscriptions() int {
+	cl.lock.RLock()
+	defer cl.lock.RUnlock()
+	return len(cl.subscriptionByKey)
+}
+
+func (cl *Client) forgetServerSubscription(server *serverSubscription) {
+	delete(cl.subscriptionByRequestID, server.req.ID)
+	if server.confirmed && cl.subscriptionByWSSubID[server.subID] == server {
+		delete(cl.subscriptionByWSSubID, server.subID)
+	}
+	if cl.subscriptionByKey[server.key] == server {
+		delete(cl.subscriptionByKey, server.key)
+	}
+}
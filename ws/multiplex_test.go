@@ -0,0 +1,49 @@
+package ws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/scatkit/pumpdexer/rpc"
+	"github.com/scatkit/pumpdexer/solana"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_SharesIdenticalSubscriptions(t *testing.T) {
+	srv := newWSTestServer(t)
+	cl, err := Connect(context.Background(), srv.WSURL())
+	require.NoError(t, err)
+	defer cl.Close()
+
+	pool := solana.MustPubkeyFromBase58("GJVvpsKp5snWU4VVZa71yeNnLCRckecooqEhiyRSszMC")
+	first, err := cl.AccountSubscribeWithOpts(pool, rpc.CommitmentConfirmed, "")
+	require.NoError(t, err)
+	second, err := cl.AccountSubscribeWithOpts(pool, rpc.CommitmentConfirmed, "")
+	require.NoError(t, err)
+	// a different commitment is a different server subscription
+	other, err := cl.AccountSubscribeWithOpts(pool, rpc.CommitmentFinalized, "")
+	require.NoError(t, err)
+	defer other.Unsubscribe()
+
+	require.Equal(t, []string{"accountSubscribe", "accountSubscribe"}, srv.waitForMethods(t, 2))
+
+	srv.notify(t, accountNotificationResult(7, 42))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	for _, sub := range []*AccountSubscription{first, second} {
+		got, err := sub.Recv(ctx)
+		require.NoError(t, err)
+		require.Equal(t, uint64(7), got.Context.Slot)
+		require.Equal(t, uint64(42), got.Value.Lamports)
+	}
+
+	// the node is told to unsubscribe only once the last local subscriber leaves
+	first.Unsubscribe()
+	time.Sleep(50 * time.Millisecond)
+	require.Len(t, srv.Methods(), 2)
+
+	second.Unsubscribe()
+	require.Equal(t, "accountUnsubscribe", srv.waitForMethods(t, 3)[2])
+}
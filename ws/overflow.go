@@ -0,0 +1,86 @@
+package ws
+
+import "fmt"
+
+// OverflowPolicy decides what a subscription does with a new message
+// when its stream buffer is full (the consumer is not keeping up).
+type OverflowPolicy uint8
+
+const (
+	// Close the subscription with an error (default).
+	OverflowClose OverflowPolicy = iota
+	// Discard the oldest buffered message to make room for the new one.
+	OverflowDropOldest
+	// Discard the new message and keep the buffered ones.
+	OverflowDropNewest
+	// Keep only the latest message. The stream holds at most one pending value,
+	// which is replaced by every new message. Account subscriptions watch a single
+	// account, so this always yields the latest account state.
+	OverflowCoalesce
+	// Wait until the consumer reads. NOTE: this stalls the connection's reader,
+	// so every other subscription on the same client waits as well.
+	OverflowBlock
+)
+
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowClose:
+		return "close"
+	case OverflowDropOldest:
+		return "drop_oldest"
+	case OverflowDropNewest:
+		return "drop_newest"
+	case OverflowCoalesce:
+		return "coalesce"
+	case OverflowBlock:
+		return "block"
+	default:
+		return fmt.Sprintf("OverflowPolicy(%d)", uint8(p))
+	}
+}
+
+// Size of the stream buffer of a subscription when none is provided.
+var DefaultSubscriptionBufferSize = 200_000
+
+type SubscriptionOption interface {
+	apply(opts *subscriptionOptions)
+}
+
+type subscriptionOptions struct {
+	bufferSize int
+	overflow   OverflowPolicy
+}
+
+type subscriptionOptionFunc func(opts *subscriptionOptions)
+
+func (f subscriptionOptionFunc) apply(opts *subscriptionOptions) {
+	f(opts)
+}
+
+// SubscriptionBufferSize sets how many messages can wait in the stream before the overflow policy kicks in.
+// Ignored by OverflowCoalesce which always buffers a single message.
+func SubscriptionBufferSize(size int) SubscriptionOption {
+	return subscriptionOptionFunc(func(opts *subscriptionOptions) { opts.bufferSize = size })
+}
+
+// SubscriptionOverflowPolicy sets what happens when the stream buffer is full.
+func SubscriptionOverflowPolicy(policy OverflowPolicy) SubscriptionOption {
+	return subscriptionOptionFunc(func(opts *subscriptionOptions) { opts.overflow = policy })
+}
+
+func newSubscriptionOptions(opts []SubscriptionOption) subscriptionOptions {
+	options := subscriptionOptions{
+		bufferSize: DefaultSubscriptionBufferSize,
+		overflow:   OverflowClose,
+	}
+	for _, opt := range opts {
+		opt.apply(&options)
+	}
+	if options.bufferSize <= 0 {
+		options.bufferSize = DefaultSubscriptionBufferSize
+	}
+	if options.overflow == OverflowCoalesce {
+		options.bufferSize = 1
+	}
+	return options
+}
@@ -0,0 +1,84 @@
+package ws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSubscription(opts ...SubscriptionOption) *Subscription {
+	return newSubscription(newSubscriptionOptions(opts))
+}
+
+func drain(sub *Subscription) (out []interface{}) {
+	for {
+		select {
+		case v := <-sub.stream:
+			out = append(out, v)
+		default:
+			return out
+		}
+	}
+}
+
+func TestSubscription_OverflowPolicies(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("close", func(t *testing.T) {
+		sub := newTestSubscription(SubscriptionBufferSize(2))
+		require.NoError(t, sub.deliver(ctx, 1))
+		require.NoError(t, sub.deliver(ctx, 2))
+		require.Error(t, sub.deliver(ctx, 3))
+		require.Equal(t, []interface{}{1, 2}, drain(sub))
+	})
+
+	t.Run("drop oldest", func(t *testing.T) {
+		sub := newTestSubscription(SubscriptionBufferSize(2), SubscriptionOverflowPolicy(OverflowDropOldest))
+		for i := 1; i <= 4; i++ {
+			require.NoError(t, sub.deliver(ctx, i))
+		}
+		require.Equal(t, []interface{}{3, 4}, drain(sub))
+		require.Equal(t, uint64(2), sub.Dropped())
+	})
+
+	t.Run("drop newest", func(t *testing.T) {
+		sub := newTestSubscription(SubscriptionBufferSize(2), SubscriptionOverflowPolicy(OverflowDropNewest))
+		for i := 1; i <= 4; i++ {
+			require.NoError(t, sub.deliver(ctx, i))
+		}
+		require.Equal(t, []interface{}{1, 2}, drain(sub))
+		require.Equal(t, uint64(2), sub.Dropped())
+	})
+
+	t.Run("coalesce", func(t *testing.T) {
+		sub := newTestSubscription(SubscriptionBufferSize(100), SubscriptionOverflowPolicy(OverflowCoalesce))
+		require.Equal(t, 1, cap(sub.stream))
+		for i := 1; i <= 5; i++ {
+			require.NoError(t, sub.deliver(ctx, i))
+		}
+		require.Equal(t, []interface{}{5}, drain(sub))
+		require.Equal(t, uint64(4), sub.Dropped())
+	})
+
+	t.Run("block", func(t *testing.T) {
+		sub := newTestSubscription(SubscriptionBufferSize(1), SubscriptionOverflowPolicy(OverflowBlock))
+		require.NoError(t, sub.deliver(ctx, 1))
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			require.NoError(t, sub.deliver(ctx, 2))
+		}()
+		require.Equal(t, 1, <-sub.stream)
+		<-done
+		require.Equal(t, []interface{}{2}, drain(sub))
+		require.Zero(t, sub.Dropped())
+
+		// a cancelled context releases a blocked delivery
+		cctx, cancel := context.WithCancel(ctx)
+		require.NoError(t, sub.deliver(cctx, 3))
+		cancel()
+		require.NoError(t, sub.deliver(cctx, 4))
+	})
+}
@@ -0,0 +1,213 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/scatkit/pumpdexer/rpc"
+	"github.com/scatkit/pumpdexer/solana"
+	"go.uber.org/zap"
+)
+
+// Max number of server subscriptions per connection when none is provided.
+var DefaultMaxSubscriptionsPerConn = 100
+
+var ErrPoolExhausted = errors.New("ws pool: every connection reached its subscription cap")
+
+type PoolOptions struct {
+	// Options used to dial every underlying connection.
+	ClientOptions *Options
+	// Max number of server subscriptions on a single connection.
+	MaxSubscriptionsPerConn int
+	// Max number of connections; 0 means no limit.
+	MaxConns int
+}
+
+// Pool spreads subscriptions over several websocket connections.
+// A connection carries at most MaxSubscriptionsPerConn server subscriptions,
+// new connections are opened when every existing one is full.
+// Identical subscriptions are routed to the same connection so they are shared (see serverSubscription).
+type Pool struct {
+	rpcURL   string
+	opts     PoolOptions
+	lock     sync.Mutex
+	clients  []*Client
+	isClosed bool
+	// closed when the connection being dialed, if any, is open (or failed)
+	dialing chan struct{}
+}
+
+func ConnectPool(ctx context.Context, rpcEndpoint string) (*Pool, error) {
+	return ConnectPoolWithOptions(ctx, rpcEndpoint, nil)
+}
+
+// ConnectPoolWithOptions opens the first connection right away so dial errors surface early.
+func ConnectPoolWithOptions(ctx context.Context, rpcEndpoint string, opts *PoolOptions) (*Pool, error) {
+	pool := &Pool{rpcURL: rpcEndpoint}
+	if opts != nil {
+		pool.opts = *opts
+	}
+	if pool.opts.MaxSubscriptionsPerConn <= 0 {
+		pool.opts.MaxSubscriptionsPerConn = DefaultMaxSubscriptionsPerConn
+	}
+
+	client, err := ConnectWithOptions(ctx, rpcEndpoint, pool.opts.ClientOptions)
+	if err != nil {
+		return nil, err
+	}
+	pool.clients = append(pool.clients, client)
+	return pool, nil
+}
+
+func (p *Pool) Close() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for _, cl := range p.clients {
+		cl.Close()
+	}
+	p.clients = nil
+	p.isClosed = true
+}
+
+// NumConns returns the number of open connections.
+func (p *Pool) NumConns() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return len(p.clients)
+}
+
+// pick returns the connection the subscription should go to, with the lock held: the caller
+// subscribes on it, then releases the lock. A new connection is dialed with ctx, without the lock,
+// so a slow endpoint doesn't block the callers that have room on the open connections;
+// callers needing a new connection meanwhile wait for that dial.
+func (p *Pool) pick(ctx context.Context, method string, params []interface{}, conf map[string]interface{}) (*Client, error) {
+	key, err := subscriptionKey(method, params, conf)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.lock.Lock()
+		cl, err := p.pickOpen(key)
+		if cl != nil {
+			return cl, nil
+		}
+		if err != nil {
+			p.lock.Unlock()
+			return nil, err
+		}
+		if dialing := p.dialing; dialing != nil {
+			p.lock.Unlock()
+			select {
+			case <-dialing:
+				continue
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		if p.opts.MaxConns > 0 && len(p.clients) >= p.opts.MaxConns {
+			p.lock.Unlock()
+			return nil, ErrPoolExhausted
+		}
+
+		done := make(chan struct{})
+		p.dialing = done
+		p.lock.Unlock()
+		// HandshakeTimeout bounds the dial too
+		client, err := ConnectWithOptions(ctx, p.rpcURL, p.opts.ClientOptions)
+		p.lock.Lock()
+		p.dialing = nil
+		close(done)
+		if err == nil && p.isClosed {
+			client.Close()
+			err = errors.New("ws pool: closed")
+		}
+		if err != nil {
+			p.lock.Unlock()
+			return nil, err
+		}
+		p.clients = append(p.clients, client)
+		zlog.Info("opened new connection in ws pool", zap.Int("connections", len(p.clients)))
+		p.lock.Unlock()
+	}
+}
+
+// pickOpen returns the open connection the subscription should go to, or nil if they are all full.
+// The caller must hold the lock.
+func (p *Pool) pickOpen(key string) (*Client, error) {
+	if p.isClosed {
+		return nil, errors.New("ws pool: closed")
+	}
+
+	// forget the connections that died
+	alive := p.clients[:0]
+	for _, cl := range p.clients {
+		if cl.connCtx.Err() != nil {
+			cl.Close()
+			continue
+		}
+		alive = append(alive, cl)
+	}
+	p.clients = alive
+
+	// an identical subscription is shared, it does not count against the cap
+	var leastLoaded *Client
+	leastCount := p.opts.MaxSubscriptionsPerConn
+	for _, cl := range p.clients {
+		if cl.hasSubscription(key) {
+			return cl, nil
+		}
+		if count := cl.numSubscriptions(); count < leastCount {
+			leastLoaded, leastCount = cl, count
+		}
+	}
+	return leastLoaded, nil
+}
+
+func (p *Pool) AccountSubscribeWithOpts(account solana.PublicKey, commitment rpc.CommitmentType, encoding solana.EncodingType,
+	opts ...SubscriptionOption,
+) (*AccountSubscription, error) {
+	return p.AccountSubscribeWithOptsContext(context.Background(), account, commitment, encoding, opts...)
+}
+
+// AccountSubscribeWithOptsContext is AccountSubscribeWithOpts; ctx bounds the dial of a new connection, if one is needed.
+func (p *Pool) AccountSubscribeWithOptsContext(ctx context.Context, account solana.PublicKey, commitment rpc.CommitmentType, encoding solana.EncodingType,
+	opts ...SubscriptionOption,
+) (*AccountSubscription, error) {
+	params, conf := accountSubscribeParams(account, commitment, encoding)
+	cl, err := p.pick(ctx, "accountSubscribe", params, conf)
+	if err != nil {
+		return nil, err
+	}
+	defer p.lock.Unlock()
+	return cl.AccountSubscribeWithOpts(account, commitment, encoding, opts...)
+}
+
+func (p *Pool) LogSubscribe(filter LogsSubscribeFilterType, commitment rpc.CommitmentType, opts ...SubscriptionOption) (*LogSubscription, error) {
+	return p.logSubscribe(context.Background(), filter, commitment, opts...)
+}
+
+// LogSubscribeContext is LogSubscribe; ctx bounds the dial of a new connection, if one is needed.
+func (p *Pool) LogSubscribeContext(ctx context.Context, filter LogsSubscribeFilterType, commitment rpc.CommitmentType, opts ...SubscriptionOption) (*LogSubscription, error) {
+	return p.logSubscribe(ctx, filter, commitment, opts...)
+}
+
+func (p *Pool) LogSubscribeToAddress(mentions solana.PublicKey, commitment rpc.CommitmentType, opts ...SubscriptionOption) (*LogSubscription, error) {
+	return p.logSubscribe(context.Background(), mentionsFilter(mentions), commitment, opts...)
+}
+
+// LogSubscribeToAddressContext is LogSubscribeToAddress; ctx bounds the dial of a new connection, if one is needed.
+func (p *Pool) LogSubscribeToAddressContext(ctx context.Context, mentions solana.PublicKey, commitment rpc.CommitmentType, opts ...SubscriptionOption) (*LogSubscription, error) {
+	return p.logSubscribe(ctx, mentionsFilter(mentions), commitment, opts...)
+}
+
+func (p *Pool) logSubscribe(ctx context.Context, filter interface{}, commitment rpc.CommitmentType, opts ...SubscriptionOption) (*LogSubscription, error) {
+	params, conf := logSubscribeParams(filter, commitment)
+	cl, err := p.pick(ctx, "logsSubscribe", params, conf)
+	if err != nil {
+		return nil, err
+	}
+	defer p.lock.Unlock()
+	return cl.logSubscribe(filter, commitment, opts...)
+}
@@ -0,0 +1,98 @@
+package ws
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/scatkit/pumpdexer/rpc"
+	"github.com/scatkit/pumpdexer/solana"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPool_SpreadsSubscriptions(t *testing.T) {
+	srv := newWSTestServer(t)
+	pool, err := ConnectPoolWithOptions(context.Background(), srv.WSURL(), &PoolOptions{MaxSubscriptionsPerConn: 2, MaxConns: 2})
+	require.NoError(t, err)
+	defer pool.Close()
+
+	accounts := []solana.PublicKey{
+		solana.MustPubkeyFromBase58("GJVvpsKp5snWU4VVZa71yeNnLCRckecooqEhiyRSszMC"),
+		solana.MustPubkeyFromBase58("6tpCWpvihiRkF3G7ZKGE8T3jCbMs8kvxsw8hRz1JJz6Z"),
+		solana.MustPubkeyFromBase58("675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8"),
+	}
+	for _, account := range accounts {
+		_, err := pool.AccountSubscribeWithOpts(account, rpc.CommitmentConfirmed, "")
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2, pool.NumConns())
+	require.Equal(t, 2, srv.NumConns())
+
+	// identical subscriptions are shared and do not count against the cap
+	_, err = pool.AccountSubscribeWithOpts(accounts[0], rpc.CommitmentConfirmed, "")
+	require.NoError(t, err)
+	require.Len(t, srv.waitForMethods(t, 3), 3)
+
+	logs, err := pool.LogSubscribeToAddress(accounts[2], rpc.CommitmentConfirmed)
+	require.NoError(t, err)
+	require.Equal(t, "logsSubscribe", srv.waitForMethods(t, 4)[3])
+
+	// both connections are full and the pool may not grow
+	_, err = pool.LogSubscribe(LogsSubcribeFilterAll, rpc.CommitmentConfirmed)
+	require.ErrorIs(t, err, ErrPoolExhausted)
+
+	// leaving frees a slot
+	logs.Unsubscribe()
+	_, err = pool.LogSubscribe(LogsSubcribeFilterAll, rpc.CommitmentConfirmed)
+	require.NoError(t, err)
+	require.Equal(t, 2, pool.NumConns())
+}
+
+func TestPool_DialDoesNotBlock(t *testing.T) {
+	srv := newWSTestServer(t)
+	pool, err := ConnectPoolWithOptions(context.Background(), srv.WSURL(), &PoolOptions{MaxSubscriptionsPerConn: 1})
+	require.NoError(t, err)
+	defer pool.Close()
+	account := solana.MustPubkeyFromBase58("GJVvpsKp5snWU4VVZa71yeNnLCRckecooqEhiyRSszMC")
+	_, err = pool.AccountSubscribeWithOpts(account, rpc.CommitmentConfirmed, "")
+	require.NoError(t, err)
+
+	// The next connection goes to an endpoint that never answers the handshake.
+	hung, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer hung.Close()
+	pool.rpcURL = "ws://" + hung.Addr().String()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	dialed := make(chan error, 1)
+	go func() {
+		_, err := pool.LogSubscribeContext(ctx, LogsSubcribeFilterAll, rpc.CommitmentConfirmed)
+		dialed <- err
+	}()
+	require.Eventually(t, func() bool {
+		pool.lock.Lock()
+		defer pool.lock.Unlock()
+		return pool.dialing != nil
+	}, 2*time.Second, time.Millisecond)
+
+	// Meanwhile the open connection still serves the subscriptions it has room for.
+	_, err = pool.AccountSubscribeWithOpts(account, rpc.CommitmentConfirmed, "")
+	require.NoError(t, err)
+	require.Equal(t, 1, pool.NumConns())
+
+	// A caller that needs a connection too gives up with its own ctx.
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer waitCancel()
+	_, err = pool.LogSubscribeToAddressContext(waitCtx, account, rpc.CommitmentConfirmed)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	cancel()
+	select {
+	case err := <-dialed:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(2 * time.Second):
+		t.Fatal("the dial was not cancelled")
+	}
+	require.Equal(t, 1, pool.NumConns())
+}
@@ -0,0 +1,53 @@
+package ws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/scatkit/pumpdexer/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// wsTestServer adds the helpers of the ws tests to testutil.Server.
+type wsTestServer struct {
+	*testutil.Server
+}
+
+func newWSTestServer(t *testing.T) *wsTestServer {
+	srv := &wsTestServer{Server: testutil.NewServer()}
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// waitForMethods blocks until the server has received (and acknowledged) `count` requests.
+func (srv *wsTestServer) waitForMethods(t *testing.T, count int) []string {
+	require.Eventually(t, func() bool { return len(srv.Methods()) >= count }, 2*time.Second, 5*time.Millisecond)
+	return srv.Methods()
+}
+
+// notify sends an account notification to every account subscription.
+func (srv *wsTestServer) notify(t *testing.T, result interface{}) {
+	require.NotZero(t, srv.Notify("accountSubscribe", nil, result))
+}
+
+// flood pushes `count` account notifications, stopping when the client goes away.
+func (srv *wsTestServer) flood(count int) {
+	for i := 0; i < count; i++ {
+		if srv.Notify("accountSubscribe", nil, accountNotificationResult(uint64(i), uint64(i))) == 0 {
+			return
+		}
+	}
+}
+
+func accountNotificationResult(slot uint64, lamports uint64) map[string]interface{} {
+	return map[string]interface{}{
+		"context": map[string]interface{}{"slot": slot},
+		"value": map[string]interface{}{
+			"lamports":   lamports,
+			"owner":      "11111111111111111111111111111111",
+			"data":       []string{"", "base64"},
+			"executable": false,
+			"rentEpoch":  0,
+		},
+	}
+}
@@ -0,0 +1,165 @@
+package ws
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/scatkit/pumpdexer/rpc"
+	"github.com/scatkit/pumpdexer/solana"
+	"github.com/stretchr/testify/require"
+)
+
+var testPool = solana.MustPubkeyFromBase58("GJVvpsKp5snWU4VVZa71yeNnLCRckecooqEhiyRSszMC")
+
+func connectTestClient(t *testing.T) (*wsTestServer, *Client) {
+	srv := newWSTestServer(t)
+	cl, err := Connect(context.Background(), srv.WSURL())
+	require.NoError(t, err)
+	t.Cleanup(cl.Close)
+	return srv, cl
+}
+
+func waitDone(t *testing.T, done <-chan struct{}) {
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("subscription was not closed")
+	}
+}
+
+func TestSubscription_ConcurrentUnsubscribe(t *testing.T) {
+	srv, cl := connectTestClient(t)
+
+	sub, err := cl.AccountSubscribeWithOpts(testPool, rpc.CommitmentConfirmed, "", SubscriptionBufferSize(8), SubscriptionOverflowPolicy(OverflowDropOldest))
+	require.NoError(t, err)
+	srv.waitForMethods(t, 1)
+
+	flooded := make(chan struct{})
+	go func() {
+		defer close(flooded)
+		srv.flood(500)
+	}()
+
+	received := make(chan error, 1)
+	go func() {
+		for {
+			if _, err := sub.Recv(context.Background()); err != nil {
+				received <- err
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			time.Sleep(time.Millisecond)
+			sub.Unsubscribe()
+		}()
+	}
+	wg.Wait()
+	<-flooded
+
+	waitDone(t, sub.Done())
+	require.ErrorIs(t, <-received, ErrSubscriptionClosed)
+	// exactly one unsubscribe call reached the node
+	require.Equal(t, []string{"accountSubscribe", "accountUnsubscribe"}, srv.waitForMethods(t, 2))
+}
+
+func TestSubscription_OverflowCloseWhileConsuming(t *testing.T) {
+	srv, cl := connectTestClient(t)
+
+	sub, err := cl.AccountSubscribeWithOpts(testPool, rpc.CommitmentConfirmed, "", SubscriptionBufferSize(1))
+	require.NoError(t, err)
+	srv.waitForMethods(t, 1)
+	srv.flood(50)
+
+	waitDone(t, sub.Done())
+	var lastErr error
+	for lastErr == nil {
+		_, lastErr = sub.Recv(context.Background())
+	}
+	require.ErrorContains(t, lastErr, "max cap")
+
+	// closing again from the outside is harmless
+	sub.Unsubscribe()
+	sub.Unsubscribe()
+}
+
+func TestSubscription_SharedSubscribersChurn(t *testing.T) {
+	srv, cl := connectTestClient(t)
+
+	keep, err := cl.AccountSubscribeWithOpts(testPool, rpc.CommitmentConfirmed, "")
+	require.NoError(t, err)
+	srv.waitForMethods(t, 1)
+	go srv.flood(300)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sub, err := cl.AccountSubscribeWithOpts(testPool, rpc.CommitmentConfirmed, "", SubscriptionOverflowPolicy(OverflowCoalesce))
+			if err != nil {
+				return
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+			sub.Recv(ctx)
+			sub.Unsubscribe()
+		}()
+	}
+	wg.Wait()
+
+	// the subscription that stayed is still alive and shared nothing with the leavers' lifecycles
+	select {
+	case <-keep.Done():
+		t.Fatal("shared subscription closed by other subscribers")
+	default:
+	}
+	require.NotContains(t, srv.Methods(), "accountUnsubscribe")
+	keep.Unsubscribe()
+}
+
+func TestClient_CloseEndsSubscriptions(t *testing.T) {
+	srv, cl := connectTestClient(t)
+
+	acc, err := cl.AccountSubscribeWithOpts(testPool, rpc.CommitmentConfirmed, "", SubscriptionOverflowPolicy(OverflowBlock), SubscriptionBufferSize(1))
+	require.NoError(t, err)
+	logs, err := cl.LogSubscribe(LogsSubcribeFilterAll, rpc.CommitmentConfirmed)
+	require.NoError(t, err)
+	srv.waitForMethods(t, 2)
+
+	// the reader is blocked on the full stream when the client closes
+	go srv.flood(10)
+	time.Sleep(20 * time.Millisecond)
+	cl.Close()
+
+	waitDone(t, acc.Done())
+	waitDone(t, logs.Done())
+	_, err = logs.Recv(context.Background())
+	require.ErrorIs(t, err, ErrClientClosed)
+
+	// Nothing is written to the closed connection.
+	logs.Unsubscribe()
+	_, err = cl.LogSubscribe(LogsSubcribeFilterAll, rpc.CommitmentConfirmed)
+	require.ErrorIs(t, err, ErrClientClosed)
+	cl.Close()
+	require.Len(t, srv.Methods(), 2)
+}
+
+func TestClient_CloseErrorWins(t *testing.T) {
+	// The subscriptions end with ErrClientClosed, never with the read error of the closed connection.
+	for i := 0; i < 50; i++ {
+		_, cl := connectTestClient(t)
+		sub, err := cl.LogSubscribe(LogsSubcribeFilterAll, rpc.CommitmentConfirmed)
+		require.NoError(t, err)
+		cl.Close()
+		_, err = sub.Recv(context.Background())
+		require.ErrorIs(t, err, ErrClientClosed)
+	}
+}
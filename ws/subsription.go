@@ -1,39 +1,133 @@
 package ws
 
+import (
+  "context"
+  "errors"
+  "fmt"
+  "sync"
+  "sync/atomic"
+)
+
+var ErrSubscriptionClosed = errors.New("subscription is closed")
+
+// Subscription is a local subscriber. Several of them may share
+// the same server subscription (see serverSubscription).
+//
+// The `stream` and `err` channels are never closed, so the client can't panic
+// by sending on them. The end of the subscription is signaled by closing `done`,
+// which happens exactly once no matter which goroutine closes it.
 type Subscription struct{
-  req               *request 
-  subID             uint64
+  server            *serverSubscription
   stream            chan interface{} // channel that accepts the result (interface)
-  err               chan error 
-  closeFunc         func(err error) // client's method closeSubscription(req.ID, err)
-  closed            bool
-  unsubscribeMethod string
-  decoderFunc       decoderFunc
+  err               chan error // holds the error that closed the subscription (if any)
+  done              chan struct{}
+  closeOnce         sync.Once
+  closeErr          error // set before `done` is closed
+  closeFunc         func() // client's method removeSubscriber(sub, nil)
+  overflow          OverflowPolicy
+  dropped           atomic.Uint64 // messages discarded by the overflow policy
 }
 
 type decoderFunc func([]byte) (interface{}, error)
 
-func newSubscription(req *request, closeFunc func(err error), unsubMethod string, decoderFunc decoderFunc,
-) *Subscription{
-  
+func newSubscription(opts subscriptionOptions) *Subscription{
   return &Subscription{
-    req:      req,
-    subID:    0,
-    stream:   make(chan interface{}, 200_000),
-    err:      make(chan error, 100_000),
-    closeFunc:  closeFunc,
-    unsubscribeMethod: unsubMethod,
-    decoderFunc: decoderFunc,
+    stream:   make(chan interface{}, opts.bufferSize),
+    err:      make(chan error, 1),
+    done:     make(chan struct{}),
+    overflow: opts.overflow,
   }
 }
 
-func (s *Subscription) Unsubscribe(){
-  s.unsubscribe(nil)
+// deliver pushes the result onto the stream following the overflow policy.
+// Returns an error only when the policy is OverflowClose and the stream is full.
+func (s *Subscription) deliver(ctx context.Context, result interface{}) error{
+  select{
+  case <-s.done:
+    return nil
+  default:
+  }
+
+  if s.overflow == OverflowBlock{
+    select{
+    case s.stream <- result:
+    case <-s.done:
+    case <-ctx.Done():
+    }
+    return nil
+  }
+
+  select{
+  case s.stream <- result:
+    return nil
+  default:
+  }
+
+  switch s.overflow{
+  case OverflowDropNewest:
+    s.dropped.Add(1)
+  case OverflowDropOldest, OverflowCoalesce:
+    // make room by discarding the oldest pending value (the only one when coalescing)
+    select{
+    case <-s.stream:
+      s.dropped.Add(1)
+    default:
+    }
+    select{
+    case s.stream <- result:
+    default:
+      s.dropped.Add(1)
+    }
+  default:
+    return fmt.Errorf("reached channel's max cap %d", cap(s.stream))
+  }
+  return nil
 }
 
-func (s *Subscription) unsubscribe(err error){
-  s.closeFunc(err) 
-  s.closed = true 
-  close(s.stream)
-  close(s.err)
+// close ends the subscription with an optional error.
+// It is idempotent and safe to call from any goroutine; only the first error is kept.
+func (s *Subscription) close(err error){
+  s.closeOnce.Do(func(){
+    s.closeErr = err
+    if err != nil{
+      s.err <- err // never blocks: buffered and sent only once
+    }
+    close(s.done)
+  })
+}
+
+// Done returns a channel that's closed when the subscription ends,
+// either through Unsubscribe or because the client closed it with an error.
+func (s *Subscription) Done() <-chan struct{}{
+  return s.done
+}
+
+// Dropped returns the number of messages discarded by the overflow policy.
+func (s *Subscription) Dropped() uint64{
+  return s.dropped.Load()
+}
+
+// recv waits for the next result; shared by the typed subscriptions.
+func (s *Subscription) recv(ctx context.Context) (interface{}, error){
+  select{
+  case <-ctx.Done():
+    return nil, ctx.Err()
+  case d := <-s.stream:
+    return d, nil
+  case err := <-s.err:
+    return nil, err
+  case <-s.done:
+    if s.closeErr != nil{
+      return nil, s.closeErr
+    }
+    return nil, ErrSubscriptionClosed
+  }
+}
+
+// Unsubscribe is idempotent and safe to call from any goroutine.
+func (s *Subscription) Unsubscribe(){
+  s.close(nil)
+  if s.closeFunc != nil{
+    s.closeFunc()
+  }
 }